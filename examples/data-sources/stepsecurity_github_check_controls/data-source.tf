@@ -0,0 +1,46 @@
+terraform {
+  required_providers {
+    stepsecurity = {
+      source = "step-security/stepsecurity"
+    }
+  }
+}
+
+provider "stepsecurity" {
+  api_key  = "xxxxxxxx" # can also be set as env variable STEP_SECURITY_API_KEY
+  customer = "abcdefg"  # can also be set as env variable STEP_SECURITY_CUSTOMER
+}
+
+# Retrieve the controls available for GitHub checks of an organization
+data "stepsecurity_github_check_controls" "available" {
+  owner = "my-org"
+}
+
+# Values to use for `control` in stepsecurity_github_checks
+output "controls" {
+  value = [for c in data.stepsecurity_github_check_controls.available.controls : c.control]
+}
+
+# Settings each control accepts, with their type and default
+output "control_settings" {
+  value = {
+    for c in data.stepsecurity_github_check_controls.available.controls :
+    c.control => { for s in c.settings : s.key => "${s.type} (default: ${coalesce(s.default, "none")})" }
+    if length(c.settings) > 0
+  }
+}
+
+# Enable every available control as an optional check
+resource "stepsecurity_github_checks" "all_controls" {
+  owner = "my-org"
+  controls = [
+    for c in data.stepsecurity_github_check_controls.available.controls : {
+      control = c.control
+      enable  = true
+      type    = "optional"
+    } if contains(c.types, "optional")
+  ]
+  optional_checks = {
+    repos = ["*"]
+  }
+}
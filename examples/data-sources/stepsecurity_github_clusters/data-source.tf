@@ -0,0 +1,32 @@
+terraform {
+  required_providers {
+    stepsecurity = {
+      source = "step-security/stepsecurity"
+    }
+  }
+}
+
+provider "stepsecurity" {
+  api_key  = "xxxxxxxx" # can also be set as env variable STEP_SECURITY_API_KEY
+  customer = "abcdefg"  # can also be set as env variable STEP_SECURITY_CUSTOMER
+}
+
+# Retrieve the self-hosted runner clusters reporting to StepSecurity
+data "stepsecurity_github_clusters" "all" {
+  owner = "my-org"
+}
+
+# Runner scale sets of each cluster
+output "runner_scale_sets" {
+  value = {
+    for c in data.stepsecurity_github_clusters.all.clusters :
+    c.name => [for s in c.runner_scale_sets : "${s.namespace}/${s.name}"]
+  }
+}
+
+# Attach a policy to every production cluster
+resource "stepsecurity_github_policy_store_attachment" "prod_clusters" {
+  owner       = "my-org"
+  policy_name = "block-policy"
+  clusters    = [for name in data.stepsecurity_github_clusters.all.names : name if startswith(name, "prod-")]
+}
@@ -0,0 +1,26 @@
+terraform {
+  required_providers {
+    stepsecurity = {
+      source = "step-security/stepsecurity"
+    }
+  }
+}
+
+provider "stepsecurity" {
+  api_key  = "xxxxxxxx" # can also be set as env variable STEP_SECURITY_API_KEY
+  customer = "abcdefg"  # can also be set as env variable STEP_SECURITY_CUSTOMER
+}
+
+# Retrieve the PR template policy-driven PRs of a repository use
+data "stepsecurity_github_effective_pr_template" "payments" {
+  owner = "test-organization"
+  repo  = "payments-monorepo"
+}
+
+output "payments_pr_template_source" {
+  value = data.stepsecurity_github_effective_pr_template.payments.source
+}
+
+output "payments_pr_preview" {
+  value = data.stepsecurity_github_effective_pr_template.payments.rendered_preview
+}
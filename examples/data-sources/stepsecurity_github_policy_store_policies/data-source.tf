@@ -0,0 +1,34 @@
+terraform {
+  required_providers {
+    stepsecurity = {
+      source = "step-security/stepsecurity"
+    }
+  }
+}
+
+provider "stepsecurity" {
+  api_key  = "xxxxxxxx" # can also be set as env variable STEP_SECURITY_API_KEY
+  customer = "abcdefg"  # can also be set as env variable STEP_SECURITY_CUSTOMER
+}
+
+# Retrieve all Harden-Runner policies of an organization
+data "stepsecurity_github_policy_store_policies" "all" {
+  owner = "my-org"
+}
+
+# Policies that only audit egress traffic
+output "audit_mode_policies" {
+  value = [
+    for p in data.stepsecurity_github_policy_store_policies.all.policies : p.policy_name
+    if p.egress_policy == "audit"
+  ]
+}
+
+# Repositories each block mode policy is attached to
+output "block_mode_repositories" {
+  value = {
+    for p in data.stepsecurity_github_policy_store_policies.all.policies :
+    p.policy_name => p.attachments == null ? [] : [for r in p.attachments.repositories : r.name]
+    if p.egress_policy == "block"
+  }
+}
@@ -0,0 +1,26 @@
+terraform {
+  required_providers {
+    stepsecurity = {
+      source = "step-security/stepsecurity"
+    }
+  }
+}
+
+provider "stepsecurity" {
+  api_key  = "xxxxxxxx" # can also be set as env variable STEP_SECURITY_API_KEY
+  customer = "abcdefg"  # can also be set as env variable STEP_SECURITY_CUSTOMER
+}
+
+# Retrieve StepSecurity's default PR template
+data "stepsecurity_github_pr_template_default" "this" {
+  owner = "my-org"
+}
+
+# Extend the default template with a team-specific section and label
+resource "stepsecurity_github_pr_template" "this" {
+  owner          = "my-org"
+  title          = data.stepsecurity_github_pr_template_default.this.title
+  summary        = "${data.stepsecurity_github_pr_template_default.this.summary}\n## Review\nPing #platform-security in Slack for questions.\n"
+  commit_message = data.stepsecurity_github_pr_template_default.this.commit_message
+  labels         = concat(data.stepsecurity_github_pr_template_default.this.labels, ["security"])
+}
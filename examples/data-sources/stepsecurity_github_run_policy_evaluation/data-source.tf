@@ -0,0 +1,32 @@
+terraform {
+  required_providers {
+    stepsecurity = {
+      source = "step-security/stepsecurity"
+    }
+  }
+}
+
+provider "stepsecurity" {
+  api_key  = "xxxxxxxx" # can also be set as env variable STEP_SECURITY_API_KEY
+  customer = "abcdefg"  # can also be set as env variable STEP_SECURITY_CUSTOMER
+}
+
+# Evaluate a workflow against every run policy that applies to the repository
+data "stepsecurity_github_run_policy_evaluation" "ci" {
+  owner    = "my-org"
+  repo     = "my-repo"
+  workflow = file("${path.module}/.github/workflows/ci.yml")
+}
+
+# Whether the workflow would be blocked once dry-run policies are enforced
+output "would_be_blocked" {
+  value = data.stepsecurity_github_run_policy_evaluation.ci.would_be_blocked
+}
+
+# List violations per job and step
+output "violations" {
+  value = [
+    for v in data.stepsecurity_github_run_policy_evaluation.ci.violations :
+    "${v.policy_name}: ${v.job}${v.step != "" ? " / ${v.step}" : ""}: ${v.message}"
+  ]
+}
@@ -0,0 +1,36 @@
+terraform {
+  required_providers {
+    stepsecurity = {
+      source = "step-security/stepsecurity"
+    }
+  }
+}
+
+provider "stepsecurity" {
+  api_key  = "xxxxxxxx" # can also be set as env variable STEP_SECURITY_API_KEY
+  customer = "abcdefg"  # can also be set as env variable STEP_SECURITY_CUSTOMER
+}
+
+# Endpoints Harden-Runner observed while the workflow ran in audit mode
+data "stepsecurity_github_workflow_observed_endpoints" "ci" {
+  owner                         = "my-org"
+  repo                          = "api"
+  workflow                      = "ci.yml"
+  include_recommended_endpoints = true
+}
+
+# Block egress traffic to anything the workflow was not observed calling
+resource "stepsecurity_github_policy_store" "ci" {
+  owner             = "my-org"
+  policy_name       = "api-ci"
+  egress_policy     = "block"
+  allowed_endpoints = data.stepsecurity_github_workflow_observed_endpoints.ci.allowed_endpoints
+}
+
+# Review what each job called before switching to block mode
+output "observed_endpoints" {
+  value = {
+    for e in data.stepsecurity_github_workflow_observed_endpoints.ci.endpoints :
+    e.endpoint => e.jobs
+  }
+}
@@ -39,16 +39,25 @@ resource "stepsecurity_github_checks" "test-organization" {
       control = "PyPI Package Compromised Updates"
       enable  = true
       type    = "required"
+      settings = {
+        packages_to_exempt_in_compromised_updates_check = ["my-internal-package"]
+      }
     },
     {
       control = "Script Injection"
       enable  = true
       type    = "optional"
+      settings = {
+        exempted_workflows = [".github/workflows/release.yml"]
+        allowed_triggers   = ["workflow_dispatch"]
+        severity_threshold = "high"
+      }
     }
   ]
   required_checks = {
     repos = ["*"] # applies to all repositories in the organization
   }
+  enable_required_checks_for_new_repos = false # '*' covers the repositories that exist at apply; new repositories are enrolled on the next apply
   optional_checks = {
     repos = ["test-repo-1"] # applies to only test-repo-1
   }
@@ -69,7 +78,8 @@ import {
 
 # github PR checks configuration with PyPI controls
 resource "stepsecurity_github_checks" "test-organization-pypi" {
-  owner = "test-organization"
+  owner      = "test-organization"
+  on_destroy = "restore_previous" # restores the checks configuration that existed before this resource was created
   controls = [
     {
       control = "PyPI Package Cooldown"
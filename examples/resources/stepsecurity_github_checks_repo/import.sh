@@ -0,0 +1,5 @@
+#!/bin/bash
+
+# A repository's checks enrollment can be imported using the owner and repository name separated by a forward slash
+# Format: owner/repo
+terraform import stepsecurity_github_checks_repo.payments_api my-org/payments-api
@@ -0,0 +1,46 @@
+terraform {
+  required_providers {
+    stepsecurity = {
+      source = "step-security/stepsecurity"
+    }
+  }
+}
+
+provider "stepsecurity" {
+  api_key  = "xxxxxxxx" # can also be set as env variable STEP_SECURITY_API_KEY
+  customer = "abcdefg"  # can also be set as env variable STEP_SECURITY_CUSTOMER
+}
+
+# Org-wide controls are managed once. Leaving out required_checks, optional_checks and
+# baseline_check leaves repository enrollment to stepsecurity_github_checks_repo.
+resource "stepsecurity_github_checks" "controls" {
+  owner = "my-org"
+
+  controls = [
+    {
+      control = "Script Injection"
+      enable  = true
+      type    = "required"
+    },
+    {
+      control = "NPM Package Cooldown"
+      enable  = true
+      type    = "optional"
+    },
+  ]
+}
+
+# Each team enrolls its own repositories, e.g. from the team's own configuration.
+resource "stepsecurity_github_checks_repo" "payments_api" {
+  owner               = "my-org"
+  repo                = "payments-api"
+  baseline            = true
+  run_required_checks = true
+  run_optional_checks = true
+}
+
+resource "stepsecurity_github_checks_repo" "website" {
+  owner               = "my-org"
+  repo                = "website"
+  run_required_checks = true
+}
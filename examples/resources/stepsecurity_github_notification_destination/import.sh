@@ -0,0 +1,5 @@
+#!/bin/bash
+
+# Notification destinations can be imported using the owner and destination ID separated by a forward slash
+# Format: owner/destination_id
+terraform import stepsecurity_github_notification_destination.security_slack my-org/destination-id-12345
@@ -11,7 +11,8 @@ provider "stepsecurity" {
   customer = "abcdefg"  # can also be set as env variable STEP_SECURITY_CUSTOMER
 }
 
-# Policy with block mode and basic endpoints
+# Policy with block mode and basic endpoints. Endpoints are host:port;
+# "*.domain:port" allows every subdomain of domain.
 resource "stepsecurity_github_policy_store" "audit-policy" {
   owner         = "test-organization"
   policy_name   = "audit-policy"
@@ -19,7 +20,8 @@ resource "stepsecurity_github_policy_store" "audit-policy" {
   allowed_endpoints = [
     "github.com:443",
     "api.github.com:443",
-    "registry.npmjs.org:443"
+    "registry.npmjs.org:443",
+    "*.actions.githubusercontent.com:443"
   ]
 }
 
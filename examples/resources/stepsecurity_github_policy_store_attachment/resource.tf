@@ -72,6 +72,25 @@ resource "stepsecurity_github_policy_store_attachment" "cluster-attachment" {
   ]
 }
 
+# Policy attachment to some namespaces and runner scale sets of a cluster
+resource "stepsecurity_github_policy_store_attachment" "cluster-scope-attachment" {
+  owner       = "test-organization"
+  policy_name = "namespace-policy"
+
+  cluster_scopes = [
+    {
+      name       = "production-k8s-cluster"
+      namespaces = ["payments"]
+      runner_scale_sets = [
+        {
+          name      = "arc-runner-set"
+          namespace = "arc-runners"
+        }
+      ]
+    }
+  ]
+}
+
 # Complex attachment with mixed org and cluster attachments
 resource "stepsecurity_github_policy_store_attachment" "mixed-attachment" {
   owner       = "test-organization"
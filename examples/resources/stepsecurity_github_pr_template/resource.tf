@@ -19,7 +19,7 @@ resource "stepsecurity_github_pr_template" "example" {
   summary        = <<-EOT
     ## Summary
 
-    This pull request has been generated by [StepSecurity](https://int1.stepsecurity.io/github/shubham-stepsecurity/actions/dashboard) as part of your enterprise subscription to ensure compliance with recommended security best practices. Please review and merge the pull request to apply these security enhancements.
+    This pull request has been generated by [StepSecurity](https://www.stepsecurity.io/) as part of your enterprise subscription to ensure compliance with recommended security best practices. Please review and merge the pull request to apply these security enhancements.
 
     ## Security Fixes
 
@@ -34,6 +34,17 @@ resource "stepsecurity_github_pr_template" "example" {
     Signed-off-by: StepSecurity Bot <bot@stepsecurity.io>
   EOT
   labels         = ["security", "automated"]
+
+  # Route PRs to the platform security team and open them as drafts
+  reviewers      = ["octocat"]
+  team_reviewers = ["platform-security"]
+  assignees      = ["octocat"]
+  draft          = true
+
+  # Name head branches security/<generated name>, and add commit trailers
+  branch_prefix = "security/"
+  sign_off      = true
+  co_authors    = ["Mona Lisa <mona@example.com>"]
 }
 
 # For importing existing PR template config to terraform state
@@ -0,0 +1,5 @@
+#!/bin/bash
+
+# Repo notification overrides can be imported using the owner and repository name separated by a forward slash
+# Format: owner/repo
+terraform import stepsecurity_github_repo_notification_override.release my-org/release
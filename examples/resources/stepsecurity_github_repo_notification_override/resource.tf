@@ -0,0 +1,26 @@
+# Alert on more events for the release pipeline and page the on-call team
+resource "stepsecurity_github_repo_notification_override" "release" {
+  owner = "my-org"
+  repo  = "release"
+
+  events = {
+    file_overwrite          = true
+    new_endpoint_discovered = true
+    https_detections        = false
+  }
+
+  destination_ids = [stepsecurity_github_notification_destination.oncall.destination_id]
+}
+
+# Only notify the destinations of a sandbox repository, not the org channels
+resource "stepsecurity_github_repo_notification_override" "sandbox" {
+  owner = "my-org"
+  repo  = "sandbox"
+
+  destination_ids        = [stepsecurity_github_notification_destination.sandbox.destination_id]
+  inherit_owner_channels = false
+}
+
+output "release_effective_events" {
+  value = stepsecurity_github_repo_notification_override.release.effective_events
+}
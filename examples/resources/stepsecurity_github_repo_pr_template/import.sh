@@ -0,0 +1,6 @@
+#!/bin/bash
+
+# Repository PR templates can be imported using the owner and repository name.
+# Format: <owner>/<repo>
+
+terraform import stepsecurity_github_repo_pr_template.payments test-organization/payments-monorepo
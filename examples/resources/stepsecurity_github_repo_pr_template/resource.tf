@@ -0,0 +1,30 @@
+terraform {
+  required_providers {
+    stepsecurity = {
+      source = "step-security/stepsecurity"
+    }
+  }
+}
+
+provider "stepsecurity" {
+  api_key  = "xxxxxxxx" # can also be set as env variable STEP_SECURITY_API_KEY
+  customer = "abcdefg"  # can also be set as env variable STEP_SECURITY_CUSTOMER
+}
+
+# Use a team-specific PR template in the payments monorepo instead of the organization's
+resource "stepsecurity_github_repo_pr_template" "payments" {
+  owner          = "test-organization"
+  repo           = "payments-monorepo"
+  title          = "fix(payments): apply security best practices"
+  summary        = <<-EOT
+    ## Security Fixes
+
+    {{STEPSECURITY_SECURITY_FIXES}}
+
+    Questions? Ping #payments-platform in Slack.
+  EOT
+  commit_message = "fix(payments): apply security best practices"
+  labels         = ["security", "team:payments"]
+  team_reviewers = ["payments-platform"]
+  branch_prefix  = "payments/security/"
+}
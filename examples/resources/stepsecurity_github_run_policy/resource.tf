@@ -345,6 +345,33 @@ resource "stepsecurity_github_run_policy" "repo_specific_runner_policy" {
   }
 }
 
+# Repository Filter Example - Applies a policy to every service repository, including
+# ones created later: private and internal repositories whose name matches svc-*,
+# except legacy services. The filter narrows all_repos and is resolved by
+# StepSecurity, so nothing needs to be re-applied for new repositories.
+resource "stepsecurity_github_run_policy" "service_repos_action_policy" {
+  owner     = "my-org"
+  name      = "Service Repositories Action Policy"
+  all_repos = true
+
+  repository_filter = {
+    include_patterns = ["svc-*"]
+    exclude_patterns = ["svc-legacy-*"]
+    visibility       = ["private", "internal"]
+  }
+
+  policy_config = {
+    owner                  = "my-org"
+    name                   = "Service Repositories Action Policy"
+    enable_action_policy   = true
+    require_pinned_actions = true
+    allowed_actions = {
+      "actions/checkout"            = "allow"
+      "step-security/harden-runner" = "allow"
+    }
+  }
+}
+
 # Allowed Actions Policy Example (all_repos, pinned actions enforcement)
 resource "stepsecurity_github_run_policy" "pinned_actions_policy" {
   owner     = "my-org"
@@ -408,6 +435,29 @@ resource "stepsecurity_github_run_policy" "runner_policy_allowed_mode" {
   }
 }
 
+# Runner Label Policy Example (runs_on block) - The structured runs_on block is an
+# alternative to the flat runs_on_mode / *_runner_labels attributes. A label listed
+# in both allowed_labels and disallowed_labels, or a mode with nothing to evaluate,
+# is rejected at plan time.
+resource "stepsecurity_github_run_policy" "runner_policy_runs_on_block" {
+  owner     = "my-org"
+  name      = "Runner Label Policy - Structured"
+  all_repos = true
+
+  policy_config = {
+    owner                 = "my-org"
+    name                  = "Runner Label Policy - Structured"
+    enable_runs_on_policy = true
+    runs_on = {
+      mode           = "allowed"
+      allowed_labels = ["ubuntu-latest"]
+      allowed_constraints = {
+        family = ["c7a", "m7a"]
+      }
+    }
+  }
+}
+
 # Harden Runner Policy Example (opt-in checks) - In addition to requiring Harden
 # Runner on targeted jobs, require every targeted job to read its configuration
 # from the policy store (use-policy-store: true on the Harden Runner step; the
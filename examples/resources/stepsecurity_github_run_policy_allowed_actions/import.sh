@@ -0,0 +1,7 @@
+#!/bin/bash
+
+# Run policies can be imported using the owner and policy ID separated by a forward slash
+# Format: owner/policy_id
+# Importing a policy of a different type fails; use the resource matching the policy type.
+# Policies that enable several controls can only be managed with stepsecurity_github_run_policy.
+terraform import stepsecurity_github_run_policy_allowed_actions.example my-org/policy-id-12345
@@ -0,0 +1,37 @@
+terraform {
+  required_providers {
+    stepsecurity = {
+      source = "step-security/stepsecurity"
+    }
+  }
+}
+
+provider "stepsecurity" {
+  api_key  = "xxxxxxxx" # can also be set as env variable STEP_SECURITY_API_KEY
+  customer = "abcdefg"  # can also be set as env variable STEP_SECURITY_CUSTOMER
+}
+
+# Allowed Actions Policy - only the listed actions may run in every repository
+resource "stepsecurity_github_run_policy_allowed_actions" "example" {
+  owner     = "my-org"
+  name      = "Allowed Actions Policy"
+  all_repos = true
+
+  allowed_actions = {
+    "actions/checkout"            = "allow"
+    "step-security/harden-runner" = "allow"
+    "actions/setup-node"          = "allow"
+  }
+}
+
+# Dry-run variant scoped to specific repositories
+resource "stepsecurity_github_run_policy_allowed_actions" "dry_run" {
+  owner        = "my-org"
+  name         = "Allowed Actions Policy - Dry Run"
+  repositories = ["repo1", "repo2"]
+  is_dry_run   = true
+
+  allowed_actions = {
+    "actions/checkout" = "allow"
+  }
+}
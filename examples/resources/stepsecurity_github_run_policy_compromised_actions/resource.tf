@@ -0,0 +1,19 @@
+terraform {
+  required_providers {
+    stepsecurity = {
+      source = "step-security/stepsecurity"
+    }
+  }
+}
+
+provider "stepsecurity" {
+  api_key  = "xxxxxxxx" # can also be set as env variable STEP_SECURITY_API_KEY
+  customer = "abcdefg"  # can also be set as env variable STEP_SECURITY_CUSTOMER
+}
+
+# Compromised Actions Policy - blocks runs that use known-compromised actions
+resource "stepsecurity_github_run_policy_compromised_actions" "example" {
+  owner    = "my-org"
+  name     = "Compromised Actions Policy"
+  all_orgs = true
+}
@@ -0,0 +1,22 @@
+terraform {
+  required_providers {
+    stepsecurity = {
+      source = "step-security/stepsecurity"
+    }
+  }
+}
+
+provider "stepsecurity" {
+  api_key  = "xxxxxxxx" # can also be set as env variable STEP_SECURITY_API_KEY
+  customer = "abcdefg"  # can also be set as env variable STEP_SECURITY_CUSTOMER
+}
+
+# Pinned Actions Policy - every action must be pinned to a commit SHA,
+# except actions published by GitHub and by the organization itself
+resource "stepsecurity_github_run_policy_pinned_actions" "example" {
+  owner     = "my-org"
+  name      = "Pinned Actions Policy"
+  all_repos = true
+
+  actions_to_exempt_while_pinning = ["actions/*", "my-org/*"]
+}
@@ -0,0 +1,38 @@
+terraform {
+  required_providers {
+    stepsecurity = {
+      source = "step-security/stepsecurity"
+    }
+  }
+}
+
+provider "stepsecurity" {
+  api_key  = "xxxxxxxx" # can also be set as env variable STEP_SECURITY_API_KEY
+  customer = "abcdefg"  # can also be set as env variable STEP_SECURITY_CUSTOMER
+}
+
+# Runs-On Policy (disallowed mode) - blocks self-hosted runners and every
+# GitHub-hosted standard runner label
+resource "stepsecurity_github_run_policy_runs_on" "disallowed" {
+  owner     = "my-org"
+  name      = "Runner Label Policy - Disallowed"
+  all_repos = true
+
+  disallowed_runner_labels      = ["self-hosted"]
+  enable_standard_runner_labels = true
+}
+
+# Runs-On Policy (allowed mode) - only ubuntu-latest and runs-on.com runners
+# from the listed instance families may be used
+resource "stepsecurity_github_run_policy_runs_on" "allowed" {
+  owner        = "my-org"
+  name         = "Runner Label Policy - Allowed"
+  all_repos    = true
+  runs_on_mode = "allowed"
+
+  allowed_runner_labels = ["ubuntu-latest"]
+  allowed_runner_constraints = {
+    family = ["c7a", "m7a"]
+    cpu    = ["2", "4"]
+  }
+}
@@ -0,0 +1,23 @@
+terraform {
+  required_providers {
+    stepsecurity = {
+      source = "step-security/stepsecurity"
+    }
+  }
+}
+
+provider "stepsecurity" {
+  api_key  = "xxxxxxxx" # can also be set as env variable STEP_SECURITY_API_KEY
+  customer = "abcdefg"  # can also be set as env variable STEP_SECURITY_CUSTOMER
+}
+
+# Secrets Policy - blocks runs that could exfiltrate secrets, except for bots
+resource "stepsecurity_github_run_policy_secrets" "example" {
+  owner     = "my-org"
+  name      = "Secrets Policy"
+  all_repos = true
+
+  exempted_users                 = ["dependabot[bot]", "renovate[bot]"]
+  bulk_secrets_only_mode         = true
+  secrets_analyze_default_branch = true
+}
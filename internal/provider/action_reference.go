@@ -0,0 +1,206 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var (
+	actionOwnerPattern = regexp.MustCompile(`^[A-Za-z0-9](?:[A-Za-z0-9-]*[A-Za-z0-9])?$`)
+	actionRepoPattern  = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+	// actionPinnedRefPattern matches refs that cannot contain "/": full commit SHAs and
+	// version tags such as v4 or 1.2.3. Such a ref followed by "/..." is a misplaced path.
+	actionPinnedRefPattern = regexp.MustCompile(`^(?:[0-9a-fA-F]{40}|v?[0-9]+(?:\.[0-9]+)*)$`)
+)
+
+// actionReference is a parsed GitHub Actions reference as used by run policies and
+// policy-driven PRs. Supported forms are `owner/repo[/path][@ref]`, `owner/*`,
+// `owner/prefix*` and `*`.
+type actionReference struct {
+	Owner string
+	Repo  string
+	Path  string
+	Ref   string
+	// AnyOwner is set for the bare `*` wildcard, AnyRepo for `owner/*` and
+	// RepoPrefix for `owner/prefix*`, in which case Repo holds the prefix.
+	AnyOwner   bool
+	AnyRepo    bool
+	RepoPrefix bool
+}
+
+// isWildcard reports whether the reference matches more than one action.
+func (a actionReference) isWildcard() bool {
+	return a.AnyOwner || a.AnyRepo || a.RepoPrefix
+}
+
+// parseActionReference parses value into an actionReference. The returned error
+// explains what is wrong with the reference and is suitable for diagnostics.
+func parseActionReference(value string) (actionReference, error) {
+	var ref actionReference
+
+	if value == "" {
+		return ref, fmt.Errorf("action reference must not be empty")
+	}
+	if strings.TrimSpace(value) != value || strings.ContainsAny(value, " \t\r\n") {
+		return ref, fmt.Errorf("action reference %q must not contain whitespace", value)
+	}
+	if value == "*" {
+		ref.AnyOwner = true
+		return ref, nil
+	}
+	if isLocalOrDockerAction(value) {
+		return ref, fmt.Errorf("%q is a local or Docker action; only repository actions (owner/repo) are supported", value)
+	}
+
+	name := value
+	if at := strings.Index(value, "@"); at >= 0 {
+		name, ref.Ref = value[:at], value[at+1:]
+		if ref.Ref == "" {
+			return ref, fmt.Errorf("action reference %q has an empty ref after \"@\"", value)
+		}
+		if strings.Contains(ref.Ref, "@") {
+			return ref, fmt.Errorf("action reference %q contains more than one \"@\"", value)
+		}
+		if strings.Count(name, "/") == 0 && strings.Contains(ref.Ref, "/") {
+			return ref, fmt.Errorf("action reference %q has \"@ref\" in the wrong place; the ref must follow the full action path, e.g. owner/repo/path@ref", value)
+		}
+		// Branch refs may contain "/", but a SHA or version tag followed by a path is an
+		// action path written after the ref, e.g. actions/cache@v4/restore.
+		if pinned, subpath, ok := strings.Cut(ref.Ref, "/"); ok && actionPinnedRefPattern.MatchString(pinned) {
+			return ref, fmt.Errorf("action reference %q has \"@ref\" in the wrong place; the ref must follow the full action path: %s/%s@%s", value, name, subpath, pinned)
+		}
+	}
+
+	segments := strings.Split(name, "/")
+	if len(segments) < 2 {
+		return ref, fmt.Errorf("action reference %q is missing an owner; use owner/repo (e.g. actions/checkout) or owner/* to match every action of an owner", value)
+	}
+	for i, segment := range segments {
+		if segment == "" {
+			return ref, fmt.Errorf("action reference %q contains an empty path segment", value)
+		}
+		if strings.Contains(segment, "*") && (i != 1 || len(segments) != 2 || strings.Index(segment, "*") != len(segment)-1) {
+			return ref, fmt.Errorf("action reference %q uses an unsupported wildcard; only \"*\", \"owner/*\" and \"owner/prefix*\" are supported", value)
+		}
+	}
+
+	ref.Owner = segments[0]
+	if !actionOwnerPattern.MatchString(ref.Owner) {
+		return ref, fmt.Errorf("action reference %q has an invalid owner %q; owners may only contain letters, digits and single hyphens", value, ref.Owner)
+	}
+
+	ref.Repo = segments[1]
+	if strings.HasSuffix(ref.Repo, "*") {
+		if ref.Ref != "" {
+			return ref, fmt.Errorf("action reference %q combines a wildcard with a ref; wildcards match every ref", value)
+		}
+		ref.Repo = strings.TrimSuffix(ref.Repo, "*")
+		ref.AnyRepo = ref.Repo == ""
+		ref.RepoPrefix = !ref.AnyRepo
+		if ref.RepoPrefix && !actionRepoPattern.MatchString(ref.Repo) {
+			return ref, fmt.Errorf("action reference %q has an invalid repository prefix %q", value, ref.Repo)
+		}
+		return ref, nil
+	}
+	if !actionRepoPattern.MatchString(ref.Repo) {
+		return ref, fmt.Errorf("action reference %q has an invalid repository %q", value, ref.Repo)
+	}
+	ref.Path = strings.Join(segments[2:], "/")
+
+	return ref, nil
+}
+
+// isLocalOrDockerAction reports whether value refers to an action in the workflow's own
+// repository (`./path`) or to a Docker image (`docker://image`) rather than to a
+// repository action.
+func isLocalOrDockerAction(value string) bool {
+	return strings.HasPrefix(value, "./") || strings.HasPrefix(value, "docker://")
+}
+
+var _ validator.String = actionReferenceValidator{}
+
+// actionReferenceValidator validates that a string is a well-formed action reference.
+// It is applied per element with setvalidator.ValueStringsAre, listvalidator.ValueStringsAre
+// or mapvalidator.KeysAre so that diagnostics point at the offending element.
+type actionReferenceValidator struct {
+	allowWildcards bool
+	// allowLocal also accepts local (`./path`) and Docker (`docker://image`) actions,
+	// which policy-driven PRs can pin, exempt and replace like repository actions.
+	allowLocal bool
+}
+
+func (v actionReferenceValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+func (v actionReferenceValidator) MarkdownDescription(_ context.Context) string {
+	description := "value must be an action reference of the form `owner/repo[/path][@ref]`"
+	if v.allowWildcards {
+		description += ", `owner/*`, `owner/prefix*` or `*`"
+	}
+	if v.allowLocal {
+		description += ", a local action `./path` or a Docker action `docker://image`"
+	}
+	return description
+}
+
+func (v actionReferenceValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if v.allowLocal && isLocalOrDockerAction(value) {
+		name := strings.TrimPrefix(strings.TrimPrefix(value, "./"), "docker://")
+		if name == "" || strings.ContainsAny(value, " \t\r\n") {
+			resp.Diagnostics.AddAttributeError(
+				req.Path,
+				"Invalid Action Reference",
+				fmt.Sprintf("%q must name a path (./path) or an image (docker://image) without whitespace.", value),
+			)
+		}
+		return
+	}
+
+	ref, err := parseActionReference(value)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Action Reference", err.Error())
+		return
+	}
+	if ref.isWildcard() && !v.allowWildcards {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Action Reference",
+			fmt.Sprintf("%q is a wildcard, which is not supported here; list each action as owner/repo[/path][@ref].", value),
+		)
+	}
+}
+
+// matches reports whether the reference, used as a pattern, covers target. Owner and
+// repository comparisons are case-insensitive like GitHub's. A pattern without a path
+// covers every action in the repository, and a pattern without a ref covers every ref.
+func (a actionReference) matches(target actionReference) bool {
+	if a.AnyOwner {
+		return true
+	}
+	if !strings.EqualFold(a.Owner, target.Owner) {
+		return false
+	}
+	switch {
+	case a.AnyRepo:
+		return true
+	case a.RepoPrefix:
+		return strings.HasPrefix(strings.ToLower(target.Repo), strings.ToLower(a.Repo))
+	}
+	if !strings.EqualFold(a.Repo, target.Repo) {
+		return false
+	}
+	if a.Path != "" && !strings.EqualFold(a.Path, target.Path) {
+		return false
+	}
+	return a.Ref == "" || a.Ref == target.Ref
+}
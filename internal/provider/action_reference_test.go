@@ -0,0 +1,145 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseActionReference(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		value       string
+		expected    actionReference
+		errContains string
+	}{
+		{value: "*", expected: actionReference{AnyOwner: true}},
+		{value: "actions/checkout", expected: actionReference{Owner: "actions", Repo: "checkout"}},
+		{value: "actions/checkout@v4", expected: actionReference{Owner: "actions", Repo: "checkout", Ref: "v4"}},
+		{value: "github/codeql-action/init@v3", expected: actionReference{Owner: "github", Repo: "codeql-action", Path: "init", Ref: "v3"}},
+		{value: "my-org/actions/deploy/prod@release/v1", expected: actionReference{Owner: "my-org", Repo: "actions", Path: "deploy/prod", Ref: "release/v1"}},
+		{value: "my-org/*", expected: actionReference{Owner: "my-org", AnyRepo: true}},
+		{value: "fkirc/skip-*", expected: actionReference{Owner: "fkirc", Repo: "skip-", RepoPrefix: true}},
+		{value: "", errContains: "must not be empty"},
+		{value: " actions/checkout", errContains: "must not contain whitespace"},
+		{value: "checkout", errContains: "is missing an owner"},
+		{value: "checkout@v4", errContains: "is missing an owner"},
+		{value: "actions@v4/checkout", errContains: "in the wrong place"},
+		{value: "actions/checkout@8f4b7f84864484a7bf31766abe9204da3cbe65b3/sub", errContains: "actions/checkout/sub@8f4b7f84864484a7bf31766abe9204da3cbe65b3"},
+		{value: "actions/cache@v4/restore", errContains: "actions/cache/restore@v4"},
+		{value: "my-org/actions@1.2.3/deploy", errContains: "in the wrong place"},
+		{value: "my-org/actions@release/v1", expected: actionReference{Owner: "my-org", Repo: "actions", Ref: "release/v1"}},
+		{value: "actions/checkout@", errContains: "empty ref"},
+		{value: "actions/checkout@v4@v5", errContains: "more than one"},
+		{value: "actions//checkout", errContains: "empty path segment"},
+		{value: "actions/", errContains: "empty path segment"},
+		{value: "*/checkout", errContains: "unsupported wildcard"},
+		{value: "actions/*/sub", errContains: "unsupported wildcard"},
+		{value: "actions/check*out", errContains: "unsupported wildcard"},
+		{value: "actions/**", errContains: "unsupported wildcard"},
+		{value: "my-org/*@v1", errContains: "combines a wildcard with a ref"},
+		{value: "-bad/repo", errContains: "invalid owner"},
+		{value: "actions/check$out", errContains: "invalid repository"},
+		{value: "./.github/actions/build", errContains: "local or Docker action"},
+		{value: "docker://alpine:3.19", errContains: "local or Docker action"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			t.Parallel()
+
+			ref, err := parseActionReference(tc.value)
+			if tc.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, ref)
+		})
+	}
+}
+
+func TestActionReferenceValidator(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		value          types.String
+		allowWildcards bool
+		allowLocal     bool
+		expectError    bool
+	}{
+		{name: "null", value: types.StringNull()},
+		{name: "unknown", value: types.StringUnknown()},
+		{name: "exact", value: types.StringValue("actions/checkout@v4")},
+		{name: "wildcard allowed", value: types.StringValue("my-org/*"), allowWildcards: true},
+		{name: "wildcard rejected", value: types.StringValue("my-org/*"), expectError: true},
+		{name: "any rejected", value: types.StringValue("*"), expectError: true},
+		{name: "malformed", value: types.StringValue("checkout"), allowWildcards: true, expectError: true},
+		{name: "local rejected", value: types.StringValue("./.github/actions/build"), allowWildcards: true, expectError: true},
+		{name: "local allowed", value: types.StringValue("./.github/actions/build"), allowLocal: true},
+		{name: "docker allowed", value: types.StringValue("docker://alpine:3.20"), allowLocal: true},
+		{name: "docker without image", value: types.StringValue("docker://"), allowLocal: true, expectError: true},
+		{name: "repository action with local allowed", value: types.StringValue("actions/checkout"), allowLocal: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			attrPath := path.Root("allowed_actions").AtMapKey("x")
+			resp := &validator.StringResponse{}
+			actionReferenceValidator{allowWildcards: tc.allowWildcards, allowLocal: tc.allowLocal}.ValidateString(context.Background(), validator.StringRequest{
+				Path:        attrPath,
+				ConfigValue: tc.value,
+			}, resp)
+
+			assert.Equal(t, tc.expectError, resp.Diagnostics.HasError(), "diags: %v", resp.Diagnostics)
+			if tc.expectError {
+				// Diagnostics point at the offending element, not the whole collection.
+				assert.Equal(t, attrPath, resp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath).Path())
+			}
+		})
+	}
+}
+
+func TestActionReferenceMatches(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		pattern  string
+		target   string
+		expected bool
+	}{
+		{pattern: "*", target: "actions/checkout@v4", expected: true},
+		{pattern: "actions/*", target: "actions/checkout@v4", expected: true},
+		{pattern: "actions/*", target: "my-org/checkout@v4", expected: false},
+		{pattern: "fkirc/skip-*", target: "fkirc/skip-duplicate-actions@v5", expected: true},
+		{pattern: "fkirc/skip-*", target: "fkirc/other@v5", expected: false},
+		{pattern: "actions/checkout", target: "Actions/Checkout@v4", expected: true},
+		{pattern: "actions/checkout@v4", target: "actions/checkout@v4", expected: true},
+		{pattern: "actions/checkout@v4", target: "actions/checkout@v3", expected: false},
+		{pattern: "github/codeql-action", target: "github/codeql-action/init@v3", expected: true},
+		{pattern: "github/codeql-action/init", target: "github/codeql-action/analyze@v3", expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.pattern+"~"+tc.target, func(t *testing.T) {
+			t.Parallel()
+
+			pattern, err := parseActionReference(tc.pattern)
+			require.NoError(t, err)
+			target, err := parseActionReference(tc.target)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, pattern.matches(target))
+		})
+	}
+}
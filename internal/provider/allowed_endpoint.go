@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var endpointDomainPattern = regexp.MustCompile(`^(?:[A-Za-z0-9](?:[A-Za-z0-9-]{0,61}[A-Za-z0-9])?\.)*[A-Za-z0-9](?:[A-Za-z0-9-]{0,61}[A-Za-z0-9])?$`)
+
+// blockModeRecommendedEndpoints lists endpoints that most workflows need when egress
+// traffic is blocked, with what they are used for.
+var blockModeRecommendedEndpoints = []struct {
+	Endpoint string
+	Reason   string
+}{
+	{Endpoint: "github.com:443", Reason: "cloning repositories, e.g. with actions/checkout"},
+	{Endpoint: "api.github.com:443", Reason: "GitHub API calls made by most actions"},
+}
+
+// allowedEndpoint is a parsed Harden-Runner allowed endpoint. Supported forms are
+// `host:port`, `*.domain:port` and `ip:port`, with IPv6 addresses in brackets.
+type allowedEndpoint struct {
+	// Host is the lower-cased host name or IP address. For wildcard endpoints it
+	// holds the domain after the `*.` prefix.
+	Host     string
+	Port     int
+	Wildcard bool
+}
+
+// parseAllowedEndpoint parses value into an allowedEndpoint. The returned error
+// explains what is wrong with the endpoint and is suitable for diagnostics.
+func parseAllowedEndpoint(value string) (allowedEndpoint, error) {
+	var endpoint allowedEndpoint
+
+	if value == "" {
+		return endpoint, fmt.Errorf("endpoint must not be empty")
+	}
+	if strings.ContainsAny(value, " \t\r\n") {
+		return endpoint, fmt.Errorf("endpoint %q must not contain whitespace", value)
+	}
+	if strings.Contains(value, "://") {
+		return endpoint, fmt.Errorf("endpoint %q must not contain a scheme; use host:port", value)
+	}
+
+	host, port, err := net.SplitHostPort(value)
+	if err != nil {
+		return endpoint, fmt.Errorf("endpoint %q must be of the form host:port", value)
+	}
+	endpoint.Port, err = strconv.Atoi(port)
+	if err != nil || endpoint.Port < 1 || endpoint.Port > 65535 {
+		return endpoint, fmt.Errorf("endpoint %q has an invalid port %q; ports range from 1 to 65535", value, port)
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		endpoint.Host = ip.String()
+		return endpoint, nil
+	}
+	if strings.Contains(host, "/") {
+		return endpoint, fmt.Errorf("endpoint %q is a CIDR range; only single IP addresses are supported", value)
+	}
+	if domain, ok := strings.CutPrefix(host, "*."); ok {
+		endpoint.Wildcard = true
+		host = domain
+	}
+	if strings.Contains(host, "*") {
+		return endpoint, fmt.Errorf("endpoint %q has an unsupported wildcard; only a leading \"*.\" is supported", value)
+	}
+	if len(host) > 253 || !endpointDomainPattern.MatchString(host) {
+		return endpoint, fmt.Errorf("endpoint %q has an invalid host name %q", value, host)
+	}
+	endpoint.Host = strings.ToLower(host)
+
+	return endpoint, nil
+}
+
+// covers reports whether the endpoint, used as an allow rule, allows target. A
+// wildcard endpoint allows subdomains of its domain but not the domain itself.
+func (e allowedEndpoint) covers(target allowedEndpoint) bool {
+	if e.Port != target.Port {
+		return false
+	}
+	if e.Wildcard {
+		return strings.HasSuffix(target.Host, "."+e.Host) || target.Wildcard && target.Host == e.Host
+	}
+	return !target.Wildcard && e.Host == target.Host
+}
+
+// missingBlockModeEndpoints returns the entries of blockModeRecommendedEndpoints that
+// no endpoint in allowed covers. Entries of allowed that don't parse are ignored.
+func missingBlockModeEndpoints(allowed []string) []string {
+	var rules []allowedEndpoint
+	for _, value := range allowed {
+		if endpoint, err := parseAllowedEndpoint(value); err == nil {
+			rules = append(rules, endpoint)
+		}
+	}
+
+	var missing []string
+	for _, recommended := range blockModeRecommendedEndpoints {
+		target, _ := parseAllowedEndpoint(recommended.Endpoint)
+		covered := false
+		for _, rule := range rules {
+			if rule.covers(target) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			missing = append(missing, fmt.Sprintf("%s (%s)", recommended.Endpoint, recommended.Reason))
+		}
+	}
+	return missing
+}
+
+var _ validator.String = allowedEndpointValidator{}
+
+// allowedEndpointValidator validates that a string is a well-formed allowed endpoint.
+// It is applied per element with setvalidator.ValueStringsAre so that diagnostics
+// point at the offending element.
+type allowedEndpointValidator struct{}
+
+func (v allowedEndpointValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+func (v allowedEndpointValidator) MarkdownDescription(_ context.Context) string {
+	return "value must be an endpoint of the form `host:port`, `*.domain:port` or `ip:port`"
+}
+
+func (v allowedEndpointValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := parseAllowedEndpoint(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Allowed Endpoint", err.Error())
+	}
+}
@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAllowedEndpoint(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		value       string
+		expected    allowedEndpoint
+		errContains string
+	}{
+		{value: "github.com:443", expected: allowedEndpoint{Host: "github.com", Port: 443}},
+		{value: "API.GitHub.com:443", expected: allowedEndpoint{Host: "api.github.com", Port: 443}},
+		{value: "*.blob.core.windows.net:443", expected: allowedEndpoint{Host: "blob.core.windows.net", Port: 443, Wildcard: true}},
+		{value: "localhost:8080", expected: allowedEndpoint{Host: "localhost", Port: 8080}},
+		{value: "10.0.0.1:22", expected: allowedEndpoint{Host: "10.0.0.1", Port: 22}},
+		{value: "[2001:db8::1]:443", expected: allowedEndpoint{Host: "2001:db8::1", Port: 443}},
+		{value: "", errContains: "must not be empty"},
+		{value: "github.com :443", errContains: "must not contain whitespace"},
+		{value: "https://github.com:443", errContains: "must not contain a scheme"},
+		{value: "github.com", errContains: "must be of the form host:port"},
+		{value: "2001:db8::1:443", errContains: "must be of the form host:port"},
+		{value: "github.com:https", errContains: "invalid port"},
+		{value: "github.com:0", errContains: "invalid port"},
+		{value: "github.com:65536", errContains: "invalid port"},
+		{value: "10.0.0.0/8:443", errContains: "CIDR range"},
+		{value: "api.*.github.com:443", errContains: "unsupported wildcard"},
+		{value: "*:443", errContains: "unsupported wildcard"},
+		{value: "-github.com:443", errContains: "invalid host name"},
+		{value: "github..com:443", errContains: "invalid host name"},
+		{value: "git_hub.com:443", errContains: "invalid host name"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			t.Parallel()
+
+			endpoint, err := parseAllowedEndpoint(tc.value)
+			if tc.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, endpoint)
+		})
+	}
+}
+
+func TestAllowedEndpointValidator(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		value       types.String
+		expectError bool
+	}{
+		{name: "null", value: types.StringNull()},
+		{name: "unknown", value: types.StringUnknown()},
+		{name: "host", value: types.StringValue("registry.npmjs.org:443")},
+		{name: "wildcard", value: types.StringValue("*.actions.githubusercontent.com:443")},
+		{name: "missing port", value: types.StringValue("registry.npmjs.org"), expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			attrPath := path.Root("allowed_endpoints").AtSetValue(tc.value)
+			resp := &validator.StringResponse{}
+			allowedEndpointValidator{}.ValidateString(context.Background(), validator.StringRequest{
+				Path:        attrPath,
+				ConfigValue: tc.value,
+			}, resp)
+
+			assert.Equal(t, tc.expectError, resp.Diagnostics.HasError(), "diags: %v", resp.Diagnostics)
+			if tc.expectError {
+				assert.Equal(t, attrPath, resp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath).Path())
+			}
+		})
+	}
+}
+
+func TestAllowedEndpointCovers(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		rule     string
+		target   string
+		expected bool
+	}{
+		{rule: "github.com:443", target: "github.com:443", expected: true},
+		{rule: "GitHub.com:443", target: "github.com:443", expected: true},
+		{rule: "github.com:443", target: "github.com:80"},
+		{rule: "github.com:443", target: "api.github.com:443"},
+		{rule: "*.github.com:443", target: "api.github.com:443", expected: true},
+		{rule: "*.github.com:443", target: "uploads.api.github.com:443", expected: true},
+		{rule: "*.github.com:443", target: "github.com:443"},
+		{rule: "*.github.com:443", target: "notgithub.com:443"},
+		{rule: "*.github.com:443", target: "*.api.github.com:443", expected: true},
+		{rule: "*.github.com:443", target: "*.github.com:443", expected: true},
+		{rule: "api.github.com:443", target: "*.github.com:443"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.rule+" "+tc.target, func(t *testing.T) {
+			t.Parallel()
+
+			rule, err := parseAllowedEndpoint(tc.rule)
+			require.NoError(t, err)
+			target, err := parseAllowedEndpoint(tc.target)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, rule.covers(target))
+		})
+	}
+}
+
+func TestMissingBlockModeEndpoints(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, missingBlockModeEndpoints([]string{"github.com:443", "api.github.com:443"}))
+	assert.Empty(t, missingBlockModeEndpoints([]string{"github.com:443", "*.github.com:443"}))
+	assert.Equal(t,
+		[]string{"api.github.com:443 (GitHub API calls made by most actions)"},
+		missingBlockModeEndpoints([]string{"github.com:443", "not an endpoint"}),
+	)
+	assert.Len(t, missingBlockModeEndpoints(nil), len(blockModeRecommendedEndpoints))
+}
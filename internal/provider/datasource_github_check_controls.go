@@ -0,0 +1,220 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &githubCheckControlsDataSource{}
+	_ datasource.DataSourceWithConfigure = &githubCheckControlsDataSource{}
+)
+
+// NewGithubCheckControlsDataSource is a helper function to simplify the provider implementation.
+func NewGithubCheckControlsDataSource() datasource.DataSource {
+	return &githubCheckControlsDataSource{}
+}
+
+// githubCheckControlsDataSource exposes the catalog of controls available for GitHub checks.
+type githubCheckControlsDataSource struct {
+	client stepsecurityapi.Client
+}
+
+type githubCheckControlsDataSourceModel struct {
+	Owner    types.String `tfsdk:"owner"`
+	Controls types.List   `tfsdk:"controls"`
+}
+
+var checkControlSettingAttrTypes = map[string]attr.Type{
+	"key":         types.StringType,
+	"type":        types.StringType,
+	"description": types.StringType,
+	"default":     types.StringType,
+	"min":         types.Int64Type,
+	"max":         types.Int64Type,
+}
+
+var checkControlAttrTypes = map[string]attr.Type{
+	"control":     types.StringType,
+	"name":        types.StringType,
+	"check":       types.StringType,
+	"description": types.StringType,
+	"types":       types.ListType{ElemType: types.StringType},
+	"settings":    types.ListType{ElemType: types.ObjectType{AttrTypes: checkControlSettingAttrTypes}},
+}
+
+// Metadata returns the data source type name.
+func (d *githubCheckControlsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_github_check_controls"
+}
+
+// Schema defines the schema for the data source.
+func (d *githubCheckControlsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves the controls that can be configured in `stepsecurity_github_checks`, including the settings each control accepts. When the StepSecurity API does not serve a control catalog, the controls built into the provider are returned.",
+		Attributes: map[string]schema.Attribute{
+			"owner": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The GitHub organization or user to retrieve the controls for.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"controls": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The available controls, ordered by check name.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"control": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The value to use for `control` in `stepsecurity_github_checks`: the display name for controls built into the provider, the check name otherwise.",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The display name of the control.",
+						},
+						"check": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The check name of the control.",
+						},
+						"description": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "What the control checks.",
+						},
+						"types": schema.ListAttribute{
+							Computed:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "The check types the control can run as, `required` and/or `optional`.",
+						},
+						"settings": schema.ListNestedAttribute{
+							Computed:            true,
+							MarkdownDescription: "The settings the control accepts.",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"key": schema.StringAttribute{
+										Computed:            true,
+										MarkdownDescription: "The settings key, used in `settings.values` of `stepsecurity_github_checks`.",
+									},
+									"type": schema.StringAttribute{
+										Computed:            true,
+										MarkdownDescription: "The value type: `int`, `bool`, `string` or `string_list`.",
+									},
+									"description": schema.StringAttribute{
+										Computed:            true,
+										MarkdownDescription: "What the setting does.",
+									},
+									"default": schema.StringAttribute{
+										Computed:            true,
+										MarkdownDescription: "The default value, in the format used by `settings.values`. Null when the setting has no default.",
+									},
+									"min": schema.Int64Attribute{
+										Computed:            true,
+										MarkdownDescription: "The minimum value of an `int` setting.",
+									},
+									"max": schema.Int64Attribute{
+										Computed:            true,
+										MarkdownDescription: "The maximum value of an `int` setting.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *githubCheckControlsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(stepsecurityapi.Client)
+	if !ok || client == nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected stepsecurityapi.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *githubCheckControlsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state githubCheckControlsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	catalog, err := d.client.GetChecksControlCatalog(ctx, state.Owner.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading GitHub check controls",
+			"Could not read the control catalog for owner "+state.Owner.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	controls := make([]attr.Value, 0, len(catalog))
+	for _, entry := range catalog {
+		settings := make([]attr.Value, 0, len(entry.Settings))
+		for _, setting := range entry.Settings {
+			defaultValue := types.StringNull()
+			if setting.Default != nil {
+				formatted, err := formatControlSettingValue(setting.Default)
+				if err != nil {
+					resp.Diagnostics.AddError(
+						"Error reading GitHub check controls",
+						fmt.Sprintf("Could not read the default of setting %q of control %q: %s", setting.Key, entry.Check, err),
+					)
+					return
+				}
+				defaultValue = types.StringValue(formatted)
+			}
+			settings = append(settings, types.ObjectValueMust(checkControlSettingAttrTypes, map[string]attr.Value{
+				"key":         types.StringValue(setting.Key),
+				"type":        types.StringValue(setting.Type),
+				"description": types.StringValue(setting.Description),
+				"default":     defaultValue,
+				"min":         types.Int64PointerValue(setting.Min),
+				"max":         types.Int64PointerValue(setting.Max),
+			}))
+		}
+
+		controls = append(controls, types.ObjectValueMust(checkControlAttrTypes, map[string]attr.Value{
+			"control":     types.StringValue(controlNameForCheck(entry.Check)),
+			"name":        types.StringValue(entry.Name),
+			"check":       types.StringValue(entry.Check),
+			"description": types.StringValue(entry.Description),
+			"types":       types.ListValueMust(types.StringType, stringsToAttrValues(entry.Types)),
+			"settings":    types.ListValueMust(types.ObjectType{AttrTypes: checkControlSettingAttrTypes}, settings),
+		}))
+	}
+
+	state.Controls = types.ListValueMust(types.ObjectType{AttrTypes: checkControlAttrTypes}, controls)
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// stringsToAttrValues converts values to string attribute values.
+func stringsToAttrValues(values []string) []attr.Value {
+	elements := make([]attr.Value, 0, len(values))
+	for _, value := range values {
+		elements = append(elements, types.StringValue(value))
+	}
+	return elements
+}
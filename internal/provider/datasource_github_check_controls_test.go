@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	fwdatasource "github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
+)
+
+func TestGithubCheckControlsDataSource_Metadata(t *testing.T) {
+	t.Parallel()
+
+	resp := &fwdatasource.MetadataResponse{}
+	NewGithubCheckControlsDataSource().Metadata(context.Background(), fwdatasource.MetadataRequest{ProviderTypeName: "stepsecurity"}, resp)
+	assert.Equal(t, "stepsecurity_github_check_controls", resp.TypeName)
+}
+
+func TestGithubCheckControlsDataSource_Read(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mockClient := &stepsecurityapi.MockStepSecurityClient{}
+	mockClient.On("GetChecksControlCatalog", mock.Anything, "test-org").Return(testControlCatalog(), nil).Once()
+
+	resp := testDataSourceRead(t, &githubCheckControlsDataSource{client: mockClient}, map[string]tftypes.Value{"owner": tftypes.NewValue(tftypes.String, "test-org")})
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+	mockClient.AssertExpectations(t)
+
+	var state githubCheckControlsDataSourceModel
+	require.False(t, resp.State.Get(ctx, &state).HasError())
+	require.Len(t, state.Controls.Elements(), len(stepsecurityapi.AvailableControls)+1)
+
+	byCheck := map[string]types.Object{}
+	for _, element := range state.Controls.Elements() {
+		obj := element.(types.Object)
+		byCheck[obj.Attributes()["check"].(types.String).ValueString()] = obj
+	}
+
+	// Built-in controls are referenced by display name, the others by check name.
+	cooldown := byCheck["npm_package_recent_release_guard"].Attributes()
+	assert.Equal(t, "NPM Package Cooldown", cooldown["control"].(types.String).ValueString())
+	settings := cooldown["settings"].(types.List).Elements()
+	require.Len(t, settings, 2)
+	period := settings[0].(types.Object).Attributes()
+	assert.Equal(t, "cooldown_period_in_days", period["key"].(types.String).ValueString())
+	assert.Equal(t, "2", period["default"].(types.String).ValueString())
+	assert.Equal(t, int64(30), period["max"].(types.Int64).ValueInt64())
+
+	pinning := byCheck["action_pinning_check"].Attributes()
+	assert.Equal(t, "action_pinning_check", pinning["control"].(types.String).ValueString())
+	assert.Equal(t, "Action Pinning", pinning["name"].(types.String).ValueString())
+	allowTags := pinning["settings"].(types.List).Elements()[0].(types.Object).Attributes()
+	assert.Equal(t, "false", allowTags["default"].(types.String).ValueString())
+	assert.True(t, allowTags["min"].IsNull())
+}
+
+func TestGithubCheckControlsDataSource_ReadError(t *testing.T) {
+	t.Parallel()
+
+	mockClient := &stepsecurityapi.MockStepSecurityClient{}
+	mockClient.On("GetChecksControlCatalog", mock.Anything, "test-org").Return(stepsecurityapi.ControlCatalog(nil), errors.New("boom")).Once()
+
+	resp := testDataSourceRead(t, &githubCheckControlsDataSource{client: mockClient}, map[string]tftypes.Value{"owner": tftypes.NewValue(tftypes.String, "test-org")})
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "boom")
+}
@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &githubClustersDataSource{}
+	_ datasource.DataSourceWithConfigure = &githubClustersDataSource{}
+)
+
+// NewGithubClustersDataSource is a helper function to simplify the provider implementation.
+func NewGithubClustersDataSource() datasource.DataSource {
+	return &githubClustersDataSource{}
+}
+
+// githubClustersDataSource lists the self-hosted runner clusters known to StepSecurity.
+type githubClustersDataSource struct {
+	client stepsecurityapi.Client
+}
+
+type githubClustersDataSourceModel struct {
+	Owner    types.String `tfsdk:"owner"`
+	Names    types.List   `tfsdk:"names"`
+	Clusters types.List   `tfsdk:"clusters"`
+}
+
+var runnerScaleSetAttrTypes = map[string]attr.Type{
+	"name":      types.StringType,
+	"namespace": types.StringType,
+}
+
+var clusterAttrTypes = map[string]attr.Type{
+	"name":              types.StringType,
+	"namespaces":        types.ListType{ElemType: types.StringType},
+	"runner_scale_sets": types.ListType{ElemType: types.ObjectType{AttrTypes: runnerScaleSetAttrTypes}},
+	"last_seen":         types.StringType,
+}
+
+// Metadata returns the data source type name.
+func (d *githubClustersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_github_clusters"
+}
+
+// Schema defines the schema for the data source.
+func (d *githubClustersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves the Kubernetes clusters running self-hosted runners, such as Actions Runner Controller (ARC) clusters, that report to StepSecurity. " +
+			"Cluster names, namespaces and runner scale sets are what `clusters` and `cluster_scopes` of `stepsecurity_github_policy_store_attachment` refer to.",
+		Attributes: map[string]schema.Attribute{
+			"owner": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The GitHub organization or user to retrieve the clusters for.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"names": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The names of the clusters.",
+			},
+			"clusters": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The clusters.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The cluster name.",
+						},
+						"namespaces": schema.ListAttribute{
+							Computed:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "The namespaces of the cluster that run runners.",
+						},
+						"runner_scale_sets": schema.ListNestedAttribute{
+							Computed:            true,
+							MarkdownDescription: "The runner scale sets of the cluster.",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"name": schema.StringAttribute{
+										Computed:            true,
+										MarkdownDescription: "The runner scale set name.",
+									},
+									"namespace": schema.StringAttribute{
+										Computed:            true,
+										MarkdownDescription: "The namespace the runner scale set runs in.",
+									},
+								},
+							},
+						},
+						"last_seen": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "When the cluster last reported to StepSecurity, in RFC 3339 format.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *githubClustersDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(stepsecurityapi.Client)
+	if !ok || client == nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected stepsecurityapi.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *githubClustersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state githubClustersDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusters, err := d.client.ListClusters(ctx, state.Owner.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading clusters",
+			"Could not list the clusters for owner "+state.Owner.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	names := make([]attr.Value, 0, len(clusters))
+	values := make([]attr.Value, 0, len(clusters))
+	for _, cluster := range clusters {
+		scaleSets := make([]attr.Value, 0, len(cluster.RunnerScaleSets))
+		for _, scaleSet := range cluster.RunnerScaleSets {
+			scaleSets = append(scaleSets, types.ObjectValueMust(runnerScaleSetAttrTypes, map[string]attr.Value{
+				"name":      types.StringValue(scaleSet.Name),
+				"namespace": types.StringValue(scaleSet.Namespace),
+			}))
+		}
+
+		names = append(names, types.StringValue(cluster.Name))
+		values = append(values, types.ObjectValueMust(clusterAttrTypes, map[string]attr.Value{
+			"name":              types.StringValue(cluster.Name),
+			"namespaces":        types.ListValueMust(types.StringType, stringsToAttrValues(cluster.Namespaces)),
+			"runner_scale_sets": types.ListValueMust(types.ObjectType{AttrTypes: runnerScaleSetAttrTypes}, scaleSets),
+			"last_seen":         optionalStringValue(cluster.LastSeen),
+		}))
+	}
+
+	state.Names = types.ListValueMust(types.StringType, names)
+	state.Clusters = types.ListValueMust(types.ObjectType{AttrTypes: clusterAttrTypes}, values)
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	fwdatasource "github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
+)
+
+func TestGithubClustersDataSource_Metadata(t *testing.T) {
+	t.Parallel()
+
+	resp := &fwdatasource.MetadataResponse{}
+	NewGithubClustersDataSource().Metadata(context.Background(), fwdatasource.MetadataRequest{ProviderTypeName: "stepsecurity"}, resp)
+	assert.Equal(t, "stepsecurity_github_clusters", resp.TypeName)
+}
+
+func TestGithubClustersDataSource_Read(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mockClient := &stepsecurityapi.MockStepSecurityClient{}
+	mockClient.On("ListClusters", mock.Anything, "test-org").Return([]stepsecurityapi.Cluster{
+		{
+			Name:            "prod",
+			Namespaces:      []string{"arc-runners"},
+			RunnerScaleSets: []stepsecurityapi.RunnerScaleSet{{Name: "linux-x64", Namespace: "arc-runners"}},
+			LastSeen:        "2026-03-04T00:00:00Z",
+		},
+		{Name: "staging"},
+	}, nil).Once()
+
+	resp := testDataSourceRead(t, &githubClustersDataSource{client: mockClient}, map[string]tftypes.Value{"owner": tftypes.NewValue(tftypes.String, "test-org")})
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+	mockClient.AssertExpectations(t)
+
+	var state githubClustersDataSourceModel
+	require.False(t, resp.State.Get(ctx, &state).HasError())
+
+	var names []string
+	require.False(t, state.Names.ElementsAs(ctx, &names, false).HasError())
+	assert.Equal(t, []string{"prod", "staging"}, names)
+
+	require.Len(t, state.Clusters.Elements(), 2)
+	prod := state.Clusters.Elements()[0].(types.Object).Attributes()
+	assert.Equal(t, "2026-03-04T00:00:00Z", prod["last_seen"].(types.String).ValueString())
+	scaleSet := prod["runner_scale_sets"].(types.List).Elements()[0].(types.Object).Attributes()
+	assert.Equal(t, "linux-x64", scaleSet["name"].(types.String).ValueString())
+	assert.Equal(t, "arc-runners", scaleSet["namespace"].(types.String).ValueString())
+
+	staging := state.Clusters.Elements()[1].(types.Object).Attributes()
+	assert.True(t, staging["last_seen"].IsNull())
+	assert.Empty(t, staging["namespaces"].(types.List).Elements())
+}
+
+func TestGithubClustersDataSource_ReadError(t *testing.T) {
+	t.Parallel()
+
+	mockClient := &stepsecurityapi.MockStepSecurityClient{}
+	mockClient.On("ListClusters", mock.Anything, "test-org").Return([]stepsecurityapi.Cluster(nil), errors.New("boom")).Once()
+
+	resp := testDataSourceRead(t, &githubClustersDataSource{client: mockClient}, map[string]tftypes.Value{"owner": tftypes.NewValue(tftypes.String, "test-org")})
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "boom")
+}
@@ -0,0 +1,180 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
+)
+
+// Sources of an effective PR template.
+const (
+	prTemplateSourceRepository   = "repository"
+	prTemplateSourceOrganization = "organization"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &githubEffectivePRTemplateDataSource{}
+	_ datasource.DataSourceWithConfigure = &githubEffectivePRTemplateDataSource{}
+)
+
+// NewGithubEffectivePRTemplateDataSource is a helper function to simplify the provider implementation.
+func NewGithubEffectivePRTemplateDataSource() datasource.DataSource {
+	return &githubEffectivePRTemplateDataSource{}
+}
+
+// githubEffectivePRTemplateDataSource retrieves the PR template policy-driven PRs of a
+// repository use: the repository's own template, or else the owner's.
+type githubEffectivePRTemplateDataSource struct {
+	client stepsecurityapi.Client
+}
+
+type githubEffectivePRTemplateDataSourceModel struct {
+	githubPRTemplateModel
+	Repo   types.String `tfsdk:"repo"`
+	Source types.String `tfsdk:"source"`
+}
+
+// Metadata returns the data source type name.
+func (d *githubEffectivePRTemplateDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_github_effective_pr_template"
+}
+
+// Schema defines the schema for the data source.
+func (d *githubEffectivePRTemplateDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves the PR template policy-driven PRs of a repository use: the template of `stepsecurity_github_repo_pr_template` " +
+			"when the repository has one, and the template of `stepsecurity_github_pr_template` otherwise.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "`owner/repo`.",
+			},
+			"owner": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The GitHub organization or user that owns the repository.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"repo": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The repository name.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"source": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Where the template comes from: `repository` or `organization`.",
+			},
+			"title": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The PR title.",
+			},
+			"summary": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The PR summary.",
+			},
+			"commit_message": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The commit message.",
+			},
+			"labels": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The PR labels.",
+			},
+			"reviewers": schema.SetAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "GitHub usernames reviews are requested from.",
+			},
+			"team_reviewers": schema.SetAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Slugs of the teams reviews are requested from.",
+			},
+			"assignees": schema.SetAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "GitHub usernames PRs are assigned to.",
+			},
+			"draft": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether PRs are opened as drafts.",
+			},
+			"branch_prefix": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The prefix of the head branch of PRs. Null when StepSecurity's default naming is used.",
+			},
+			"sign_off": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether a `Signed-off-by` trailer is appended to the commit message.",
+			},
+			"co_authors": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Co-authors credited with a `Co-authored-by` trailer on the commit.",
+			},
+			"rendered_preview": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The body of a sample policy-driven PR rendered from `summary`.",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *githubEffectivePRTemplateDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(stepsecurityapi.Client)
+	if !ok || client == nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected stepsecurityapi.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *githubEffectivePRTemplateDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state githubEffectivePRTemplateDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	owner, repo := state.Owner.ValueString(), state.Repo.ValueString()
+
+	source := prTemplateSourceRepository
+	template, err := d.client.GetRepoGitHubPRTemplate(ctx, owner, repo)
+	if err != nil && stepsecurityapi.IsNotFound(err) {
+		source = prTemplateSourceOrganization
+		template, err = d.client.GetGitHubPRTemplate(ctx, owner)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading effective PR template",
+			fmt.Sprintf("Could not read the PR template of %s/%s: %s", owner, repo, err),
+		)
+		return
+	}
+
+	state.ID = types.StringValue(owner + "/" + repo)
+	state.Source = types.StringValue(source)
+	updatePRTemplateModel(&state.githubPRTemplateModel, template)
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	fwdatasource "github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
+)
+
+func TestGithubEffectivePRTemplateDataSource_Metadata(t *testing.T) {
+	t.Parallel()
+
+	resp := &fwdatasource.MetadataResponse{}
+	NewGithubEffectivePRTemplateDataSource().Metadata(context.Background(), fwdatasource.MetadataRequest{ProviderTypeName: "stepsecurity"}, resp)
+	assert.Equal(t, "stepsecurity_github_effective_pr_template", resp.TypeName)
+}
+
+func TestGithubEffectivePRTemplateDataSource_Read(t *testing.T) {
+	t.Parallel()
+
+	repoTemplate := &stepsecurityapi.GitHubPRTemplate{
+		Title:         "fix(payments): apply security best practices",
+		Summary:       "{{STEPSECURITY_SECURITY_FIXES}}",
+		CommitMessage: "fix(payments): harden workflows",
+		TeamReviewers: []string{"payments"},
+	}
+	orgTemplate := &stepsecurityapi.GitHubPRTemplate{
+		Title:         "[StepSecurity] Apply security best practices",
+		Summary:       "## Security Fixes\n\n{{STEPSECURITY_SECURITY_FIXES}}",
+		CommitMessage: "[StepSecurity] Apply security best practices",
+		Labels:        []string{"security"},
+	}
+	notFound := fmt.Errorf("failed to get repo PR template: %w", &stepsecurityapi.APIError{StatusCode: http.StatusNotFound, Body: "not found"})
+
+	testCases := []struct {
+		name           string
+		setup          func(*stepsecurityapi.MockStepSecurityClient)
+		expectedSource string
+		expectedTitle  string
+	}{
+		{
+			name: "repository_template",
+			setup: func(m *stepsecurityapi.MockStepSecurityClient) {
+				m.On("GetRepoGitHubPRTemplate", mock.Anything, "test-org", "monorepo").Return(repoTemplate, nil)
+			},
+			expectedSource: "repository",
+			expectedTitle:  repoTemplate.Title,
+		},
+		{
+			name: "falls_back_to_organization",
+			setup: func(m *stepsecurityapi.MockStepSecurityClient) {
+				m.On("GetRepoGitHubPRTemplate", mock.Anything, "test-org", "monorepo").Return((*stepsecurityapi.GitHubPRTemplate)(nil), notFound)
+				m.On("GetGitHubPRTemplate", mock.Anything, "test-org").Return(orgTemplate, nil)
+			},
+			expectedSource: "organization",
+			expectedTitle:  orgTemplate.Title,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			mockClient := &stepsecurityapi.MockStepSecurityClient{}
+			tc.setup(mockClient)
+
+			resp := testDataSourceRead(t, &githubEffectivePRTemplateDataSource{client: mockClient}, map[string]tftypes.Value{
+				"owner": tftypes.NewValue(tftypes.String, "test-org"),
+				"repo":  tftypes.NewValue(tftypes.String, "monorepo"),
+			})
+			require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+			mockClient.AssertExpectations(t)
+
+			var state githubEffectivePRTemplateDataSourceModel
+			require.False(t, resp.State.Get(ctx, &state).HasError())
+			assert.Equal(t, "test-org/monorepo", state.ID.ValueString())
+			assert.Equal(t, tc.expectedSource, state.Source.ValueString())
+			assert.Equal(t, tc.expectedTitle, state.Title.ValueString())
+			assert.NotContains(t, state.RenderedPreview.ValueString(), "{{STEPSECURITY_SECURITY_FIXES}}")
+		})
+	}
+}
+
+func TestGithubEffectivePRTemplateDataSource_ReadError(t *testing.T) {
+	t.Parallel()
+
+	mockClient := &stepsecurityapi.MockStepSecurityClient{}
+	mockClient.On("GetRepoGitHubPRTemplate", mock.Anything, "test-org", "monorepo").Return((*stepsecurityapi.GitHubPRTemplate)(nil), errors.New("boom"))
+
+	resp := testDataSourceRead(t, &githubEffectivePRTemplateDataSource{client: mockClient}, map[string]tftypes.Value{
+		"owner": tftypes.NewValue(tftypes.String, "test-org"),
+		"repo":  tftypes.NewValue(tftypes.String, "monorepo"),
+	})
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "boom")
+}
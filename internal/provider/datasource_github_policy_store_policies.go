@@ -0,0 +1,269 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &githubPolicyStorePoliciesDataSource{}
+	_ datasource.DataSourceWithConfigure = &githubPolicyStorePoliciesDataSource{}
+)
+
+// NewGithubPolicyStorePoliciesDataSource is a helper function to simplify the provider implementation.
+func NewGithubPolicyStorePoliciesDataSource() datasource.DataSource {
+	return &githubPolicyStorePoliciesDataSource{}
+}
+
+// githubPolicyStorePoliciesDataSource lists the Harden-Runner policies in the policy store of an owner.
+type githubPolicyStorePoliciesDataSource struct {
+	client stepsecurityapi.Client
+}
+
+type githubPolicyStorePoliciesDataSourceModel struct {
+	Owner    types.String `tfsdk:"owner"`
+	Policies types.List   `tfsdk:"policies"`
+}
+
+var policyStoreRepoAttachmentAttrTypes = map[string]attr.Type{
+	"name":          types.StringType,
+	"apply_to_repo": types.BoolType,
+	"workflows":     types.ListType{ElemType: types.StringType},
+}
+
+var policyStoreAttachmentsAttrTypes = map[string]attr.Type{
+	"apply_to_org": types.BoolType,
+	"repositories": types.ListType{ElemType: types.ObjectType{AttrTypes: policyStoreRepoAttachmentAttrTypes}},
+	"clusters":     types.ListType{ElemType: types.StringType},
+}
+
+var policyStorePolicyAttrTypes = map[string]attr.Type{
+	"policy_name":             types.StringType,
+	"egress_policy":           types.StringType,
+	"allowed_endpoints":       types.ListType{ElemType: types.StringType},
+	"disable_telemetry":       types.BoolType,
+	"disable_sudo":            types.BoolType,
+	"disable_file_monitoring": types.BoolType,
+	"lockdown":                types.ObjectType{AttrTypes: lockdownAttrTypes()},
+	"attachments":             types.ObjectType{AttrTypes: policyStoreAttachmentsAttrTypes},
+}
+
+// Metadata returns the data source type name.
+func (d *githubPolicyStorePoliciesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_github_policy_store_policies"
+}
+
+// Schema defines the schema for the data source.
+func (d *githubPolicyStorePoliciesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves all Harden-Runner policies in the policy store of a GitHub organization, together with where each policy is attached.",
+		Attributes: map[string]schema.Attribute{
+			"owner": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The GitHub organization or user to retrieve the policies for.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"policies": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The policies in the policy store.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"policy_name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The name of the policy.",
+						},
+						"egress_policy": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The egress policy mode, `audit` or `block`.",
+						},
+						"allowed_endpoints": schema.ListAttribute{
+							Computed:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "The endpoints allowed when the egress policy is `block`.",
+						},
+						"disable_telemetry": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether telemetry collection is disabled.",
+						},
+						"disable_sudo": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether sudo access is disabled for the Harden-Runner agent.",
+						},
+						"disable_file_monitoring": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether file monitoring is disabled.",
+						},
+						"lockdown": schema.SingleNestedAttribute{
+							Computed:            true,
+							MarkdownDescription: "The lockdown configuration. Null when the policy has none.",
+							Attributes: map[string]schema.Attribute{
+								"enabled": schema.BoolAttribute{
+									Computed:            true,
+									MarkdownDescription: "Whether lockdown mode is enabled.",
+								},
+								"privileged_container": schema.BoolAttribute{
+									Computed:            true,
+									MarkdownDescription: "Whether a Privileged-Container detection triggers lockdown.",
+								},
+								"runner_worker_memory_read": schema.BoolAttribute{
+									Computed:            true,
+									MarkdownDescription: "Whether a Runner-Worker-Memory-Read detection triggers lockdown.",
+								},
+								"reverse_shell": schema.BoolAttribute{
+									Computed:            true,
+									MarkdownDescription: "Whether a Reverse-Shell detection triggers lockdown.",
+								},
+							},
+						},
+						"attachments": schema.SingleNestedAttribute{
+							Computed:            true,
+							MarkdownDescription: "Where the policy is attached. Null when the policy is not attached.",
+							Attributes: map[string]schema.Attribute{
+								"apply_to_org": schema.BoolAttribute{
+									Computed:            true,
+									MarkdownDescription: "Whether the policy applies to the entire organization.",
+								},
+								"repositories": schema.ListNestedAttribute{
+									Computed:            true,
+									MarkdownDescription: "The repositories the policy is attached to.",
+									NestedObject: schema.NestedAttributeObject{
+										Attributes: map[string]schema.Attribute{
+											"name": schema.StringAttribute{
+												Computed:            true,
+												MarkdownDescription: "The repository name.",
+											},
+											"apply_to_repo": schema.BoolAttribute{
+												Computed:            true,
+												MarkdownDescription: "Whether the policy applies to every workflow of the repository.",
+											},
+											"workflows": schema.ListAttribute{
+												Computed:            true,
+												ElementType:         types.StringType,
+												MarkdownDescription: "The workflows of the repository the policy is attached to.",
+											},
+										},
+									},
+								},
+								"clusters": schema.ListAttribute{
+									Computed:            true,
+									ElementType:         types.StringType,
+									MarkdownDescription: "The clusters the policy is attached to.",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *githubPolicyStorePoliciesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(stepsecurityapi.Client)
+	if !ok || client == nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected stepsecurityapi.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *githubPolicyStorePoliciesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state githubPolicyStorePoliciesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policies, err := d.client.ListGitHubPolicyStorePolicies(ctx, state.Owner.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading GitHub policy store policies",
+			"Could not list the policy store policies for owner "+state.Owner.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	values := make([]attr.Value, 0, len(policies))
+	for _, policy := range policies {
+		values = append(values, policyStorePolicyValue(policy))
+	}
+
+	state.Policies = types.ListValueMust(types.ObjectType{AttrTypes: policyStorePolicyAttrTypes}, values)
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// policyStorePolicyValue converts a policy store policy to its data source object.
+func policyStorePolicyValue(policy stepsecurityapi.GitHubPolicyStorePolicy) attr.Value {
+	lockdown := types.ObjectNull(lockdownAttrTypes())
+	if policy.Lockdown != nil {
+		detections := make(map[string]bool, len(policy.Lockdown.Detections))
+		for _, detection := range policy.Lockdown.Detections {
+			detections[detection] = true
+		}
+		lockdown = types.ObjectValueMust(lockdownAttrTypes(), map[string]attr.Value{
+			"enabled":                   types.BoolValue(policy.Lockdown.Enabled),
+			"privileged_container":      types.BoolValue(detections["Privileged-Container"]),
+			"runner_worker_memory_read": types.BoolValue(detections["Runner-Worker-Memory-Read"]),
+			"reverse_shell":             types.BoolValue(detections["Reverse-Shell"]),
+		})
+	}
+
+	return types.ObjectValueMust(policyStorePolicyAttrTypes, map[string]attr.Value{
+		"policy_name":             types.StringValue(policy.PolicyName),
+		"egress_policy":           types.StringValue(policy.EgressPolicy),
+		"allowed_endpoints":       types.ListValueMust(types.StringType, stringsToAttrValues(policy.AllowedEndpoints)),
+		"disable_telemetry":       types.BoolValue(policy.DisableTelemetry),
+		"disable_sudo":            types.BoolValue(policy.DisableSudo),
+		"disable_file_monitoring": types.BoolValue(policy.DisableFileMonitoring),
+		"lockdown":                lockdown,
+		"attachments":             policyStoreAttachmentsValue(policy.Attachments),
+	})
+}
+
+// policyStoreAttachmentsValue converts policy attachments to their data source object.
+func policyStoreAttachmentsValue(attachments *stepsecurityapi.PolicyAttachments) attr.Value {
+	if attachments == nil || (attachments.Org == nil && len(attachments.Clusters) == 0) {
+		return types.ObjectNull(policyStoreAttachmentsAttrTypes)
+	}
+
+	applyToOrg := false
+	repos := make([]attr.Value, 0)
+	if attachments.Org != nil {
+		applyToOrg = attachments.Org.ApplyToOrg
+		for _, repo := range attachments.Org.Repos {
+			repos = append(repos, types.ObjectValueMust(policyStoreRepoAttachmentAttrTypes, map[string]attr.Value{
+				"name":          types.StringValue(repo.Name),
+				"apply_to_repo": types.BoolValue(repo.ApplyToRepo),
+				"workflows":     types.ListValueMust(types.StringType, stringsToAttrValues(repo.Workflows)),
+			}))
+		}
+	}
+
+	return types.ObjectValueMust(policyStoreAttachmentsAttrTypes, map[string]attr.Value{
+		"apply_to_org": types.BoolValue(applyToOrg),
+		"repositories": types.ListValueMust(types.ObjectType{AttrTypes: policyStoreRepoAttachmentAttrTypes}, repos),
+		"clusters":     types.ListValueMust(types.StringType, stringsToAttrValues(attachments.Clusters)),
+	})
+}
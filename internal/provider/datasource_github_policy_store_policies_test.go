@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	fwdatasource "github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
+)
+
+func TestGithubPolicyStorePoliciesDataSource_Metadata(t *testing.T) {
+	t.Parallel()
+
+	resp := &fwdatasource.MetadataResponse{}
+	NewGithubPolicyStorePoliciesDataSource().Metadata(context.Background(), fwdatasource.MetadataRequest{ProviderTypeName: "stepsecurity"}, resp)
+	assert.Equal(t, "stepsecurity_github_policy_store_policies", resp.TypeName)
+}
+
+func TestGithubPolicyStorePoliciesDataSource_Read(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mockClient := &stepsecurityapi.MockStepSecurityClient{}
+	mockClient.On("ListGitHubPolicyStorePolicies", mock.Anything, "test-org").Return([]stepsecurityapi.GitHubPolicyStorePolicy{
+		{
+			Owner:            "test-org",
+			PolicyName:       "audit-all",
+			EgressPolicy:     "audit",
+			AllowedEndpoints: []string{"github.com:443"},
+		},
+		{
+			Owner:            "test-org",
+			PolicyName:       "locked",
+			EgressPolicy:     "block",
+			AllowedEndpoints: []string{"github.com:443", "registry.npmjs.org:443"},
+			DisableSudo:      true,
+			Lockdown:         &stepsecurityapi.LockdownConfig{Enabled: true, Detections: []string{"Reverse-Shell", "Privileged-Container"}},
+			Attachments: &stepsecurityapi.PolicyAttachments{
+				Org: &stepsecurityapi.OrgResource{
+					Name: "test-org",
+					Repos: []stepsecurityapi.RepoResource{
+						{Name: "api", Workflows: []string{"ci.yml"}},
+						{Name: "web", ApplyToRepo: true},
+					},
+				},
+				Clusters: []string{"prod"},
+			},
+		},
+	}, nil).Once()
+
+	resp := testDataSourceRead(t, &githubPolicyStorePoliciesDataSource{client: mockClient}, map[string]tftypes.Value{"owner": tftypes.NewValue(tftypes.String, "test-org")})
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+	mockClient.AssertExpectations(t)
+
+	var state githubPolicyStorePoliciesDataSourceModel
+	require.False(t, resp.State.Get(ctx, &state).HasError())
+	require.Len(t, state.Policies.Elements(), 2)
+
+	audit := state.Policies.Elements()[0].(types.Object).Attributes()
+	assert.Equal(t, "audit-all", audit["policy_name"].(types.String).ValueString())
+	assert.Equal(t, "audit", audit["egress_policy"].(types.String).ValueString())
+	assert.True(t, audit["lockdown"].IsNull())
+	assert.True(t, audit["attachments"].IsNull())
+
+	locked := state.Policies.Elements()[1].(types.Object).Attributes()
+	assert.Equal(t, "block", locked["egress_policy"].(types.String).ValueString())
+	assert.Len(t, locked["allowed_endpoints"].(types.List).Elements(), 2)
+	assert.True(t, locked["disable_sudo"].(types.Bool).ValueBool())
+
+	lockdown := locked["lockdown"].(types.Object).Attributes()
+	assert.True(t, lockdown["enabled"].(types.Bool).ValueBool())
+	assert.True(t, lockdown["reverse_shell"].(types.Bool).ValueBool())
+	assert.True(t, lockdown["privileged_container"].(types.Bool).ValueBool())
+	assert.False(t, lockdown["runner_worker_memory_read"].(types.Bool).ValueBool())
+
+	attachments := locked["attachments"].(types.Object).Attributes()
+	assert.False(t, attachments["apply_to_org"].(types.Bool).ValueBool())
+	assert.Len(t, attachments["clusters"].(types.List).Elements(), 1)
+	repos := attachments["repositories"].(types.List).Elements()
+	require.Len(t, repos, 2)
+	api := repos[0].(types.Object).Attributes()
+	assert.Equal(t, "api", api["name"].(types.String).ValueString())
+	assert.False(t, api["apply_to_repo"].(types.Bool).ValueBool())
+	assert.Len(t, api["workflows"].(types.List).Elements(), 1)
+	web := repos[1].(types.Object).Attributes()
+	assert.True(t, web["apply_to_repo"].(types.Bool).ValueBool())
+	assert.Empty(t, web["workflows"].(types.List).Elements())
+}
+
+func TestGithubPolicyStorePoliciesDataSource_ReadError(t *testing.T) {
+	t.Parallel()
+
+	mockClient := &stepsecurityapi.MockStepSecurityClient{}
+	mockClient.On("ListGitHubPolicyStorePolicies", mock.Anything, "test-org").Return([]stepsecurityapi.GitHubPolicyStorePolicy(nil), errors.New("boom")).Once()
+
+	resp := testDataSourceRead(t, &githubPolicyStorePoliciesDataSource{client: mockClient}, map[string]tftypes.Value{"owner": tftypes.NewValue(tftypes.String, "test-org")})
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "boom")
+}
@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &githubPRTemplateDefaultDataSource{}
+	_ datasource.DataSourceWithConfigure = &githubPRTemplateDefaultDataSource{}
+)
+
+// NewGithubPRTemplateDefaultDataSource is a helper function to simplify the provider implementation.
+func NewGithubPRTemplateDefaultDataSource() datasource.DataSource {
+	return &githubPRTemplateDefaultDataSource{}
+}
+
+// githubPRTemplateDefaultDataSource retrieves StepSecurity's default PR template.
+type githubPRTemplateDefaultDataSource struct {
+	client stepsecurityapi.Client
+}
+
+type githubPRTemplateDefaultDataSourceModel struct {
+	Owner         types.String `tfsdk:"owner"`
+	Title         types.String `tfsdk:"title"`
+	Summary       types.String `tfsdk:"summary"`
+	CommitMessage types.String `tfsdk:"commit_message"`
+	Labels        types.List   `tfsdk:"labels"`
+}
+
+// Metadata returns the data source type name.
+func (d *githubPRTemplateDefaultDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_github_pr_template_default"
+}
+
+// Schema defines the schema for the data source.
+func (d *githubPRTemplateDefaultDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves StepSecurity's default PR template for policy-driven PRs, which an owner's template is reset to when " +
+			"`stepsecurity_github_pr_template` is destroyed. Use it to start from and extend the official template.",
+		Attributes: map[string]schema.Attribute{
+			"owner": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The GitHub organization or user to retrieve the default template for.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"title": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The default PR title.",
+			},
+			"summary": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The default PR summary, including the `" + stepsecurityapi.PRTemplateSecurityFixesPlaceholder + "` placeholder.",
+			},
+			"commit_message": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The default commit message.",
+			},
+			"labels": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The default PR labels.",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *githubPRTemplateDefaultDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(stepsecurityapi.Client)
+	if !ok || client == nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected stepsecurityapi.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *githubPRTemplateDefaultDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state githubPRTemplateDefaultDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	template, err := d.client.GetDefaultGitHubPRTemplate(ctx, state.Owner.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading default PR template",
+			"Could not read the default PR template for owner "+state.Owner.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	state.Title = types.StringValue(template.Title)
+	state.Summary = types.StringValue(template.Summary)
+	state.CommitMessage = types.StringValue(template.CommitMessage)
+	state.Labels = types.ListValueMust(types.StringType, stringsToAttrValues(template.Labels))
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
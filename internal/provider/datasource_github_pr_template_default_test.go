@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	fwdatasource "github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
+)
+
+func TestGithubPRTemplateDefaultDataSource_Metadata(t *testing.T) {
+	t.Parallel()
+
+	resp := &fwdatasource.MetadataResponse{}
+	NewGithubPRTemplateDefaultDataSource().Metadata(context.Background(), fwdatasource.MetadataRequest{ProviderTypeName: "stepsecurity"}, resp)
+	assert.Equal(t, "stepsecurity_github_pr_template_default", resp.TypeName)
+}
+
+func TestGithubPRTemplateDefaultDataSource_Read(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mockClient := &stepsecurityapi.MockStepSecurityClient{}
+	mockClient.On("GetDefaultGitHubPRTemplate", mock.Anything, "test-org").Return(&stepsecurityapi.GitHubPRTemplate{
+		Title:         "[StepSecurity] Apply security best practices",
+		Summary:       "## Security Fixes\n\n{{STEPSECURITY_SECURITY_FIXES}}",
+		CommitMessage: "[StepSecurity] Apply security best practices",
+	}, nil).Once()
+
+	resp := testDataSourceRead(t, &githubPRTemplateDefaultDataSource{client: mockClient}, map[string]tftypes.Value{"owner": tftypes.NewValue(tftypes.String, "test-org")})
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+	mockClient.AssertExpectations(t)
+
+	var state githubPRTemplateDefaultDataSourceModel
+	require.False(t, resp.State.Get(ctx, &state).HasError())
+	assert.Equal(t, "[StepSecurity] Apply security best practices", state.Title.ValueString())
+	assert.Equal(t, "## Security Fixes\n\n{{STEPSECURITY_SECURITY_FIXES}}", state.Summary.ValueString())
+	assert.Equal(t, "[StepSecurity] Apply security best practices", state.CommitMessage.ValueString())
+	assert.False(t, state.Labels.IsNull())
+	assert.Empty(t, state.Labels.Elements())
+}
+
+func TestGithubPRTemplateDefaultDataSource_ReadError(t *testing.T) {
+	t.Parallel()
+
+	mockClient := &stepsecurityapi.MockStepSecurityClient{}
+	mockClient.On("GetDefaultGitHubPRTemplate", mock.Anything, "test-org").Return((*stepsecurityapi.GitHubPRTemplate)(nil), errors.New("boom")).Once()
+
+	resp := testDataSourceRead(t, &githubPRTemplateDefaultDataSource{client: mockClient}, map[string]tftypes.Value{"owner": tftypes.NewValue(tftypes.String, "test-org")})
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "boom")
+}
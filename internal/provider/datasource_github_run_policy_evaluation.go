@@ -0,0 +1,587 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gopkg.in/yaml.v3"
+
+	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &githubRunPolicyEvaluationDataSource{}
+	_ datasource.DataSourceWithConfigure = &githubRunPolicyEvaluationDataSource{}
+)
+
+const (
+	runPolicyRuleAllowedActions = "allowed_actions"
+	runPolicyRulePinnedActions  = "pinned_actions"
+	runPolicyRuleRunsOn         = "runs_on"
+	runPolicyRuleJobContainer   = "job_container"
+)
+
+var fullCommitSHAPattern = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// standardRunnerLabels approximates the GitHub-hosted runner label set the backend
+// expands enable_standard_runner_labels to. The backend list is authoritative.
+var standardRunnerLabels = map[string]struct{}{
+	"ubuntu-latest":       {},
+	"ubuntu-24.04":        {},
+	"ubuntu-22.04":        {},
+	"ubuntu-20.04":        {},
+	"ubuntu-24.04-arm":    {},
+	"ubuntu-22.04-arm":    {},
+	"windows-latest":      {},
+	"windows-2025":        {},
+	"windows-2022":        {},
+	"windows-2019":        {},
+	"windows-11-arm":      {},
+	"macos-latest":        {},
+	"macos-latest-large":  {},
+	"macos-latest-xlarge": {},
+	"macos-15":            {},
+	"macos-15-large":      {},
+	"macos-15-xlarge":     {},
+	"macos-14":            {},
+	"macos-14-large":      {},
+	"macos-14-xlarge":     {},
+	"macos-13":            {},
+	"macos-13-large":      {},
+	"macos-13-xlarge":     {},
+}
+
+// NewGithubRunPolicyEvaluationDataSource is a helper function to simplify the provider implementation.
+func NewGithubRunPolicyEvaluationDataSource() datasource.DataSource {
+	return &githubRunPolicyEvaluationDataSource{}
+}
+
+// githubRunPolicyEvaluationDataSource is the data source implementation.
+type githubRunPolicyEvaluationDataSource struct {
+	client stepsecurityapi.Client
+}
+
+// githubRunPolicyEvaluationDataSourceModel maps the data source schema data.
+type githubRunPolicyEvaluationDataSourceModel struct {
+	Owner              types.String `tfsdk:"owner"`
+	Repo               types.String `tfsdk:"repo"`
+	Workflow           types.String `tfsdk:"workflow"`
+	EvaluatedPolicyIDs types.List   `tfsdk:"evaluated_policy_ids"`
+	Blocked            types.Bool   `tfsdk:"blocked"`
+	WouldBeBlocked     types.Bool   `tfsdk:"would_be_blocked"`
+	Violations         types.List   `tfsdk:"violations"`
+}
+
+// runPolicyViolation is a single finding produced by evaluating a workflow.
+type runPolicyViolation struct {
+	PolicyID   string
+	PolicyName string
+	IsDryRun   bool
+	Job        string
+	StepIndex  *int64
+	Step       string
+	Rule       string
+	Message    string
+}
+
+var runPolicyViolationAttrTypes = map[string]attr.Type{
+	"policy_id":   types.StringType,
+	"policy_name": types.StringType,
+	"is_dry_run":  types.BoolType,
+	"job":         types.StringType,
+	"step_index":  types.Int64Type,
+	"step":        types.StringType,
+	"rule":        types.StringType,
+	"message":     types.StringType,
+}
+
+// Metadata returns the data source type name.
+func (d *githubRunPolicyEvaluationDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_github_run_policy_evaluation"
+}
+
+// Schema defines the schema for the data source.
+func (d *githubRunPolicyEvaluationDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Evaluates a GitHub Actions workflow against the run policies that apply to a repository, without running it. " +
+			"Use it to check whether a workflow would be blocked before setting `is_dry_run = false` on a policy. " +
+			"The evaluation is performed locally by the provider and covers allowed actions, action pinning, runner labels and job containers; " +
+			"the StepSecurity backend remains authoritative.",
+		Attributes: map[string]schema.Attribute{
+			"owner": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The GitHub organization or user whose run policies are evaluated.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"repo": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The repository the workflow belongs to. Only policies that apply to this repository are evaluated.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"workflow": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The workflow file contents as a YAML string, e.g. `file(\".github/workflows/ci.yml\")`.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"evaluated_policy_ids": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "IDs of the run policies that apply to the repository and were evaluated.",
+			},
+			"blocked": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether an enforced (non dry-run) policy would block the workflow today.",
+			},
+			"would_be_blocked": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the workflow would be blocked if every evaluated policy were enforced, i.e. after setting `is_dry_run = false`.",
+			},
+			"violations": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Violations found, in workflow order.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"policy_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the policy that reported the violation.",
+						},
+						"policy_name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The name of the policy that reported the violation.",
+						},
+						"is_dry_run": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the policy is currently in dry-run mode.",
+						},
+						"job": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the job containing the violation.",
+						},
+						"step_index": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Zero-based index of the offending step, or null for job-level violations.",
+						},
+						"step": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The step name, falling back to its `id` or `uses`. Empty for job-level violations.",
+						},
+						"rule": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The rule that was violated: `allowed_actions`, `pinned_actions`, `runs_on` or `job_container`.",
+						},
+						"message": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "A human-readable description of the violation.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *githubRunPolicyEvaluationDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(stepsecurityapi.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected stepsecurityapi.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read evaluates the workflow against the applicable run policies.
+func (d *githubRunPolicyEvaluationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state githubRunPolicyEvaluationDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	workflow, err := parseWorkflow(state.Workflow.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("workflow"),
+			"Invalid workflow",
+			"Could not parse the workflow YAML: "+err.Error(),
+		)
+		return
+	}
+
+	policies, err := d.client.ListRunPolicies(ctx, state.Owner.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading run policies",
+			"Could not read run policies for owner "+state.Owner.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	policyIDs := make([]attr.Value, 0, len(policies))
+	var violations []runPolicyViolation
+	for _, policy := range policies {
+		if !runPolicyAppliesToRepo(policy, state.Repo.ValueString()) {
+			continue
+		}
+		policyIDs = append(policyIDs, types.StringValue(policy.PolicyID))
+		violations = append(violations, evaluateWorkflowAgainstRunPolicy(workflow, policy)...)
+	}
+
+	blocked := false
+	violationValues := make([]attr.Value, 0, len(violations))
+	for _, v := range violations {
+		if !v.IsDryRun {
+			blocked = true
+		}
+		stepIndex := types.Int64Null()
+		if v.StepIndex != nil {
+			stepIndex = types.Int64Value(*v.StepIndex)
+		}
+		obj, objDiags := types.ObjectValue(runPolicyViolationAttrTypes, map[string]attr.Value{
+			"policy_id":   types.StringValue(v.PolicyID),
+			"policy_name": types.StringValue(v.PolicyName),
+			"is_dry_run":  types.BoolValue(v.IsDryRun),
+			"job":         types.StringValue(v.Job),
+			"step_index":  stepIndex,
+			"step":        types.StringValue(v.Step),
+			"rule":        types.StringValue(v.Rule),
+			"message":     types.StringValue(v.Message),
+		})
+		resp.Diagnostics.Append(objDiags...)
+		violationValues = append(violationValues, obj)
+	}
+
+	idsList, listDiags := types.ListValue(types.StringType, policyIDs)
+	resp.Diagnostics.Append(listDiags...)
+	violationsList, listDiags := types.ListValue(types.ObjectType{AttrTypes: runPolicyViolationAttrTypes}, violationValues)
+	resp.Diagnostics.Append(listDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.EvaluatedPolicyIDs = idsList
+	state.Blocked = types.BoolValue(blocked)
+	state.WouldBeBlocked = types.BoolValue(len(violations) > 0)
+	state.Violations = violationsList
+
+	// Set the state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// runPolicyAppliesToRepo reports whether policy covers repo. Only the name patterns of a
+// repository filter are checked; topics and visibility are not known locally, so a policy
+// filtered by them is assumed to apply.
+func runPolicyAppliesToRepo(policy stepsecurityapi.RunPolicy, repo string) bool {
+	if policy.AllOrgs {
+		return true
+	}
+	if policy.AllRepos {
+		return policy.RepositoryFilter.MatchesName(repo)
+	}
+	for _, r := range policy.Repositories {
+		if strings.EqualFold(r, repo) {
+			return true
+		}
+	}
+	return false
+}
+
+// parsedWorkflow holds the parts of a workflow file that run policies inspect.
+type parsedWorkflow struct {
+	Jobs []parsedWorkflowJob
+}
+
+type parsedWorkflowJob struct {
+	ID           string
+	Uses         string
+	RunsOn       []string
+	HasContainer bool
+	Steps        []parsedWorkflowStep
+}
+
+type parsedWorkflowStep struct {
+	ID   string `yaml:"id"`
+	Name string `yaml:"name"`
+	Uses string `yaml:"uses"`
+}
+
+// label returns the name used to refer to the step in violations.
+func (s parsedWorkflowStep) label() string {
+	switch {
+	case s.Name != "":
+		return s.Name
+	case s.ID != "":
+		return s.ID
+	}
+	return s.Uses
+}
+
+// parseWorkflow extracts jobs from workflow YAML, preserving their order in the file.
+func parseWorkflow(content string) (*parsedWorkflow, error) {
+	var raw struct {
+		Jobs yaml.Node `yaml:"jobs"`
+	}
+	if err := yaml.Unmarshal([]byte(content), &raw); err != nil {
+		return nil, err
+	}
+	if raw.Jobs.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("workflow must define a \"jobs\" mapping")
+	}
+
+	workflow := &parsedWorkflow{}
+	for i := 0; i+1 < len(raw.Jobs.Content); i += 2 {
+		var job struct {
+			Uses      string               `yaml:"uses"`
+			RunsOn    yaml.Node            `yaml:"runs-on"`
+			Container yaml.Node            `yaml:"container"`
+			Steps     []parsedWorkflowStep `yaml:"steps"`
+		}
+		id := raw.Jobs.Content[i].Value
+		if err := raw.Jobs.Content[i+1].Decode(&job); err != nil {
+			return nil, fmt.Errorf("job %q: %w", id, err)
+		}
+		runsOn, err := runsOnLabels(job.RunsOn)
+		if err != nil {
+			return nil, fmt.Errorf("job %q: %w", id, err)
+		}
+		workflow.Jobs = append(workflow.Jobs, parsedWorkflowJob{
+			ID:           id,
+			Uses:         job.Uses,
+			RunsOn:       runsOn,
+			HasContainer: job.Container.Kind != 0 && job.Container.Tag != "!!null",
+			Steps:        job.Steps,
+		})
+	}
+	return workflow, nil
+}
+
+// runsOnLabels flattens the string, list and {group, labels} forms of runs-on.
+func runsOnLabels(node yaml.Node) ([]string, error) {
+	switch node.Kind {
+	case 0:
+		return nil, nil
+	case yaml.ScalarNode:
+		return []string{node.Value}, nil
+	case yaml.SequenceNode:
+		var labels []string
+		err := node.Decode(&labels)
+		return labels, err
+	case yaml.MappingNode:
+		var grouped struct {
+			Labels yaml.Node `yaml:"labels"`
+		}
+		if err := node.Decode(&grouped); err != nil {
+			return nil, err
+		}
+		return runsOnLabels(grouped.Labels)
+	}
+	return nil, fmt.Errorf("unsupported runs-on value")
+}
+
+// evaluateWorkflowAgainstRunPolicy returns the violations of a single policy. It mirrors
+// the backend rules for the checks that can be evaluated from the workflow file alone.
+func evaluateWorkflowAgainstRunPolicy(workflow *parsedWorkflow, policy stepsecurityapi.RunPolicy) []runPolicyViolation {
+	config := policy.PolicyConfig
+	var violations []runPolicyViolation
+	report := func(job string, stepIndex *int64, step, rule, message string) {
+		violations = append(violations, runPolicyViolation{
+			PolicyID:   policy.PolicyID,
+			PolicyName: policy.Name,
+			IsDryRun:   config.IsDryRun,
+			Job:        job,
+			StepIndex:  stepIndex,
+			Step:       step,
+			Rule:       rule,
+			Message:    message,
+		})
+	}
+
+	allowed := parseActionPatterns(mapKeys(config.AllowedActions))
+	exempt := parseActionPatterns(config.PinnedActionsExemptions)
+	checkUses := func(job string, stepIndex *int64, step, uses string) {
+		if !config.EnableActionPolicy || uses == "" {
+			return
+		}
+		ref, err := parseActionReference(uses)
+		if err != nil {
+			// Local and Docker actions are not subject to the action policy.
+			return
+		}
+		// Without pinning the action policy is an allow list, and an empty list allows
+		// nothing. With pinning the list only applies when actions are listed.
+		checkAllowed := !config.RequirePinnedActions || len(config.AllowedActions) > 0
+		if checkAllowed && !matchesAnyActionPattern(allowed, ref) {
+			report(job, stepIndex, step, runPolicyRuleAllowedActions, fmt.Sprintf("Action %q is not in the allowed actions list.", uses))
+		}
+		if config.RequirePinnedActions && !fullCommitSHAPattern.MatchString(ref.Ref) && !matchesAnyActionPattern(exempt, ref) {
+			report(job, stepIndex, step, runPolicyRulePinnedActions, fmt.Sprintf("Action %q is not pinned to a full-length commit SHA.", uses))
+		}
+	}
+
+	for _, job := range workflow.Jobs {
+		checkUses(job.ID, nil, "", job.Uses)
+		for i, step := range job.Steps {
+			index := int64(i)
+			checkUses(job.ID, &index, step.label(), step.Uses)
+		}
+
+		if config.EnableRunsOnPolicy {
+			for _, message := range evaluateRunsOn(config, job.RunsOn) {
+				report(job.ID, nil, "", runPolicyRuleRunsOn, message)
+			}
+		}
+
+		if config.EnableHardenRunnerPolicy && config.BlockJobContainer && job.HasContainer && hardenRunnerTargetsJob(config, job.RunsOn) {
+			report(job.ID, nil, "", runPolicyRuleJobContainer, "Job runs inside a job-level container, which Harden Runner cannot monitor.")
+		}
+	}
+
+	return violations
+}
+
+// evaluateRunsOn returns a message for each runner label the policy rejects. Plain
+// labels containing expressions cannot be resolved locally and are skipped.
+func evaluateRunsOn(config stepsecurityapi.RunPolicyConfig, labels []string) []string {
+	var messages []string
+	if config.RunsOnMode == runsOnModeAllowed {
+		for _, label := range labels {
+			if strings.Contains(label, "=") {
+				if reason := runnerConstraintViolation(config.AllowedRunnerConstraints, label); reason != "" {
+					messages = append(messages, reason)
+				}
+				continue
+			}
+			if strings.Contains(label, "${{") {
+				continue
+			}
+			if !containsFold(config.AllowedRunnerLabels, label) {
+				messages = append(messages, fmt.Sprintf("Runner label %q is not in the allowed runner labels.", label))
+			}
+		}
+		return messages
+	}
+
+	for _, label := range labels {
+		if containsFold(config.DisallowedRunnerLabels, label) {
+			messages = append(messages, fmt.Sprintf("Runner label %q is disallowed.", label))
+			continue
+		}
+		if _, ok := standardRunnerLabels[strings.ToLower(label)]; ok && config.EnableStandardRunnerLabels {
+			messages = append(messages, fmt.Sprintf("Runner label %q is a GitHub-hosted standard runner label, which is disallowed.", label))
+		}
+	}
+	return messages
+}
+
+// runnerConstraintViolation checks a runs-on.com style label (`key=value/key=value`).
+// A token is allowed when its key is unconstrained or its value is in the allowed set;
+// values are compared ignoring whitespace so expressions match by their text.
+func runnerConstraintViolation(constraints map[string][]string, label string) string {
+	if len(constraints) == 0 {
+		return fmt.Sprintf("Runner label %q uses runner constraints, but the policy allows none.", label)
+	}
+	for _, token := range strings.Split(label, "/") {
+		key, value, ok := strings.Cut(token, "=")
+		if !ok {
+			continue
+		}
+		allowedValues, constrained := constraints[strings.ToLower(strings.TrimSpace(key))]
+		if !constrained {
+			continue
+		}
+		found := false
+		for _, allowedValue := range allowedValues {
+			if stripWhitespace(allowedValue) == stripWhitespace(value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Sprintf("Runner constraint %q in label %q is not allowed.", strings.TrimSpace(token), label)
+		}
+	}
+	return ""
+}
+
+// hardenRunnerTargetsJob reports whether the Harden Runner policy targets a job with
+// the given runner labels. No target labels means every job is targeted; the runs-on
+// policy's standard runner labels setting plays no part.
+func hardenRunnerTargetsJob(config stepsecurityapi.RunPolicyConfig, labels []string) bool {
+	if len(config.HardenRunnerTargetLabels) == 0 {
+		return true
+	}
+	for _, label := range labels {
+		for _, target := range config.HardenRunnerTargetLabels {
+			if strings.EqualFold(label, target) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func parseActionPatterns(values []string) []actionReference {
+	patterns := make([]actionReference, 0, len(values))
+	for _, value := range values {
+		if pattern, err := parseActionReference(value); err == nil {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns
+}
+
+func matchesAnyActionPattern(patterns []actionReference, target actionReference) bool {
+	for _, pattern := range patterns {
+		if pattern.matches(target) {
+			return true
+		}
+	}
+	return false
+}
+
+func mapKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func containsFold(set map[string]struct{}, value string) bool {
+	for item := range set {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func stripWhitespace(s string) string {
+	return strings.Join(strings.Fields(s), "")
+}
@@ -0,0 +1,295 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	fwdatasource "github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
+)
+
+const testEvaluationWorkflow = `
+name: CI
+on: [push]
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - name: Setup node
+        uses: actions/setup-node@1d0ff469b7ec7b3cb9d8673fde0c81c44821de2a
+      - run: npm test
+  deploy:
+    runs-on: [self-hosted, linux]
+    container: node:20
+    steps:
+      - id: publish
+        uses: third-party/publish@main
+  reusable:
+    uses: my-org/workflows/.github/workflows/release.yml@v1
+`
+
+func TestParseWorkflow(t *testing.T) {
+	t.Parallel()
+
+	workflow, err := parseWorkflow(testEvaluationWorkflow)
+	require.NoError(t, err)
+	require.Len(t, workflow.Jobs, 3)
+
+	// Jobs keep their order from the file.
+	assert.Equal(t, "build", workflow.Jobs[0].ID)
+	assert.Equal(t, []string{"ubuntu-latest"}, workflow.Jobs[0].RunsOn)
+	assert.False(t, workflow.Jobs[0].HasContainer)
+	require.Len(t, workflow.Jobs[0].Steps, 3)
+	assert.Equal(t, "actions/checkout@v4", workflow.Jobs[0].Steps[0].label())
+	assert.Equal(t, "Setup node", workflow.Jobs[0].Steps[1].label())
+
+	assert.Equal(t, "deploy", workflow.Jobs[1].ID)
+	assert.Equal(t, []string{"self-hosted", "linux"}, workflow.Jobs[1].RunsOn)
+	assert.True(t, workflow.Jobs[1].HasContainer)
+	assert.Equal(t, "publish", workflow.Jobs[1].Steps[0].label())
+
+	assert.Equal(t, "my-org/workflows/.github/workflows/release.yml@v1", workflow.Jobs[2].Uses)
+
+	grouped, err := parseWorkflow("jobs:\n  a:\n    runs-on:\n      group: large\n      labels: [big-runner]\n")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"big-runner"}, grouped.Jobs[0].RunsOn)
+
+	_, err = parseWorkflow("name: no jobs\n")
+	assert.ErrorContains(t, err, `"jobs" mapping`)
+
+	_, err = parseWorkflow("jobs: [")
+	assert.Error(t, err)
+}
+
+func TestEvaluateWorkflowAgainstRunPolicy(t *testing.T) {
+	t.Parallel()
+
+	workflow, err := parseWorkflow(testEvaluationWorkflow)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		config   stepsecurityapi.RunPolicyConfig
+		expected []string
+	}{
+		{
+			name: "allowed actions",
+			config: stepsecurityapi.RunPolicyConfig{
+				EnableActionPolicy: true,
+				AllowedActions:     map[string]string{"actions/*": "allow"},
+			},
+			expected: []string{
+				"deploy/0/allowed_actions",
+				"reusable/-/allowed_actions",
+			},
+		},
+		{
+			name: "empty allowed actions list",
+			config: stepsecurityapi.RunPolicyConfig{
+				EnableActionPolicy: true,
+			},
+			expected: []string{
+				"build/0/allowed_actions",
+				"build/1/allowed_actions",
+				"deploy/0/allowed_actions",
+				"reusable/-/allowed_actions",
+			},
+		},
+		{
+			name: "pinned actions with exemption",
+			config: stepsecurityapi.RunPolicyConfig{
+				EnableActionPolicy:      true,
+				RequirePinnedActions:    true,
+				PinnedActionsExemptions: []string{"my-org/*"},
+			},
+			expected: []string{
+				"build/0/pinned_actions",
+				"deploy/0/pinned_actions",
+			},
+		},
+		{
+			name: "disallowed runner labels",
+			config: stepsecurityapi.RunPolicyConfig{
+				EnableRunsOnPolicy:         true,
+				DisallowedRunnerLabels:     map[string]struct{}{"Self-Hosted": {}},
+				EnableStandardRunnerLabels: true,
+			},
+			expected: []string{
+				"build/-/runs_on",
+				"deploy/-/runs_on",
+			},
+		},
+		{
+			name: "allowed runner labels",
+			config: stepsecurityapi.RunPolicyConfig{
+				EnableRunsOnPolicy:  true,
+				RunsOnMode:          runsOnModeAllowed,
+				AllowedRunnerLabels: map[string]struct{}{"ubuntu-latest": {}, "self-hosted": {}},
+			},
+			expected: []string{
+				"deploy/-/runs_on",
+			},
+		},
+		{
+			name: "job container",
+			config: stepsecurityapi.RunPolicyConfig{
+				EnableHardenRunnerPolicy: true,
+				BlockJobContainer:        true,
+			},
+			expected: []string{
+				"deploy/-/job_container",
+			},
+		},
+		{
+			name: "job container with standard runner labels",
+			config: stepsecurityapi.RunPolicyConfig{
+				EnableHardenRunnerPolicy:   true,
+				BlockJobContainer:          true,
+				EnableStandardRunnerLabels: true,
+			},
+			expected: []string{
+				"deploy/-/job_container",
+			},
+		},
+		{
+			name: "job container outside target labels",
+			config: stepsecurityapi.RunPolicyConfig{
+				EnableHardenRunnerPolicy: true,
+				BlockJobContainer:        true,
+				HardenRunnerTargetLabels: []string{"ubuntu-latest"},
+			},
+		},
+		{
+			name: "secrets policy is not evaluated",
+			config: stepsecurityapi.RunPolicyConfig{
+				EnableSecretsPolicy: true,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			violations := evaluateWorkflowAgainstRunPolicy(workflow, stepsecurityapi.RunPolicy{
+				PolicyID:     "policy-1",
+				Name:         "Policy",
+				PolicyConfig: tc.config,
+			})
+
+			var got []string
+			for _, v := range violations {
+				step := "-"
+				if v.StepIndex != nil {
+					step = fmt.Sprint(*v.StepIndex)
+				}
+				got = append(got, v.Job+"/"+step+"/"+v.Rule)
+				assert.Equal(t, "policy-1", v.PolicyID)
+				assert.NotEmpty(t, v.Message)
+			}
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestRunnerConstraintViolation(t *testing.T) {
+	t.Parallel()
+
+	constraints := map[string][]string{
+		"family":  {"c7a", "m7a"},
+		"runs-on": {"${{ github.run_id }}"},
+	}
+
+	assert.Empty(t, runnerConstraintViolation(constraints, "runs-on=${{github.run_id}}/family=c7a/cpu=4"))
+	assert.Contains(t, runnerConstraintViolation(constraints, "runs-on=${{ github.run_id }}/family=r7"), `"family=r7"`)
+	assert.Contains(t, runnerConstraintViolation(nil, "family=c7a"), "allows none")
+}
+
+func TestRunPolicyAppliesToRepo(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, runPolicyAppliesToRepo(stepsecurityapi.RunPolicy{AllRepos: true}, "any"))
+	assert.True(t, runPolicyAppliesToRepo(stepsecurityapi.RunPolicy{AllOrgs: true}, "any"))
+	assert.True(t, runPolicyAppliesToRepo(stepsecurityapi.RunPolicy{Repositories: []string{"Repo-A"}}, "repo-a"))
+	assert.False(t, runPolicyAppliesToRepo(stepsecurityapi.RunPolicy{Repositories: []string{"repo-a"}}, "repo-b"))
+
+	filtered := stepsecurityapi.RunPolicy{
+		AllRepos:         true,
+		RepositoryFilter: &stepsecurityapi.RunPolicyRepositoryFilter{IncludePatterns: []string{"svc-*"}},
+	}
+	assert.True(t, runPolicyAppliesToRepo(filtered, "svc-orders"))
+	assert.False(t, runPolicyAppliesToRepo(filtered, "website"))
+}
+
+func TestGithubRunPolicyEvaluationDataSource_Read(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	d := NewGithubRunPolicyEvaluationDataSource().(*githubRunPolicyEvaluationDataSource)
+
+	schemaResp := &fwdatasource.SchemaResponse{}
+	d.Schema(ctx, fwdatasource.SchemaRequest{}, schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	mockClient := &stepsecurityapi.MockStepSecurityClient{}
+	mockClient.On("ListRunPolicies", mock.Anything, "test-org").Return([]stepsecurityapi.RunPolicy{
+		{
+			PolicyID: "dry-run",
+			Name:     "Pinning (dry run)",
+			AllRepos: true,
+			PolicyConfig: stepsecurityapi.RunPolicyConfig{
+				EnableActionPolicy:   true,
+				RequirePinnedActions: true,
+				IsDryRun:             true,
+			},
+		},
+		{
+			PolicyID:     "other-repo",
+			Name:         "Runner labels",
+			Repositories: []string{"other"},
+			PolicyConfig: stepsecurityapi.RunPolicyConfig{
+				EnableRunsOnPolicy:     true,
+				DisallowedRunnerLabels: map[string]struct{}{"ubuntu-latest": {}},
+			},
+		},
+	}, nil)
+	d.client = mockClient
+
+	schemaType := schemaResp.Schema.Type().TerraformType(ctx)
+	config := tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw: tftypes.NewValue(schemaType, map[string]tftypes.Value{
+			"owner":                tftypes.NewValue(tftypes.String, "test-org"),
+			"repo":                 tftypes.NewValue(tftypes.String, "my-repo"),
+			"workflow":             tftypes.NewValue(tftypes.String, testEvaluationWorkflow),
+			"evaluated_policy_ids": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
+			"blocked":              tftypes.NewValue(tftypes.Bool, nil),
+			"would_be_blocked":     tftypes.NewValue(tftypes.Bool, nil),
+			"violations":           tftypes.NewValue(schemaType.(tftypes.Object).AttributeTypes["violations"], nil),
+		}),
+	}
+
+	resp := &fwdatasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	d.Read(ctx, fwdatasource.ReadRequest{Config: config}, resp)
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+	mockClient.AssertExpectations(t)
+
+	var state githubRunPolicyEvaluationDataSourceModel
+	require.False(t, resp.State.Get(ctx, &state).HasError())
+
+	var ids []string
+	require.False(t, state.EvaluatedPolicyIDs.ElementsAs(ctx, &ids, false).HasError())
+	assert.Equal(t, []string{"dry-run"}, ids)
+	// Only the dry-run policy reports violations, so nothing is blocked yet.
+	assert.False(t, state.Blocked.ValueBool())
+	assert.True(t, state.WouldBeBlocked.ValueBool())
+	assert.Len(t, state.Violations.Elements(), 3)
+}
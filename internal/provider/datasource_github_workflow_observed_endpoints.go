@@ -0,0 +1,246 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &githubWorkflowObservedEndpointsDataSource{}
+	_ datasource.DataSourceWithConfigure = &githubWorkflowObservedEndpointsDataSource{}
+)
+
+var workflowFileNamePattern = regexp.MustCompile(`^[^/]+\.ya?ml$`)
+
+// NewGithubWorkflowObservedEndpointsDataSource is a helper function to simplify the provider implementation.
+func NewGithubWorkflowObservedEndpointsDataSource() datasource.DataSource {
+	return &githubWorkflowObservedEndpointsDataSource{}
+}
+
+// githubWorkflowObservedEndpointsDataSource exposes the egress baseline Harden-Runner recorded for a workflow.
+type githubWorkflowObservedEndpointsDataSource struct {
+	client stepsecurityapi.Client
+}
+
+type githubWorkflowObservedEndpointsDataSourceModel struct {
+	Owner                       types.String `tfsdk:"owner"`
+	Repo                        types.String `tfsdk:"repo"`
+	Workflow                    types.String `tfsdk:"workflow"`
+	Jobs                        types.Set    `tfsdk:"jobs"`
+	IncludeRecommendedEndpoints types.Bool   `tfsdk:"include_recommended_endpoints"`
+	Endpoints                   types.List   `tfsdk:"endpoints"`
+	AllowedEndpoints            types.Set    `tfsdk:"allowed_endpoints"`
+}
+
+var observedEndpointAttrTypes = map[string]attr.Type{
+	"endpoint":   types.StringType,
+	"host":       types.StringType,
+	"port":       types.Int64Type,
+	"jobs":       types.ListType{ElemType: types.StringType},
+	"first_seen": types.StringType,
+	"last_seen":  types.StringType,
+}
+
+// Metadata returns the data source type name.
+func (d *githubWorkflowObservedEndpointsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_github_workflow_observed_endpoints"
+}
+
+// Schema defines the schema for the data source.
+func (d *githubWorkflowObservedEndpointsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves the outbound endpoints Harden-Runner observed for a workflow, e.g. while it ran under an `audit` mode policy. " +
+			"`allowed_endpoints` can be passed to `allowed_endpoints` of `stepsecurity_github_policy_store` to move the workflow to `block` mode.",
+		Attributes: map[string]schema.Attribute{
+			"owner": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The GitHub organization or user that owns the repository.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"repo": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The repository name.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"workflow": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The workflow file name, e.g. `ci.yml`.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(workflowFileNamePattern, "must be a workflow file name ending in .yml or .yaml, e.g. ci.yml"),
+				},
+			},
+			"jobs": schema.SetAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Only return endpoints called by these jobs of the workflow. All jobs when unset.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"include_recommended_endpoints": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: "Also add endpoints most workflows need in `block` mode, such as `github.com:443` and `api.github.com:443`, " +
+					"to `allowed_endpoints` even when they were not observed. Defaults to `false`.",
+			},
+			"endpoints": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The observed endpoints, ordered by endpoint.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"endpoint": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The endpoint as `host:port`.",
+						},
+						"host": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The host name or IP address.",
+						},
+						"port": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The port.",
+						},
+						"jobs": schema.ListAttribute{
+							Computed:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "The jobs of the workflow that called the endpoint.",
+						},
+						"first_seen": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "When the endpoint was first called, in RFC 3339 format.",
+						},
+						"last_seen": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "When the endpoint was last called, in RFC 3339 format.",
+						},
+					},
+				},
+			},
+			"allowed_endpoints": schema.SetAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The observed endpoints as `host:port`, in the format of `allowed_endpoints` of `stepsecurity_github_policy_store`.",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *githubWorkflowObservedEndpointsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(stepsecurityapi.Client)
+	if !ok || client == nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected stepsecurityapi.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *githubWorkflowObservedEndpointsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state githubWorkflowObservedEndpointsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var jobs []string
+	if !state.Jobs.IsNull() {
+		resp.Diagnostics.Append(state.Jobs.ElementsAs(ctx, &jobs, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	baseline, err := d.client.GetWorkflowObservedEndpoints(ctx, state.Owner.ValueString(), state.Repo.ValueString(), state.Workflow.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading observed endpoints",
+			fmt.Sprintf("Could not read the observed endpoints of workflow %s in %s/%s: %s",
+				state.Workflow.ValueString(), state.Owner.ValueString(), state.Repo.ValueString(), err),
+		)
+		return
+	}
+
+	observed := make(map[string]stepsecurityapi.ObservedEndpoint, len(baseline.Endpoints))
+	for _, endpoint := range baseline.Endpoints {
+		if len(jobs) > 0 && !slices.ContainsFunc(endpoint.Jobs, func(job string) bool { return slices.Contains(jobs, job) }) {
+			continue
+		}
+		value := net.JoinHostPort(endpoint.Host, strconv.Itoa(endpoint.Port))
+		if _, err := parseAllowedEndpoint(value); err != nil {
+			resp.Diagnostics.AddWarning(
+				"Skipped observed endpoint",
+				fmt.Sprintf("The observed endpoint %q can't be used as an allowed endpoint and was skipped: %s", value, err),
+			)
+			continue
+		}
+		observed[value] = endpoint
+	}
+
+	keys := make([]string, 0, len(observed))
+	for key := range observed {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	endpoints := make([]attr.Value, 0, len(keys))
+	allowed := make([]attr.Value, 0, len(keys))
+	for _, key := range keys {
+		endpoint := observed[key]
+		endpoints = append(endpoints, types.ObjectValueMust(observedEndpointAttrTypes, map[string]attr.Value{
+			"endpoint":   types.StringValue(key),
+			"host":       types.StringValue(endpoint.Host),
+			"port":       types.Int64Value(int64(endpoint.Port)),
+			"jobs":       types.ListValueMust(types.StringType, stringsToAttrValues(endpoint.Jobs)),
+			"first_seen": optionalStringValue(endpoint.FirstSeen),
+			"last_seen":  optionalStringValue(endpoint.LastSeen),
+		}))
+		allowed = append(allowed, types.StringValue(key))
+	}
+	if state.IncludeRecommendedEndpoints.ValueBool() {
+		for _, recommended := range blockModeRecommendedEndpoints {
+			if _, ok := observed[recommended.Endpoint]; !ok {
+				allowed = append(allowed, types.StringValue(recommended.Endpoint))
+			}
+		}
+	}
+
+	state.Endpoints = types.ListValueMust(types.ObjectType{AttrTypes: observedEndpointAttrTypes}, endpoints)
+	state.AllowedEndpoints = types.SetValueMust(types.StringType, allowed)
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// optionalStringValue returns a null string for an empty value.
+func optionalStringValue(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
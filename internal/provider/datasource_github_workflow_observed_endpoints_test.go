@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	fwdatasource "github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
+)
+
+func testGithubWorkflowObservedEndpointsRead(t *testing.T, client stepsecurityapi.Client, jobs []string, includeRecommended *bool) *fwdatasource.ReadResponse {
+	t.Helper()
+
+	config := map[string]tftypes.Value{
+		"owner":    tftypes.NewValue(tftypes.String, "test-org"),
+		"repo":     tftypes.NewValue(tftypes.String, "api"),
+		"workflow": tftypes.NewValue(tftypes.String, "ci.yml"),
+	}
+	if jobs != nil {
+		values := make([]tftypes.Value, 0, len(jobs))
+		for _, job := range jobs {
+			values = append(values, tftypes.NewValue(tftypes.String, job))
+		}
+		config["jobs"] = tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, values)
+	}
+	if includeRecommended != nil {
+		config["include_recommended_endpoints"] = tftypes.NewValue(tftypes.Bool, *includeRecommended)
+	}
+
+	return testDataSourceRead(t, &githubWorkflowObservedEndpointsDataSource{client: client}, config)
+}
+
+func testObservedEndpoints() *stepsecurityapi.WorkflowObservedEndpoints {
+	return &stepsecurityapi.WorkflowObservedEndpoints{
+		Owner:    "test-org",
+		Repo:     "api",
+		Workflow: "ci.yml",
+		Endpoints: []stepsecurityapi.ObservedEndpoint{
+			{Host: "registry.npmjs.org", Port: 443, Jobs: []string{"build"}, FirstSeen: "2026-01-02T00:00:00Z", LastSeen: "2026-03-04T00:00:00Z"},
+			{Host: "github.com", Port: 443, Jobs: []string{"build", "deploy"}},
+			{Host: "2001:db8::1", Port: 8443, Jobs: []string{"deploy"}},
+			{Host: "bad host", Port: 443, Jobs: []string{"build"}},
+		},
+	}
+}
+
+func observedAllowedEndpoints(t *testing.T, resp *fwdatasource.ReadResponse) []string {
+	t.Helper()
+
+	var state githubWorkflowObservedEndpointsDataSourceModel
+	require.False(t, resp.State.Get(context.Background(), &state).HasError())
+	var allowed []string
+	require.False(t, state.AllowedEndpoints.ElementsAs(context.Background(), &allowed, false).HasError())
+	return allowed
+}
+
+func TestGithubWorkflowObservedEndpointsDataSource_Metadata(t *testing.T) {
+	t.Parallel()
+
+	resp := &fwdatasource.MetadataResponse{}
+	NewGithubWorkflowObservedEndpointsDataSource().Metadata(context.Background(), fwdatasource.MetadataRequest{ProviderTypeName: "stepsecurity"}, resp)
+	assert.Equal(t, "stepsecurity_github_workflow_observed_endpoints", resp.TypeName)
+}
+
+func TestGithubWorkflowObservedEndpointsDataSource_Read(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mockClient := &stepsecurityapi.MockStepSecurityClient{}
+	mockClient.On("GetWorkflowObservedEndpoints", mock.Anything, "test-org", "api", "ci.yml").Return(testObservedEndpoints(), nil).Once()
+
+	resp := testGithubWorkflowObservedEndpointsRead(t, mockClient, nil, nil)
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+	mockClient.AssertExpectations(t)
+
+	// The endpoint with an invalid host is skipped with a warning.
+	require.Equal(t, 1, resp.Diagnostics.WarningsCount())
+	assert.Contains(t, resp.Diagnostics.Warnings()[0].Detail(), "bad host")
+
+	var state githubWorkflowObservedEndpointsDataSourceModel
+	require.False(t, resp.State.Get(ctx, &state).HasError())
+	require.Len(t, state.Endpoints.Elements(), 3)
+
+	first := state.Endpoints.Elements()[0].(types.Object).Attributes()
+	assert.Equal(t, "[2001:db8::1]:8443", first["endpoint"].(types.String).ValueString())
+	assert.True(t, first["first_seen"].IsNull())
+	last := state.Endpoints.Elements()[2].(types.Object).Attributes()
+	assert.Equal(t, "registry.npmjs.org:443", last["endpoint"].(types.String).ValueString())
+	assert.Equal(t, int64(443), last["port"].(types.Int64).ValueInt64())
+	assert.Equal(t, "2026-01-02T00:00:00Z", last["first_seen"].(types.String).ValueString())
+
+	assert.ElementsMatch(t, []string{"[2001:db8::1]:8443", "github.com:443", "registry.npmjs.org:443"}, observedAllowedEndpoints(t, resp))
+}
+
+func TestGithubWorkflowObservedEndpointsDataSource_ReadJobsAndRecommended(t *testing.T) {
+	t.Parallel()
+
+	mockClient := &stepsecurityapi.MockStepSecurityClient{}
+	mockClient.On("GetWorkflowObservedEndpoints", mock.Anything, "test-org", "api", "ci.yml").Return(testObservedEndpoints(), nil).Once()
+
+	include := true
+	resp := testGithubWorkflowObservedEndpointsRead(t, mockClient, []string{"deploy"}, &include)
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+
+	// github.com:443 is both observed and recommended and appears once.
+	assert.ElementsMatch(t, []string{"[2001:db8::1]:8443", "github.com:443", "api.github.com:443"}, observedAllowedEndpoints(t, resp))
+}
+
+func TestGithubWorkflowObservedEndpointsDataSource_ReadError(t *testing.T) {
+	t.Parallel()
+
+	mockClient := &stepsecurityapi.MockStepSecurityClient{}
+	mockClient.On("GetWorkflowObservedEndpoints", mock.Anything, "test-org", "api", "ci.yml").Return((*stepsecurityapi.WorkflowObservedEndpoints)(nil), errors.New("boom")).Once()
+
+	resp := testGithubWorkflowObservedEndpointsRead(t, mockClient, nil, nil)
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "boom")
+}
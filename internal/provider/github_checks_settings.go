@@ -0,0 +1,496 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
+)
+
+// checkNamePattern matches check names such as "pwn_request_check". Controls that are not
+// built into the provider are configured by their check name.
+var checkNamePattern = regexp.MustCompile(`^[a-z0-9]+(_[a-z0-9]+)*$`)
+
+// workflowPathPattern matches the workflow paths, or glob patterns of them, that can be
+// exempted from the PWN Request and Script Injection checks.
+var workflowPathPattern = regexp.MustCompile(`^\.github/workflows/[^/]+$`)
+
+// eventNamePattern matches GitHub workflow trigger event names.
+var eventNamePattern = regexp.MustCompile(`^[a-z]+(_[a-z]+)*$`)
+
+// typedControlSettings maps the attributes of a control's settings object other than values
+// to the settings key they configure. Typed settings cannot be set through values.
+var typedControlSettings = map[string]string{
+	"cool_down_period":                                stepsecurityapi.CooldownPeriodSetting,
+	"packages_to_exempt_in_cooldown_check":            stepsecurityapi.ExemptedPackagesSetting,
+	"packages_to_exempt_in_compromised_updates_check": stepsecurityapi.ExemptedPackagesSetting,
+	"exempted_workflows":                              stepsecurityapi.ExemptedWorkflowsSetting,
+	"allowed_triggers":                                stepsecurityapi.AllowedTriggersSetting,
+	"severity_threshold":                              stepsecurityapi.SeverityThresholdSetting,
+}
+
+// cooldownAttributes are the typed attributes of the cooldown controls. They are converted
+// separately because cool_down_period has a default.
+var cooldownAttributes = map[string]bool{
+	"cool_down_period":                     true,
+	"packages_to_exempt_in_cooldown_check": true,
+}
+
+// typedSettingAttribute returns the attribute that configures key for entry, or "" when
+// key is set through values.
+func typedSettingAttribute(entry stepsecurityapi.ControlCatalogEntry, key string) string {
+	return typedSettingAttributeFor(hasCooldownSettings(entry), key)
+}
+
+// typedSettingAttributeFor is typedSettingAttribute for a control that is or is not a
+// cooldown control; the exempted packages setting has an attribute for each kind.
+func typedSettingAttributeFor(cooldown bool, key string) string {
+	switch key {
+	case stepsecurityapi.CooldownPeriodSetting:
+		return "cool_down_period"
+	case stepsecurityapi.ExemptedPackagesSetting:
+		if cooldown {
+			return "packages_to_exempt_in_cooldown_check"
+		}
+		return "packages_to_exempt_in_compromised_updates_check"
+	case stepsecurityapi.ExemptedWorkflowsSetting:
+		return "exempted_workflows"
+	case stepsecurityapi.AllowedTriggersSetting:
+		return "allowed_triggers"
+	case stepsecurityapi.SeverityThresholdSetting:
+		return "severity_threshold"
+	}
+	return ""
+}
+
+// typedSettingApplies reports whether attribute configures a setting entry declares.
+func typedSettingApplies(entry stepsecurityapi.ControlCatalogEntry, attribute string) bool {
+	key := typedControlSettings[attribute]
+	_, ok := entry.Setting(key)
+	return ok && typedSettingAttribute(entry, key) == attribute
+}
+
+// inapplicableTypedSettings returns the typed attributes settings sets that do not
+// configure a setting of entry, sorted by name.
+func inapplicableTypedSettings(entry stepsecurityapi.ControlCatalogEntry, settings types.Object) []string {
+	if settings.IsNull() || settings.IsUnknown() {
+		return nil
+	}
+	var inapplicable []string
+	for name, value := range settings.Attributes() {
+		if _, typed := typedControlSettings[name]; !typed || value.IsNull() {
+			continue
+		}
+		if !typedSettingApplies(entry, name) {
+			inapplicable = append(inapplicable, name)
+		}
+	}
+	sort.Strings(inapplicable)
+	return inapplicable
+}
+
+// nullControlSettings returns the attributes of a settings object with every attribute null.
+func nullControlSettings() map[string]attr.Value {
+	attrs := make(map[string]attr.Value, len(controlSettingsAttrTypes()))
+	for name, attrType := range controlSettingsAttrTypes() {
+		switch attrType.(type) {
+		case types.ListType:
+			attrs[name] = types.ListNull(types.StringType)
+		case types.MapType:
+			attrs[name] = types.MapNull(types.StringType)
+		default:
+			if attrType.Equal(types.Int64Type) {
+				attrs[name] = types.Int64Null()
+			} else {
+				attrs[name] = types.StringNull()
+			}
+		}
+	}
+	return attrs
+}
+
+// newControlSettings returns a settings object with attrs set and every other attribute
+// null, or a null object when attrs sets nothing.
+func newControlSettings(attrs map[string]attr.Value) types.Object {
+	settings := nullControlSettings()
+	empty := true
+	for name, value := range attrs {
+		settings[name] = value
+		if !value.IsNull() {
+			empty = false
+		}
+	}
+	if empty {
+		return types.ObjectNull(controlSettingsAttrTypes())
+	}
+	return types.ObjectValueMust(controlSettingsAttrTypes(), settings)
+}
+
+// typedSettingsToAPI returns the settings configured by the typed attributes of settings
+// that apply to entry, other than the cooldown attributes.
+func typedSettingsToAPI(entry stepsecurityapi.ControlCatalogEntry, settings types.Object) map[string]any {
+	result := map[string]any{}
+	if settings.IsNull() || settings.IsUnknown() {
+		return result
+	}
+	for name, value := range settings.Attributes() {
+		key, typed := typedControlSettings[name]
+		if !typed || cooldownAttributes[name] || !typedSettingApplies(entry, name) || value.IsNull() || value.IsUnknown() {
+			continue
+		}
+		switch v := value.(type) {
+		case types.List:
+			list := make([]string, 0, len(v.Elements()))
+			for _, element := range v.Elements() {
+				if str, ok := element.(types.String); ok && !str.IsNull() && !str.IsUnknown() {
+					list = append(list, str.ValueString())
+				}
+			}
+			result[key] = list
+		case types.String:
+			result[key] = v.ValueString()
+		}
+	}
+	return result
+}
+
+// typedSettingsFromAPI returns the typed attributes, other than the cooldown attributes,
+// for the settings of a check that is or is not a cooldown control.
+func typedSettingsFromAPI(settings map[string]any, cooldown bool) map[string]attr.Value {
+	attrs := map[string]attr.Value{}
+	for key, value := range settings {
+		name := typedSettingAttributeFor(cooldown, key)
+		if name == "" || cooldownAttributes[name] {
+			continue
+		}
+		switch v := value.(type) {
+		case string:
+			attrs[name] = types.StringValue(v)
+		case []string:
+			attrs[name] = types.ListValueMust(types.StringType, stringsToAttrValues(v))
+		case []any:
+			elements := make([]attr.Value, 0, len(v))
+			for _, element := range v {
+				if str, ok := element.(string); ok {
+					elements = append(elements, types.StringValue(str))
+				}
+			}
+			attrs[name] = types.ListValueMust(types.StringType, elements)
+		}
+	}
+	return attrs
+}
+
+// controlCheck returns the check name for the control attribute of a control, which is
+// either the display name of a built-in control or a check name.
+func controlCheck(control string) string {
+	if check, ok := stepsecurityapi.AvailableControls[control]; ok {
+		return check
+	}
+	return control
+}
+
+// controlNameForCheck is the inverse of controlCheck.
+func controlNameForCheck(check string) string {
+	if name := stepsecurityapi.GetControlName(check); name != "" {
+		return name
+	}
+	return check
+}
+
+// hasCooldownSettings reports whether entry accepts the settings behind cool_down_period
+// and packages_to_exempt_in_cooldown_check.
+func hasCooldownSettings(entry stepsecurityapi.ControlCatalogEntry) bool {
+	_, ok := entry.Setting(stepsecurityapi.CooldownPeriodSetting)
+	return ok
+}
+
+// builtinControl returns the catalog entry of a control built into the provider.
+func builtinControl(control string) (stepsecurityapi.ControlCatalogEntry, bool) {
+	if _, ok := stepsecurityapi.AvailableControls[control]; !ok {
+		return stepsecurityapi.ControlCatalogEntry{}, false
+	}
+	return stepsecurityapi.DefaultControlCatalog().Find(control)
+}
+
+// controlSettingValues returns the values map of a control's settings object. known is
+// false when the settings or the map are unknown.
+func controlSettingValues(settings types.Object) (values map[string]string, known bool) {
+	if settings.IsUnknown() {
+		return nil, false
+	}
+	if settings.IsNull() {
+		return nil, true
+	}
+	valuesMap, ok := settings.Attributes()["values"].(types.Map)
+	if !ok || valuesMap.IsNull() {
+		return nil, true
+	}
+	if valuesMap.IsUnknown() {
+		return nil, false
+	}
+
+	values = make(map[string]string, len(valuesMap.Elements()))
+	for key, value := range valuesMap.Elements() {
+		str, ok := value.(types.String)
+		if !ok || str.IsUnknown() {
+			return nil, false
+		}
+		values[key] = str.ValueString()
+	}
+	return values, true
+}
+
+// validateControlSettingValues checks values against the settings entry declares. Keys
+// entry does not declare are only reported when catalogComplete is true, since the
+// built-in catalog does not know about settings the API added later.
+func validateControlSettingValues(entry stepsecurityapi.ControlCatalogEntry, control string, values map[string]string, catalogComplete bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if attribute := typedSettingAttribute(entry, key); attribute != "" {
+			diags.AddError(
+				"Invalid control setting",
+				fmt.Sprintf("setting %q of control %s is configured with %s, not values", key, control, attribute),
+			)
+			continue
+		}
+
+		setting, ok := entry.Setting(key)
+		if !ok {
+			if catalogComplete {
+				diags.AddError(
+					"Invalid control setting",
+					fmt.Sprintf("control %s has no setting %q; the stepsecurity_github_check_controls data source lists the settings of each control", control, key),
+				)
+			}
+			continue
+		}
+
+		if _, err := controlSettingValueToAPI(setting, values[key]); err != nil {
+			diags.AddError(
+				"Invalid control setting",
+				fmt.Sprintf("setting %q of control %s: %s", key, control, err),
+			)
+		}
+	}
+
+	return diags
+}
+
+// controlSettingValueToAPI converts a settings value from its string form in values to
+// the type setting declares.
+func controlSettingValueToAPI(setting stepsecurityapi.ControlSettingSchema, value string) (any, error) {
+	switch setting.Type {
+	case stepsecurityapi.ControlSettingTypeInt:
+		number, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || strconv.FormatInt(number, 10) != value {
+			return nil, fmt.Errorf("%q is not an integer", value)
+		}
+		if (setting.Min != nil && number < *setting.Min) || (setting.Max != nil && number > *setting.Max) {
+			return nil, fmt.Errorf("%d is out of range%s", number, settingRange(setting))
+		}
+		return number, nil
+	case stepsecurityapi.ControlSettingTypeBool:
+		if value != "true" && value != "false" {
+			return nil, fmt.Errorf("%q is not a boolean, use \"true\" or \"false\"", value)
+		}
+		return value == "true", nil
+	case stepsecurityapi.ControlSettingTypeString:
+		return value, nil
+	case stepsecurityapi.ControlSettingTypeStringList:
+		var list []string
+		if err := json.Unmarshal([]byte(value), &list); err != nil {
+			return nil, fmt.Errorf("%q is not a JSON list of strings, use jsonencode([...])", value)
+		}
+		return list, nil
+	default:
+		return nil, fmt.Errorf("settings of type %q are not supported by this provider version", setting.Type)
+	}
+}
+
+func settingRange(setting stepsecurityapi.ControlSettingSchema) string {
+	switch {
+	case setting.Min != nil && setting.Max != nil:
+		return fmt.Sprintf(", it must be between %d and %d", *setting.Min, *setting.Max)
+	case setting.Min != nil:
+		return fmt.Sprintf(", it must be at least %d", *setting.Min)
+	case setting.Max != nil:
+		return fmt.Sprintf(", it must be at most %d", *setting.Max)
+	}
+	return ""
+}
+
+// formatControlSettingValue formats a settings value the way values expects it: numbers
+// and booleans in their literal form, strings as is and lists as JSON arrays.
+func formatControlSettingValue(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case bool, int, int64, float64, []string, []any:
+		encoded, err := json.Marshal(v)
+		return string(encoded), err
+	default:
+		return "", fmt.Errorf("unsupported settings value of type %T", value)
+	}
+}
+
+// settingValuesFromAPI returns the settings of a check that are not covered by a typed
+// attribute, formatted for values. It returns a null map when there are none.
+func settingValuesFromAPI(settings map[string]any) types.Map {
+	values := map[string]attr.Value{}
+	for key, value := range settings {
+		if typedSettingAttributeFor(false, key) != "" {
+			continue
+		}
+		formatted, err := formatControlSettingValue(value)
+		if err != nil {
+			continue
+		}
+		values[key] = types.StringValue(formatted)
+	}
+	if len(values) == 0 {
+		return types.MapNull(types.StringType)
+	}
+	return types.MapValueMust(types.StringType, values)
+}
+
+// needsControlCatalog reports whether controls reference a control or a setting that
+// the built-in catalog does not describe.
+func needsControlCatalog(controls []control) bool {
+	for _, c := range controls {
+		if c.Control.IsUnknown() || c.Control.IsNull() {
+			continue
+		}
+		entry, ok := builtinControl(c.Control.ValueString())
+		if !ok {
+			return true
+		}
+		values, _ := controlSettingValues(c.Settings)
+		for key := range values {
+			if typedSettingAttribute(entry, key) != "" {
+				continue
+			}
+			if _, ok := entry.Setting(key); !ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// controlCatalog returns the catalog describing controls: the built-in catalog when it
+// is sufficient, otherwise the owner's catalog from the API. complete reports whether
+// the API catalog was consulted.
+func (r *githubChecksResource) controlCatalog(ctx context.Context, owner string, controls []control) (catalog stepsecurityapi.ControlCatalog, complete bool, err error) {
+	if !needsControlCatalog(controls) {
+		return stepsecurityapi.DefaultControlCatalog(), false, nil
+	}
+	if r.client == nil {
+		return nil, false, fmt.Errorf("the control catalog of %s cannot be read before the provider is configured", owner)
+	}
+	catalog, err = r.client.GetChecksControlCatalog(ctx, owner)
+	if err != nil {
+		return nil, false, err
+	}
+	return catalog, true, nil
+}
+
+// normalizeSettingValue re-encodes value if it is JSON, so that formatting differences
+// do not count as changes.
+func normalizeSettingValue(value string) string {
+	var decoded any
+	if err := json.Unmarshal([]byte(value), &decoded); err != nil {
+		return value
+	}
+	encoded, err := json.Marshal(decoded)
+	if err != nil {
+		return value
+	}
+	return string(encoded)
+}
+
+// retainConfiguredSettingValues drops settings values that prior did not manage from
+// state, so settings the API reports with their defaults do not show up as drift.
+func retainConfiguredSettingValues(ctx context.Context, prior githubChecksModel, state *githubChecksModel) {
+	if prior.Controls.IsNull() || prior.Controls.IsUnknown() || state.Controls.IsNull() || state.Controls.IsUnknown() {
+		return
+	}
+
+	var priorControls, stateControls []control
+	if diags := prior.Controls.ElementsAs(ctx, &priorControls, false); diags.HasError() {
+		return
+	}
+	if diags := state.Controls.ElementsAs(ctx, &stateControls, false); diags.HasError() {
+		return
+	}
+
+	managed := make(map[string]map[string]string, len(priorControls))
+	for _, c := range priorControls {
+		values, known := controlSettingValues(c.Settings)
+		if known {
+			managed[c.Control.ValueString()] = values
+		}
+	}
+
+	for i, c := range stateControls {
+		if c.Settings.IsNull() || c.Settings.IsUnknown() {
+			continue
+		}
+		priorValues, ok := managed[c.Control.ValueString()]
+		if !ok {
+			continue
+		}
+		stateValues, _ := controlSettingValues(c.Settings)
+
+		retained := map[string]attr.Value{}
+		for key, value := range stateValues {
+			priorValue, ok := priorValues[key]
+			if !ok {
+				continue
+			}
+			// Keep the configured spelling of JSON lists the API re-encodes.
+			if normalizeSettingValue(priorValue) == normalizeSettingValue(value) {
+				value = priorValue
+			}
+			retained[key] = types.StringValue(value)
+		}
+		valuesMap := types.MapNull(types.StringType)
+		if len(retained) > 0 {
+			valuesMap = types.MapValueMust(types.StringType, retained)
+		}
+
+		attrs := c.Settings.Attributes()
+		attrs["values"] = valuesMap
+		c.Settings = newControlSettings(attrs)
+		stateControls[i] = c
+	}
+
+	state.Controls, _ = types.ListValueFrom(ctx, controlObjectType(), stateControls)
+}
+
+// cooldownPeriodDefault returns the default of the cooldown period of entry.
+func cooldownPeriodDefault(entry stepsecurityapi.ControlCatalogEntry) int64 {
+	setting, _ := entry.Setting(stepsecurityapi.CooldownPeriodSetting)
+	switch v := setting.Default.(type) {
+	case int64:
+		return v
+	case float64:
+		return int64(v)
+	}
+	return 2
+}
@@ -0,0 +1,226 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
+)
+
+// createValuesSettingsObject returns a settings object that only sets values.
+func createValuesSettingsObject(values map[string]string) types.Object {
+	elements := make(map[string]attr.Value, len(values))
+	for key, value := range values {
+		elements[key] = types.StringValue(value)
+	}
+	settings := nullControlSettings()
+	settings["values"] = types.MapValueMust(types.StringType, elements)
+	return types.ObjectValueMust(controlSettingsAttrTypes(), settings)
+}
+
+// testControlCatalog is a catalog with one control the provider does not know about.
+func testControlCatalog() stepsecurityapi.ControlCatalog {
+	minUnpinned, maxUnpinned := int64(1), int64(10)
+	return append(stepsecurityapi.DefaultControlCatalog(), stepsecurityapi.ControlCatalogEntry{
+		Name:  "Action Pinning",
+		Check: "action_pinning_check",
+		Types: []string{"optional"},
+		Settings: []stepsecurityapi.ControlSettingSchema{
+			{Key: "allow_tags", Type: stepsecurityapi.ControlSettingTypeBool, Default: false},
+			{Key: "max_unpinned", Type: stepsecurityapi.ControlSettingTypeInt, Min: &minUnpinned, Max: &maxUnpinned},
+			{Key: "trusted_owners", Type: stepsecurityapi.ControlSettingTypeStringList},
+			{Key: "message", Type: stepsecurityapi.ControlSettingTypeString},
+		},
+	})
+}
+
+func TestControlCheckAndName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "pwn_request_check", controlCheck("PWN Request"))
+	assert.Equal(t, "action_pinning_check", controlCheck("action_pinning_check"))
+	assert.Equal(t, "PWN Request", controlNameForCheck("pwn_request_check"))
+	assert.Equal(t, "action_pinning_check", controlNameForCheck("action_pinning_check"))
+}
+
+func TestControlSettingValueToAPI(t *testing.T) {
+	t.Parallel()
+
+	entry, ok := testControlCatalog().Find("action_pinning_check")
+	require.True(t, ok)
+
+	testCases := []struct {
+		key         string
+		value       string
+		want        any
+		errContains string
+	}{
+		{key: "allow_tags", value: "true", want: true},
+		{key: "allow_tags", value: "yes", errContains: "not a boolean"},
+		{key: "max_unpinned", value: "3", want: int64(3)},
+		{key: "max_unpinned", value: "03", errContains: "not an integer"},
+		{key: "max_unpinned", value: "11", errContains: "must be between 1 and 10"},
+		{key: "trusted_owners", value: `["actions", "github"]`, want: []string{"actions", "github"}},
+		{key: "trusted_owners", value: "actions", errContains: "not a JSON list of strings"},
+		{key: "message", value: "pin it", want: "pin it"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key+"="+tc.value, func(t *testing.T) {
+			t.Parallel()
+
+			setting, ok := entry.Setting(tc.key)
+			require.True(t, ok)
+			got, err := controlSettingValueToAPI(setting, tc.value)
+			if tc.errContains != "" {
+				assert.ErrorContains(t, err, tc.errContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	_, err := controlSettingValueToAPI(stepsecurityapi.ControlSettingSchema{Type: "duration"}, "1h")
+	assert.ErrorContains(t, err, "not supported")
+}
+
+func TestValidateControlSettingValues(t *testing.T) {
+	t.Parallel()
+
+	entry, ok := testControlCatalog().Find("action_pinning_check")
+	require.True(t, ok)
+
+	diags := validateControlSettingValues(entry, "action_pinning_check", map[string]string{"allow_tags": "true", "unknown": "x"}, false)
+	assert.False(t, diags.HasError(), "unexpected diags: %v", diags)
+
+	diags = validateControlSettingValues(entry, "action_pinning_check", map[string]string{"unknown": "x"}, true)
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags.Errors()[0].Detail(), `has no setting "unknown"`)
+
+	diags = validateControlSettingValues(entry, "action_pinning_check", map[string]string{"cooldown_period_in_days": "3"}, false)
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags.Errors()[0].Detail(), "configured with cool_down_period")
+}
+
+func TestSettingValuesFromAPI(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, settingValuesFromAPI(nil).IsNull())
+	assert.True(t, settingValuesFromAPI(map[string]any{"cooldown_period_in_days": float64(2)}).IsNull())
+
+	got := settingValuesFromAPI(map[string]any{
+		"cooldown_period_in_days": float64(2),
+		"allow_tags":              true,
+		"max_unpinned":            float64(3),
+		"trusted_owners":          []any{"actions", "github"},
+		"message":                 "pin it",
+	})
+	assert.True(t, got.Equal(types.MapValueMust(types.StringType, map[string]attr.Value{
+		"allow_tags":     types.StringValue("true"),
+		"max_unpinned":   types.StringValue("3"),
+		"trusted_owners": types.StringValue(`["actions","github"]`),
+		"message":        types.StringValue("pin it"),
+	})), "got %v", got)
+}
+
+func TestNeedsControlCatalog(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, needsControlCatalog([]control{
+		{Control: types.StringValue("NPM Package Cooldown"), Settings: createSettingsObject(nil, nil)},
+	}))
+	assert.True(t, needsControlCatalog([]control{
+		{Control: types.StringValue("action_pinning_check"), Settings: createNullSettingsObject()},
+	}))
+	assert.True(t, needsControlCatalog([]control{
+		{Control: types.StringValue("PWN Request"), Settings: createValuesSettingsObject(map[string]string{"comment_on_pr": "true"})},
+	}))
+}
+
+func TestRetainConfiguredSettingValues(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	prior := githubChecksModel{
+		Controls: mustControlsList([]control{
+			{
+				Control:  types.StringValue("action_pinning_check"),
+				Enable:   types.BoolValue(true),
+				Type:     types.StringValue("optional"),
+				Settings: createValuesSettingsObject(map[string]string{"trusted_owners": `["actions", "github"]`}),
+			},
+			{
+				Control:  types.StringValue("PWN Request"),
+				Enable:   types.BoolValue(true),
+				Type:     types.StringValue("required"),
+				Settings: createNullSettingsObject(),
+			},
+		}),
+	}
+	state := githubChecksModel{
+		Controls: mustControlsList([]control{
+			{
+				Control: types.StringValue("action_pinning_check"),
+				Enable:  types.BoolValue(true),
+				Type:    types.StringValue("optional"),
+				Settings: createValuesSettingsObject(map[string]string{
+					"trusted_owners": `["actions","github"]`,
+					"allow_tags":     "false",
+				}),
+			},
+			{
+				Control:  types.StringValue("PWN Request"),
+				Enable:   types.BoolValue(true),
+				Type:     types.StringValue("required"),
+				Settings: createValuesSettingsObject(map[string]string{"comment_on_pr": "true"}),
+			},
+		}),
+	}
+
+	retainConfiguredSettingValues(ctx, prior, &state)
+
+	controls := controlsListToSlice(state.Controls)
+	require.Len(t, controls, 2)
+	values, _ := controlSettingValues(controls[0].Settings)
+	assert.Equal(t, map[string]string{"trusted_owners": `["actions", "github"]`}, values)
+	assert.True(t, controls[1].Settings.IsNull())
+}
+
+func TestInapplicableTypedSettings(t *testing.T) {
+	t.Parallel()
+
+	pwn, ok := stepsecurityapi.DefaultControlCatalog().Find("PWN Request")
+	require.True(t, ok)
+	cooldown, ok := stepsecurityapi.DefaultControlCatalog().Find("NPM Package Cooldown")
+	require.True(t, ok)
+
+	cooldownPeriod := int64(3)
+	settings := createWorkflowSettingsObject([]string{".github/workflows/*.yml"}, "medium")
+	assert.Empty(t, inapplicableTypedSettings(pwn, settings))
+	assert.Equal(t, []string{"exempted_workflows", "severity_threshold"}, inapplicableTypedSettings(cooldown, settings))
+	assert.Equal(t, []string{"cool_down_period"}, inapplicableTypedSettings(pwn, createSettingsObject(&cooldownPeriod, nil)))
+}
+
+func TestTypedSettingsFromAPI(t *testing.T) {
+	t.Parallel()
+
+	got := typedSettingsFromAPI(map[string]any{
+		stepsecurityapi.ExemptedPackagesSetting:  []any{"left-pad"},
+		stepsecurityapi.AllowedTriggersSetting:   []any{"pull_request_target"},
+		stepsecurityapi.SeverityThresholdSetting: "critical",
+		"comment_on_pr":                          true,
+	}, false)
+	assert.Equal(t, map[string]attr.Value{
+		"packages_to_exempt_in_compromised_updates_check": types.ListValueMust(types.StringType, stringsToAttrValues([]string{"left-pad"})),
+		"allowed_triggers":   types.ListValueMust(types.StringType, stringsToAttrValues([]string{"pull_request_target"})),
+		"severity_threshold": types.StringValue("critical"),
+	}, got)
+
+	assert.Empty(t, typedSettingsFromAPI(map[string]any{stepsecurityapi.ExemptedPackagesSetting: []any{"left-pad"}}, true))
+}
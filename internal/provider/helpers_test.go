@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+)
+
+// testResourceSchema returns the schema of r.
+func testResourceSchema(t *testing.T, r resource.Resource) resourceschema.Schema {
+	t.Helper()
+
+	resp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), resource.SchemaRequest{}, resp)
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+
+	return resp.Schema
+}
+
+// testResourcePlan returns a plan of r holding model.
+func testResourcePlan(t *testing.T, r resource.Resource, model any) tfsdk.Plan {
+	t.Helper()
+
+	plan := tfsdk.Plan{Schema: testResourceSchema(t, r)}
+	diags := plan.Set(context.Background(), model)
+	require.False(t, diags.HasError(), "unexpected diags: %v", diags)
+
+	return plan
+}
+
+// testResourceState returns a state of r holding model.
+func testResourceState(t *testing.T, r resource.Resource, model any) tfsdk.State {
+	t.Helper()
+
+	state := tfsdk.State{Schema: testResourceSchema(t, r)}
+	diags := state.Set(context.Background(), model)
+	require.False(t, diags.HasError(), "unexpected diags: %v", diags)
+
+	return state
+}
+
+// testResourceConfig returns a configuration of r holding model.
+func testResourceConfig(t *testing.T, r resource.Resource, model any) tfsdk.Config {
+	t.Helper()
+
+	plan := testResourcePlan(t, r, model)
+	return tfsdk.Config{Raw: plan.Raw, Schema: plan.Schema}
+}
+
+// testDataSourceRead reads d with a configuration that sets the attributes in config
+// and leaves every other attribute null.
+func testDataSourceRead(t *testing.T, d datasource.DataSource, config map[string]tftypes.Value) *datasource.ReadResponse {
+	t.Helper()
+
+	ctx := context.Background()
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError(), "unexpected diags: %v", schemaResp.Diagnostics)
+
+	schemaType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := make(map[string]tftypes.Value, len(schemaType.AttributeTypes))
+	for name, typ := range schemaType.AttributeTypes {
+		values[name] = tftypes.NewValue(typ, nil)
+	}
+	for name, value := range config {
+		require.Contains(t, schemaType.AttributeTypes, name)
+		values[name] = value
+	}
+
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaType, values)}}, resp)
+	return resp
+}
@@ -0,0 +1,41 @@
+package provider
+
+import "sync"
+
+// ownerMutexKV hands out one mutex per owner. Resources that read-modify-write an
+// owner-wide configuration lock it so that parallel applies within one provider process
+// do not overwrite each other's changes.
+type ownerMutexKV struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+func newOwnerMutexKV() *ownerMutexKV {
+	return &ownerMutexKV{locks: make(map[string]*sync.Mutex)}
+}
+
+// Lock locks the mutex for owner, creating it on first use.
+func (m *ownerMutexKV) Lock(owner string) {
+	m.get(owner).Lock()
+}
+
+// Unlock unlocks the mutex for owner.
+func (m *ownerMutexKV) Unlock(owner string) {
+	m.get(owner).Unlock()
+}
+
+func (m *ownerMutexKV) get(owner string) *sync.Mutex {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	lock, ok := m.locks[owner]
+	if !ok {
+		lock = &sync.Mutex{}
+		m.locks[owner] = lock
+	}
+	return lock
+}
+
+// githubChecksConfigLocks serializes writes to an owner's GitHub checks configuration,
+// which stepsecurity_github_checks and stepsecurity_github_checks_repo share.
+var githubChecksConfigLocks = newOwnerMutexKV()
@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOwnerMutexKV(t *testing.T) {
+	t.Parallel()
+
+	locks := newOwnerMutexKV()
+
+	// Different owners do not block each other.
+	locks.Lock("org-a")
+	locks.Lock("org-b")
+	locks.Unlock("org-b")
+	locks.Unlock("org-a")
+
+	// The same owner is serialized.
+	counter := 0
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			locks.Lock("org-a")
+			defer locks.Unlock("org-a")
+			current := counter
+			counter = current + 1
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 50, counter)
+}
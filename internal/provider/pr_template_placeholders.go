@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
+)
+
+const (
+	// maxPRTitleLength is GitHub's limit on the length of a pull request title.
+	maxPRTitleLength = 256
+	// maxPRCommitMessageLength is the longest commit message StepSecurity accepts.
+	maxPRCommitMessageLength = 4096
+)
+
+// prTemplatePlaceholderPattern matches a {{...}} token in a PR template, including
+// the "$" of GitHub Actions expressions (${{ ... }}) so they can be told apart.
+var prTemplatePlaceholderPattern = regexp.MustCompile(`\$?\{\{[^{}]*\}\}`)
+
+// prTemplatePreviewSecurityFixes stands in for the security fixes of a PR in
+// rendered_preview.
+const prTemplatePreviewSecurityFixes = `### Harden Runner
+
+- Added Harden-Runner to the jobs of .github/workflows/ci.yml
+
+### Pinned Dependencies
+
+- Pinned actions/checkout to a full length commit SHA
+
+### Least Privileged GitHub Actions Token Permissions
+
+- Restricted the permissions of the GITHUB_TOKEN in .github/workflows/ci.yml`
+
+var _ validator.String = prTemplatePlaceholdersValidator{}
+
+// prTemplatePlaceholdersValidator rejects {{...}} tokens StepSecurity doesn't
+// substitute, which would show up verbatim in the PR. With requireSecurityFixes it
+// also warns when the security fixes placeholder is missing.
+type prTemplatePlaceholdersValidator struct {
+	requireSecurityFixes bool
+}
+
+func (v prTemplatePlaceholdersValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+func (v prTemplatePlaceholdersValidator) MarkdownDescription(_ context.Context) string {
+	description := "placeholders must be one of `" + strings.Join(stepsecurityapi.PRTemplatePlaceholders, "`, `") + "`"
+	if v.requireSecurityFixes {
+		description += " and the value should contain `" + stepsecurityapi.PRTemplateSecurityFixesPlaceholder + "`"
+	}
+	return description
+}
+
+func (v prTemplatePlaceholdersValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	value := req.ConfigValue.ValueString()
+
+	for _, token := range unknownPRTemplatePlaceholders(value) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Unknown PR Template Placeholder",
+			fmt.Sprintf("%s is not a placeholder StepSecurity substitutes and would appear verbatim in the PR. Supported placeholders: %s.",
+				token, strings.Join(stepsecurityapi.PRTemplatePlaceholders, ", ")),
+		)
+	}
+
+	if v.requireSecurityFixes && !strings.Contains(value, stepsecurityapi.PRTemplateSecurityFixesPlaceholder) {
+		resp.Diagnostics.AddAttributeWarning(
+			req.Path,
+			"Missing Security Fixes Placeholder",
+			fmt.Sprintf("The value doesn't contain %s, so policy-driven PRs won't list the security fixes they apply.",
+				stepsecurityapi.PRTemplateSecurityFixesPlaceholder),
+		)
+	}
+}
+
+// unknownPRTemplatePlaceholders returns the distinct {{...}} tokens of value that are
+// not in stepsecurityapi.PRTemplatePlaceholders, in order of appearance. GitHub
+// Actions expressions, which summaries quote when describing workflow fixes, are
+// not placeholders and are skipped.
+func unknownPRTemplatePlaceholders(value string) []string {
+	var unknown []string
+	for _, token := range prTemplatePlaceholderPattern.FindAllString(value, -1) {
+		if strings.HasPrefix(token, "$") {
+			continue
+		}
+		if !slices.Contains(stepsecurityapi.PRTemplatePlaceholders, token) && !slices.Contains(unknown, token) {
+			unknown = append(unknown, token)
+		}
+	}
+	return unknown
+}
+
+// renderPRTemplatePreview renders summary the way StepSecurity does for a PR with
+// sample security fixes.
+func renderPRTemplatePreview(summary string) string {
+	return strings.ReplaceAll(summary, stepsecurityapi.PRTemplateSecurityFixesPlaceholder, prTemplatePreviewSecurityFixes)
+}
+
+var _ planmodifier.String = renderedPreviewPlanModifier{}
+
+// renderedPreviewPlanModifier plans rendered_preview from the planned summary, so the
+// preview is visible before apply.
+type renderedPreviewPlanModifier struct{}
+
+func (m renderedPreviewPlanModifier) Description(ctx context.Context) string {
+	return m.MarkdownDescription(ctx)
+}
+
+func (m renderedPreviewPlanModifier) MarkdownDescription(_ context.Context) string {
+	return "Renders the planned summary with sample security fixes."
+}
+
+func (m renderedPreviewPlanModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Nothing to plan when the resource is being destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var summary types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("summary"), &summary)...)
+	if resp.Diagnostics.HasError() || summary.IsUnknown() {
+		return
+	}
+
+	resp.PlanValue = types.StringValue(renderPRTemplatePreview(summary.ValueString()))
+}
@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPRTemplatePlaceholdersValidator(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name                 string
+		value                types.String
+		requireSecurityFixes bool
+		expectedErrors       int
+		expectedWarnings     int
+	}{
+		{
+			name:                 "summary_with_fixes",
+			value:                types.StringValue("## Summary\n\n{{STEPSECURITY_SECURITY_FIXES}}\n"),
+			requireSecurityFixes: true,
+		},
+		{
+			name:                 "summary_without_fixes",
+			value:                types.StringValue("## Summary\n\nPlease review."),
+			requireSecurityFixes: true,
+			expectedWarnings:     1,
+		},
+		{
+			name:                 "unknown_placeholders_reported_once",
+			value:                types.StringValue("{{STEPSECURITY_SECURITY_FIXES}} {{REPO_NAME}} {{ REPO_NAME }} {{REPO_NAME}}"),
+			requireSecurityFixes: true,
+			expectedErrors:       2,
+		},
+		{
+			name:  "title_without_placeholders",
+			value: types.StringValue("[StepSecurity] Apply security best practices"),
+		},
+		{
+			name:           "title_with_unknown_placeholder",
+			value:          types.StringValue("[StepSecurity] {{TITLE}}"),
+			expectedErrors: 1,
+		},
+		{
+			name:                 "github_actions_expressions_are_not_placeholders",
+			value:                types.StringValue("Pinned the ref used by `${{ github.event.pull_request.title }}` and ${{github.ref}}.\n\n{{STEPSECURITY_SECURITY_FIXES}}"),
+			requireSecurityFixes: true,
+		},
+		{
+			name:           "unknown_placeholder_next_to_expression",
+			value:          types.StringValue("${{ github.ref }} {{REPO_NAME}}"),
+			expectedErrors: 1,
+		},
+		{
+			name:  "single_braces_are_not_placeholders",
+			value: types.StringValue("ci: apply fixes for ${{ github.ref }"),
+		},
+		{
+			name:                 "unknown_value",
+			value:                types.StringUnknown(),
+			requireSecurityFixes: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.StringRequest{Path: path.Root("summary"), ConfigValue: tc.value}
+			resp := &validator.StringResponse{}
+			prTemplatePlaceholdersValidator{requireSecurityFixes: tc.requireSecurityFixes}.ValidateString(context.Background(), req, resp)
+
+			assert.Equal(t, tc.expectedErrors, resp.Diagnostics.ErrorsCount(), "diags: %v", resp.Diagnostics)
+			assert.Equal(t, tc.expectedWarnings, resp.Diagnostics.WarningsCount(), "diags: %v", resp.Diagnostics)
+		})
+	}
+}
+
+func TestRenderPRTemplatePreview(t *testing.T) {
+	t.Parallel()
+
+	preview := renderPRTemplatePreview("## Security Fixes\n\n{{STEPSECURITY_SECURITY_FIXES}}\n\n## Feedback")
+
+	assert.NotContains(t, preview, "{{STEPSECURITY_SECURITY_FIXES}}")
+	assert.Contains(t, preview, "## Security Fixes\n\n"+prTemplatePreviewSecurityFixes+"\n\n## Feedback")
+	assert.Equal(t, "No fixes here", renderPRTemplatePreview("No fixes here"))
+}
+
+func TestRenderedPreviewPlanModifier(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		summary  types.String
+		expected types.String
+	}{
+		{
+			name:     "known_summary",
+			summary:  types.StringValue("Fixes:\n{{STEPSECURITY_SECURITY_FIXES}}"),
+			expected: types.StringValue("Fixes:\n" + prTemplatePreviewSecurityFixes),
+		},
+		{
+			name:     "unknown_summary",
+			summary:  types.StringUnknown(),
+			expected: types.StringUnknown(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			r := &githubPRTemplateResource{}
+			schemaResp := &resource.SchemaResponse{}
+			r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+			plan := tfsdk.Plan{Schema: schemaResp.Schema}
+			require.False(t, plan.Set(ctx, githubPRTemplateModel{
+				ID:              types.StringUnknown(),
+				Owner:           types.StringValue("test-org"),
+				Title:           types.StringValue("Security Update"),
+				Summary:         tc.summary,
+				CommitMessage:   types.StringValue("Security update"),
+				Labels:          types.ListNull(types.StringType),
+				Reviewers:       types.SetNull(types.StringType),
+				TeamReviewers:   types.SetNull(types.StringType),
+				Assignees:       types.SetNull(types.StringType),
+				Draft:           types.BoolValue(false),
+				BranchPrefix:    types.StringNull(),
+				SignOff:         types.BoolValue(false),
+				CoAuthors:       types.ListNull(types.StringType),
+				RenderedPreview: types.StringUnknown(),
+			}).HasError())
+
+			req := planmodifier.StringRequest{Plan: plan, PlanValue: types.StringUnknown()}
+			resp := &planmodifier.StringResponse{PlanValue: req.PlanValue}
+			renderedPreviewPlanModifier{}.PlanModifyString(ctx, req, resp)
+
+			require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+			assert.Equal(t, tc.expected, resp.PlanValue)
+		})
+	}
+}
@@ -175,6 +175,13 @@ func (p *StepSecurityProvider) DataSources(_ context.Context) []func() datasourc
 	return []func() datasource.DataSource{
 		NewUsersDataSource,
 		NewGithubRunPoliciesDataSource,
+		NewGithubRunPolicyEvaluationDataSource,
+		NewGithubCheckControlsDataSource,
+		NewGithubPolicyStorePoliciesDataSource,
+		NewGithubWorkflowObservedEndpointsDataSource,
+		NewGithubClustersDataSource,
+		NewGithubPRTemplateDefaultDataSource,
+		NewGithubEffectivePRTemplateDataSource,
 		NewDeveloperMDMProfileExportDataSource,
 		NewDeveloperMDMDeviceComplianceDataSource,
 		NewDeveloperMDMProfileComplianceDataSource,
@@ -187,13 +194,22 @@ func (p *StepSecurityProvider) Resources(_ context.Context) []func() resource.Re
 		NewUserResource,
 		NewRoleResource,
 		NewGithubRepoNotificationSettingsResource,
+		NewGithubNotificationDestinationResource,
+		NewGithubRepoNotificationOverrideResource,
 		NewPolicyDrivenPRResource,
 		NewGithubPolicyStoreResource,
 		NewGithubPolicyStoreAttachmentResource,
 		NewGithubSupressionRuleResource,
 		NewGithubRunPolicyResource,
+		NewGithubRunPolicyAllowedActionsResource,
+		NewGithubRunPolicyPinnedActionsResource,
+		NewGithubRunPolicyRunsOnResource,
+		NewGithubRunPolicySecretsResource,
+		NewGithubRunPolicyCompromisedActionsResource,
 		NewGitHubChecksResource,
+		NewGitHubChecksRepoResource,
 		NewGitHubPRTemplateResource,
+		NewGithubRepoPRTemplateResource,
 		NewSecureRegistryPolicyResource,
 		NewDeveloperMDMIDEExtensionPolicyResource,
 		NewDeveloperMDMPackageConfigPolicyResource,
@@ -2,18 +2,25 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
 	"sort"
 	"strings"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -70,6 +77,7 @@ func (r *githubChecksResource) Configure(_ context.Context, req resource.Configu
 // Schema defines the schema for the resource.
 func (r *githubChecksResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the GitHub checks configuration of an owner: the controls and which repositories they run on. When none of `required_checks`, `optional_checks` and `baseline_check` is set, repository enrollment is left untouched so it can be managed per repository with `stepsecurity_github_checks_repo`.",
 		Attributes: map[string]schema.Attribute{
 			"owner": schema.StringAttribute{
 				Required:    true,
@@ -79,13 +87,57 @@ func (r *githubChecksResource) Schema(_ context.Context, _ resource.SchemaReques
 				Optional:    true,
 				Description: "Custom description text appended to all check summaries.",
 			},
+			"enable_required_checks_for_new_repos": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether required checks run for repositories created after apply. Defaults to whether required_checks repos is '*'. Can be true only when required_checks repos is '*'; when false, '*' enrolls the repositories that exist at each apply.",
+			},
+			"enable_optional_checks_for_new_repos": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether optional checks run for repositories created after apply. Defaults to whether optional_checks repos is '*'. Can be true only when optional_checks repos is '*'; when false, '*' enrolls the repositories that exist at each apply.",
+			},
+			"enable_baseline_check_for_new_repos": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the baseline check runs for repositories created after apply. Defaults to whether baseline_check repos is '*'. Can be true only when baseline_check repos is '*'; when false, '*' enrolls the repositories that exist at each apply.",
+			},
+			"enrolled_repos": schema.MapNestedAttribute{
+				Computed:    true,
+				Description: "The checks each repository of the owner is enrolled in, keyed by repository name, as reported by StepSecurity. Planned from the repositories the owner has, so the plan shows which repositories change enrollment. When required_checks, optional_checks and baseline_check are all unset, stepsecurity_github_checks_repo resources manage enrollment and it is not planned.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"baseline": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the baseline check runs for the repository.",
+						},
+						"run_required_checks": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether required checks run for the repository.",
+						},
+						"run_optional_checks": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether optional checks run for the repository.",
+						},
+					},
+				},
+			},
+			"on_destroy": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(onDestroyDisableAll),
+				Description: "What happens to the checks configuration of the owner when the resource is destroyed. 'disable_all' disables every control and the checks of every repository, 'restore_previous' writes back the configuration that existed when the resource was created and 'leave' leaves the configuration as is. Default is 'disable_all'.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(onDestroyDisableAll, onDestroyRestorePrevious, onDestroyLeave),
+				},
+			},
 			"controls": schema.ListNestedAttribute{
 				Optional: true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"control": schema.StringAttribute{
 							Required:    true,
-							Description: "Control name. Available controls: " + strings.Join(stepsecurityapi.GetAvailableControls(), ", "),
+							Description: "Control name. Available controls: " + strings.Join(stepsecurityapi.GetAvailableControls(), ", ") + ". Controls the provider does not know yet are configured by check name, as listed by the stepsecurity_github_check_controls data source.",
 						},
 						"enable": schema.BoolAttribute{
 							Required:    true,
@@ -111,6 +163,39 @@ func (r *githubChecksResource) Schema(_ context.Context, _ resource.SchemaReques
 									ElementType: types.StringType,
 									Description: "Package names to exempt from cooldown checks. Only applicable to npm/PyPI/Maven/NuGet cooldown checks.",
 								},
+								"packages_to_exempt_in_compromised_updates_check": schema.ListAttribute{
+									Optional:    true,
+									ElementType: types.StringType,
+									Description: "Package names to exempt from compromised updates checks. Only applicable to npm/PyPI/Maven/NuGet compromised updates checks.",
+								},
+								"exempted_workflows": schema.ListAttribute{
+									Optional:    true,
+									ElementType: types.StringType,
+									Description: "Trusted workflow paths to exempt, e.g. '.github/workflows/release.yml'. Glob patterns are supported. Only applicable to the PWN Request and Script Injection checks.",
+									Validators: []validator.List{
+										listvalidator.ValueStringsAre(stringvalidator.RegexMatches(workflowPathPattern, "must be a path under .github/workflows/")),
+									},
+								},
+								"allowed_triggers": schema.ListAttribute{
+									Optional:    true,
+									ElementType: types.StringType,
+									Description: "Workflow trigger events that are not reported, e.g. 'pull_request_target'. Only applicable to the PWN Request and Script Injection checks.",
+									Validators: []validator.List{
+										listvalidator.ValueStringsAre(stringvalidator.RegexMatches(eventNamePattern, "must be a GitHub event name")),
+									},
+								},
+								"severity_threshold": schema.StringAttribute{
+									Optional:    true,
+									Description: "Minimum severity of the findings that fail the check. Can be one of 'low', 'medium', 'high' or 'critical'. Only applicable to the PWN Request and Script Injection checks.",
+									Validators: []validator.String{
+										stringvalidator.OneOf(stepsecurityapi.SeverityThresholds...),
+									},
+								},
+								"values": schema.MapAttribute{
+									Optional:    true,
+									ElementType: types.StringType,
+									Description: "Other settings of the control, keyed by settings key, as listed by the stepsecurity_github_check_controls data source. Values are validated and converted according to the setting's type: integers and booleans in their literal form, strings as is and string lists as JSON, e.g. jsonencode([\"a\", \"b\"]).",
+								},
 							},
 						},
 					},
@@ -195,8 +280,43 @@ type githubChecksModel struct {
 	OptionalChecks    types.Object `tfsdk:"optional_checks"`
 	BaselineCheck     types.Object `tfsdk:"baseline_check"`
 	CustomDescription types.String `tfsdk:"custom_description"`
+	OnDestroy         types.String `tfsdk:"on_destroy"`
+
+	EnableRequiredChecksForNewRepos types.Bool `tfsdk:"enable_required_checks_for_new_repos"`
+	EnableOptionalChecksForNewRepos types.Bool `tfsdk:"enable_optional_checks_for_new_repos"`
+	EnableBaselineCheckForNewRepos  types.Bool `tfsdk:"enable_baseline_check_for_new_repos"`
+	EnrolledRepos                   types.Map  `tfsdk:"enrolled_repos"`
+}
+
+// enrolledRepoAttrTypes returns the attribute types of an enrolled_repos entry.
+func enrolledRepoAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"baseline":            types.BoolType,
+		"run_required_checks": types.BoolType,
+		"run_optional_checks": types.BoolType,
+	}
 }
 
+// enrolledReposValue returns the enrolled_repos value for repos.
+func enrolledReposValue(repos map[string]stepsecurityapi.CheckOptions) types.Map {
+	elements := make(map[string]attr.Value, len(repos))
+	for repo, options := range repos {
+		elements[repo] = types.ObjectValueMust(enrolledRepoAttrTypes(), map[string]attr.Value{
+			"baseline":            types.BoolValue(options.Baseline),
+			"run_required_checks": types.BoolValue(options.RunRequiredChecks),
+			"run_optional_checks": types.BoolValue(options.RunOptionalChecks),
+		})
+	}
+	return types.MapValueMust(types.ObjectType{AttrTypes: enrolledRepoAttrTypes()}, elements)
+}
+
+// Values of on_destroy.
+const (
+	onDestroyDisableAll      = "disable_all"
+	onDestroyRestorePrevious = "restore_previous"
+	onDestroyLeave           = "leave"
+)
+
 type checksConfig struct {
 	Repos     types.List `tfsdk:"repos"`
 	OmitRepos types.List `tfsdk:"omit_repos"`
@@ -264,8 +384,13 @@ type control struct {
 // controlSettingsAttrTypes returns the attribute types for a control's "settings" object.
 func controlSettingsAttrTypes() map[string]attr.Type {
 	return map[string]attr.Type{
-		"cool_down_period":                     types.Int64Type,
-		"packages_to_exempt_in_cooldown_check": types.ListType{ElemType: types.StringType},
+		"cool_down_period":                                types.Int64Type,
+		"packages_to_exempt_in_cooldown_check":            types.ListType{ElemType: types.StringType},
+		"packages_to_exempt_in_compromised_updates_check": types.ListType{ElemType: types.StringType},
+		"exempted_workflows":                              types.ListType{ElemType: types.StringType},
+		"allowed_triggers":                                types.ListType{ElemType: types.StringType},
+		"severity_threshold":                              types.StringType,
+		"values":                                          types.MapType{ElemType: types.StringType},
 	}
 }
 
@@ -354,10 +479,14 @@ func (r *githubChecksResource) ValidateConfig(ctx context.Context, req resource.
 				continue
 			}
 
-			if _, ok := stepsecurityapi.AvailableControls[control.Control.ValueString()]; !ok {
+			// Controls that are not built in are configured by check name and validated
+			// against the API's control catalog in ModifyPlan.
+			entry, builtin := builtinControl(control.Control.ValueString())
+			if !builtin && !checkNamePattern.MatchString(control.Control.ValueString()) {
 				resp.Diagnostics.AddError(
 					"Invalid control provided",
-					"only the following controls are accepted to configure: "+strings.Join(stepsecurityapi.GetAvailableControls(), ", \n"),
+					"only the following controls are accepted to configure: "+strings.Join(stepsecurityapi.GetAvailableControls(), ", \n")+
+						"\nor the check name of a control listed by the stepsecurity_github_check_controls data source",
 				)
 			}
 
@@ -374,32 +503,38 @@ func (r *githubChecksResource) ValidateConfig(ctx context.Context, req resource.
 				)
 			}
 
-			isCooldownControl := control.Control.ValueString() == "NPM Package Cooldown" ||
-				control.Control.ValueString() == "PyPI Package Cooldown" ||
-				control.Control.ValueString() == "Maven Package Cooldown" ||
-				control.Control.ValueString() == "NuGet Package Cooldown"
-			if !isCooldownControl && !control.Settings.IsNull() && !control.Settings.IsUnknown() {
+			if control.Settings.IsNull() || control.Settings.IsUnknown() {
+				continue
+			}
+
+			isCooldownControl := hasCooldownSettings(entry)
+			if inapplicable := inapplicableTypedSettings(entry, control.Settings); builtin && len(inapplicable) > 0 {
 				resp.Diagnostics.AddError(
 					"can't provide settings",
-					"can't provide settings for control "+control.Control.ValueString(),
+					"can't provide "+strings.Join(inapplicable, ", ")+" for control "+control.Control.ValueString(),
 				)
 			}
 
-			if isCooldownControl && !control.Settings.IsNull() && !control.Settings.IsUnknown() {
+			if isCooldownControl {
 				// Extract cooldown period from the object
 				if cooldownAttr := control.Settings.Attributes()["cool_down_period"]; cooldownAttr != nil {
 					if cooldownValue, ok := cooldownAttr.(types.Int64); ok {
 						period := cooldownValue.ValueInt64()
-						if period != 0 && (period < 1 || period > 30) {
+						setting, _ := entry.Setting(stepsecurityapi.CooldownPeriodSetting)
+						if period != 0 && (period < *setting.Min || period > *setting.Max) {
 							resp.Diagnostics.AddError(
-								"cool_down_period should be between 1 and 30",
-								"cool_down_period should be between 1 and 30 for control "+control.Control.ValueString(),
+								fmt.Sprintf("cool_down_period should be between %d and %d", *setting.Min, *setting.Max),
+								fmt.Sprintf("cool_down_period should be between %d and %d for control %s", *setting.Min, *setting.Max, control.Control.ValueString()),
 							)
 						}
 					}
 				}
 			}
 
+			if values, known := controlSettingValues(control.Settings); known && builtin {
+				resp.Diagnostics.Append(validateControlSettingValues(entry, control.Control.ValueString(), values, false)...)
+			}
+
 		}
 	}
 
@@ -488,6 +623,10 @@ func (r *githubChecksResource) ValidateConfig(ctx context.Context, req resource.
 		}
 	}
 
+	validateNewReposEnrollment(&resp.Diagnostics, "enable_required_checks_for_new_repos", "required_checks", config.EnableRequiredChecksForNewRepos, requiredChecks, isRequiredCheckAppliedForAllRepos)
+	validateNewReposEnrollment(&resp.Diagnostics, "enable_optional_checks_for_new_repos", "optional_checks", config.EnableOptionalChecksForNewRepos, optionalChecks, isOptionalCheckAppliedForAllRepos)
+	validateNewReposEnrollment(&resp.Diagnostics, "enable_baseline_check_for_new_repos", "baseline_check", config.EnableBaselineCheckForNewRepos, baselineCheck, isBaselineCheckAppliedForAllRepos)
+
 }
 
 func (r *githubChecksResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
@@ -505,7 +644,23 @@ func (r *githubChecksResource) ModifyPlan(ctx context.Context, req resource.Modi
 		return
 	}
 
+	// The config tells whether cool_down_period was set or picked up its default.
+	var config githubChecksModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	enrollmentPlanned, diags := r.planRepoEnrollment(ctx, config, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if plan.Controls.IsUnknown() || plan.Controls.IsNull() {
+		if enrollmentPlanned {
+			resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+		}
 		return
 	}
 
@@ -516,27 +671,94 @@ func (r *githubChecksResource) ModifyPlan(ctx context.Context, req resource.Modi
 		return
 	}
 
+	var configControls []control
+	if !config.Controls.IsUnknown() && !config.Controls.IsNull() {
+		resp.Diagnostics.Append(config.Controls.ElementsAs(ctx, &configControls, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	catalog, catalogComplete := stepsecurityapi.DefaultControlCatalog(), false
+	if r.client != nil && !plan.Owner.IsUnknown() {
+		var err error
+		catalog, catalogComplete, err = r.controlCatalog(ctx, plan.Owner.ValueString(), controls)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading GitHub check controls",
+				err.Error(),
+			)
+			return
+		}
+	}
+
 	modified := false
 
 	for ind, control := range controls {
+		if control.Control.IsUnknown() {
+			continue
+		}
 
-		if (control.Control.ValueString() == "NPM Package Cooldown" ||
-			control.Control.ValueString() == "PyPI Package Cooldown" ||
-			control.Control.ValueString() == "Maven Package Cooldown" ||
-			control.Control.ValueString() == "NuGet Package Cooldown") && control.Settings.IsNull() {
-			// Create object with default settings
-			settingsMap := map[string]attr.Value{
-				"cool_down_period":                     types.Int64Value(2),
-				"packages_to_exempt_in_cooldown_check": types.ListNull(types.StringType),
+		// Without the API's catalog, controls that are not built in are planned as
+		// configured and validated once the owner is known.
+		entry, ok := catalog.Find(control.Control.ValueString())
+		if !ok {
+			if catalogComplete {
+				resp.Diagnostics.AddError(
+					"Invalid control provided",
+					fmt.Sprintf("%s is not a control of %s; the stepsecurity_github_check_controls data source lists the available controls", control.Control.ValueString(), plan.Owner.ValueString()),
+				)
 			}
-			control.Settings, _ = types.ObjectValue(controlSettingsAttrTypes(), settingsMap)
+			continue
+		}
+
+		if catalogComplete && !control.Type.IsUnknown() && len(entry.Types) > 0 && !slices.Contains(entry.Types, control.Type.ValueString()) {
+			resp.Diagnostics.AddError(
+				"Invalid control type",
+				fmt.Sprintf("control %s can only run as %s", control.Control.ValueString(), strings.Join(entry.Types, " or ")),
+			)
+		}
+
+		if hasCooldownSettings(entry) && control.Settings.IsNull() {
+			// Create object with default settings
+			control.Settings = newControlSettings(map[string]attr.Value{
+				"cool_down_period": types.Int64Value(cooldownPeriodDefault(entry)),
+			})
 			controls[ind] = control
 			modified = true
 		}
+
+		if ind < len(configControls) {
+			if inapplicable := inapplicableTypedSettings(entry, configControls[ind].Settings); len(inapplicable) > 0 {
+				resp.Diagnostics.AddError(
+					"can't provide settings",
+					"can't provide "+strings.Join(inapplicable, ", ")+" for control "+control.Control.ValueString(),
+				)
+			}
+		}
+
+		if !hasCooldownSettings(entry) && !control.Settings.IsNull() && !control.Settings.IsUnknown() {
+			// cool_down_period picks up its default whenever settings is set, but only
+			// cooldown controls have a cooldown period.
+			attrs := control.Settings.Attributes()
+			if cooldown, ok := attrs["cool_down_period"].(types.Int64); ok && !cooldown.IsNull() {
+				attrs["cool_down_period"] = types.Int64Null()
+				control.Settings, _ = types.ObjectValue(controlSettingsAttrTypes(), attrs)
+				controls[ind] = control
+				modified = true
+			}
+		}
+
+		if values, known := controlSettingValues(control.Settings); known && catalogComplete {
+			resp.Diagnostics.Append(validateControlSettingValues(entry, control.Control.ValueString(), values, true)...)
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	// Set the plan back (either because it was modified )
-	if modified {
+	if modified || enrollmentPlanned {
 		newControls, listDiags := types.ListValueFrom(ctx, controlObjectType(), controls)
 		resp.Diagnostics.Append(listDiags...)
 		if resp.Diagnostics.HasError() {
@@ -571,7 +793,22 @@ func (r *githubChecksResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
-	err = r.client.UpdatePRChecksConfig(ctx, plan.Owner.ValueString(), *createRequest)
+	if plan.OnDestroy.ValueString() == onDestroyRestorePrevious {
+		previous, err := r.client.GetPRChecksConfig(ctx, plan.Owner.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating GitHub Checks",
+				"Could not read the existing checks configuration to restore on destroy: "+err.Error(),
+			)
+			return
+		}
+		resp.Diagnostics.Append(savePreviousChecksConfig(ctx, resp.Private, previous)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	err = r.writeChecksConfig(ctx, plan, createRequest)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating GitHub Checks",
@@ -582,7 +819,13 @@ func (r *githubChecksResource) Create(ctx context.Context, req resource.CreateRe
 
 	state := r.convertToState(ctx, plan.Owner.ValueString(), *createRequest)
 	state.Owner = types.StringValue(plan.Owner.ValueString())
+	state.OnDestroy = plan.OnDestroy
+	keepAllReposWildcard(ctx, plan, &state, createRequest.Repos)
 	r.updateStateListsWithOrderFromPlan(ctx, plan, &state)
+	retainConfiguredSettingValues(ctx, plan, &state)
+	if repoEnrollmentUnmanaged(plan) {
+		clearRepoEnrollment(&state)
+	}
 
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
@@ -611,7 +854,19 @@ func (r *githubChecksResource) Read(ctx context.Context, req resource.ReadReques
 	}
 
 	newState := r.convertToState(ctx, state.Owner.ValueString(), config)
+	newState.OnDestroy = state.OnDestroy
+	if newState.OnDestroy.IsNull() {
+		// Imported resources start with the default.
+		newState.OnDestroy = types.StringValue(onDestroyDisableAll)
+	}
+	keepAllReposWildcard(ctx, state, &newState, config.Repos)
 	r.updateStateListsWithOrderFromPlan(ctx, state, &newState)
+	retainConfiguredSettingValues(ctx, state, &newState)
+	// A freshly imported state only has the owner set (controls are required otherwise), so
+	// imports still pick up the repository enrollment.
+	if !state.Controls.IsNull() && repoEnrollmentUnmanaged(state) {
+		clearRepoEnrollment(&newState)
+	}
 
 	diags = resp.State.Set(ctx, newState)
 	resp.Diagnostics.Append(diags...)
@@ -638,7 +893,7 @@ func (r *githubChecksResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
-	err = r.client.UpdatePRChecksConfig(ctx, plan.Owner.ValueString(), *updateRequest)
+	err = r.writeChecksConfig(ctx, plan, updateRequest)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating GitHub Checks",
@@ -649,7 +904,13 @@ func (r *githubChecksResource) Update(ctx context.Context, req resource.UpdateRe
 
 	state := r.convertToState(ctx, plan.Owner.ValueString(), *updateRequest)
 	state.Owner = types.StringValue(plan.Owner.ValueString())
+	state.OnDestroy = plan.OnDestroy
+	keepAllReposWildcard(ctx, plan, &state, updateRequest.Repos)
 	r.updateStateListsWithOrderFromPlan(ctx, plan, &state)
+	retainConfiguredSettingValues(ctx, plan, &state)
+	if repoEnrollmentUnmanaged(plan) {
+		clearRepoEnrollment(&state)
+	}
 
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
@@ -676,7 +937,46 @@ func (r *githubChecksResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
-	err := r.client.DeletePRChecksConfig(ctx, state.Owner.ValueString())
+	owner := state.Owner.ValueString()
+	switch state.OnDestroy.ValueString() {
+	case onDestroyLeave:
+		return
+	case onDestroyRestorePrevious:
+		previous, diags := previousChecksConfig(ctx, req.Private)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if previous != nil {
+			githubChecksConfigLocks.Lock(owner)
+			defer githubChecksConfigLocks.Unlock(owner)
+
+			current, err := r.client.GetPRChecksConfig(ctx, owner)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error deleting GitHub Checks",
+					err.Error(),
+				)
+				return
+			}
+			if err := r.client.UpdatePRChecksConfig(ctx, owner, restoredChecksConfig(current, *previous)); err != nil {
+				resp.Diagnostics.AddError(
+					"Error deleting GitHub Checks",
+					err.Error(),
+				)
+			}
+			return
+		}
+		resp.Diagnostics.AddWarning(
+			"No previous GitHub Checks configuration to restore",
+			"The configuration of "+owner+" that existed before the resource was created was not recorded, because the resource was imported or on_destroy was set to 'restore_previous' after it was created. All checks are disabled instead.",
+		)
+	}
+
+	githubChecksConfigLocks.Lock(owner)
+	defer githubChecksConfigLocks.Unlock(owner)
+
+	err := r.client.DeletePRChecksConfig(ctx, owner)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error deleting GitHub Checks",
@@ -687,6 +987,113 @@ func (r *githubChecksResource) Delete(ctx context.Context, req resource.DeleteRe
 
 }
 
+// previousChecksConfigKey is the private state key holding the checks configuration that
+// existed when the resource was created, for on_destroy = "restore_previous".
+const previousChecksConfigKey = "previous_checks_config"
+
+// privateState is the part of the framework's private state data the resource uses.
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// savePreviousChecksConfig records config in private so it can be restored on destroy.
+func savePreviousChecksConfig(ctx context.Context, private privateState, config stepsecurityapi.GitHubPRChecksConfig) diag.Diagnostics {
+	data, err := json.Marshal(config)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Error saving previous GitHub Checks configuration", err.Error())
+		return diags
+	}
+	return private.SetKey(ctx, previousChecksConfigKey, data)
+}
+
+// previousChecksConfig returns the configuration recorded by savePreviousChecksConfig, or
+// nil when none was recorded.
+func previousChecksConfig(ctx context.Context, private privateState) (*stepsecurityapi.GitHubPRChecksConfig, diag.Diagnostics) {
+	data, diags := private.GetKey(ctx, previousChecksConfigKey)
+	if diags.HasError() || len(data) == 0 {
+		return nil, diags
+	}
+	var config stepsecurityapi.GitHubPRChecksConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		diags.AddError("Error reading previous GitHub Checks configuration", err.Error())
+		return nil, diags
+	}
+	return &config, diags
+}
+
+// restoredChecksConfig returns previous with the controls and repositories that were added
+// to current since it was recorded disabled, so restoring it leaves nothing enabled that
+// was not enabled before.
+func restoredChecksConfig(current, previous stepsecurityapi.GitHubPRChecksConfig) stepsecurityapi.GitHubPRChecksConfig {
+	restored := previous
+	restored.Checks = make(map[string]stepsecurityapi.CheckConfig, len(current.Checks)+len(previous.Checks))
+	for check, config := range current.Checks {
+		restored.Checks[check] = stepsecurityapi.CheckConfig{Type: config.Type}
+	}
+	for check, config := range previous.Checks {
+		restored.Checks[check] = config
+	}
+	restored.Repos = make(map[string]stepsecurityapi.CheckOptions, len(current.Repos)+len(previous.Repos))
+	for repo := range current.Repos {
+		restored.Repos[repo] = stepsecurityapi.CheckOptions{}
+	}
+	for repo, options := range previous.Repos {
+		restored.Repos[repo] = options
+	}
+	return restored
+}
+
+// repoEnrollmentUnmanaged reports whether model leaves repository enrollment to
+// stepsecurity_github_checks_repo resources, i.e. configures none of required_checks,
+// optional_checks and baseline_check.
+func repoEnrollmentUnmanaged(model githubChecksModel) bool {
+	return model.RequiredChecks.IsNull() && model.OptionalChecks.IsNull() && model.BaselineCheck.IsNull()
+}
+
+// clearRepoEnrollment nulls the enrollment attributes of a state whose enrollment is
+// unmanaged, so entries written by stepsecurity_github_checks_repo do not show up as drift.
+func clearRepoEnrollment(state *githubChecksModel) {
+	state.RequiredChecks = types.ObjectNull(checksConfigAttrTypes())
+	state.OptionalChecks = types.ObjectNull(checksConfigAttrTypes())
+	state.BaselineCheck = types.ObjectNull(checksConfigAttrTypes())
+}
+
+// writeChecksConfig writes request for the owner while holding the owner's lock. When the
+// plan leaves repository enrollment unmanaged, the current repository entries and
+// new-repository settings are carried over instead of being reset.
+func (r *githubChecksResource) writeChecksConfig(ctx context.Context, plan githubChecksModel, request *stepsecurityapi.GitHubPRChecksConfig) error {
+	owner := plan.Owner.ValueString()
+	githubChecksConfigLocks.Lock(owner)
+	defer githubChecksConfigLocks.Unlock(owner)
+
+	if repoEnrollmentUnmanaged(plan) {
+		existing, err := r.client.GetPRChecksConfig(ctx, owner)
+		if err != nil {
+			return err
+		}
+		request.Repos = existing.Repos
+		request.EnableBaselineCheckForAllNewRepos = existing.EnableBaselineCheckForAllNewRepos
+		request.EnableRequiredChecksForAllNewRepos = existing.EnableRequiredChecksForAllNewRepos
+		request.EnableOptionalChecksForAllNewRepos = existing.EnableOptionalChecksForAllNewRepos
+	} else {
+		requiredChecks, optionalChecks, baselineCheck, diags := decodeEnrollment(ctx, plan)
+		if diags.HasError() {
+			return diagsToError(diags)
+		}
+		if _, allRepos := checksRepos(requiredChecks, optionalChecks, baselineCheck); allRepos != (stepsecurityapi.CheckOptions{}) {
+			existing, err := r.client.GetPRChecksConfig(ctx, owner)
+			if err != nil {
+				return err
+			}
+			enrollAllRepos(request.Repos, allRepos, existing.Repos)
+		}
+	}
+
+	return r.client.UpdatePRChecksConfig(ctx, owner, *request)
+}
+
 func (r *githubChecksResource) convertToCreateRequest(ctx context.Context, plan githubChecksModel) (*stepsecurityapi.GitHubPRChecksConfig, error) {
 	prChecksConfig := stepsecurityapi.GitHubPRChecksConfig{}
 	prChecksConfig.Checks = make(map[string]stepsecurityapi.CheckConfig)
@@ -712,20 +1119,23 @@ func (r *githubChecksResource) convertToCreateRequest(ctx context.Context, plan
 		return nil, diagsToError(diags)
 	}
 
+	catalog, _, err := r.controlCatalog(ctx, plan.Owner.ValueString(), controls)
+	if err != nil {
+		return nil, err
+	}
+
 	for _, control := range controls {
 		controlName := control.Control.ValueString()
 		checkConfig := stepsecurityapi.CheckConfig{
 			Enabled: control.Enable.ValueBool(),
 			Type:    control.Type.ValueString(),
 		}
-		if controlName == "NPM Package Cooldown" || controlName == "PyPI Package Cooldown" || controlName == "Maven Package Cooldown" || controlName == "NuGet Package Cooldown" {
+		entry, _ := catalog.Find(controlName)
+		if hasCooldownSettings(entry) {
 			if control.Settings.IsNull() {
-				control.Settings = types.ObjectNull(map[string]attr.Type{
-					"cool_down_period":                     types.Int64Type,
-					"packages_to_exempt_in_cooldown_check": types.ListType{ElemType: types.StringType},
-				})
+				control.Settings = types.ObjectNull(controlSettingsAttrTypes())
 			}
-			cooldownPeriod := int64(2) // default
+			cooldownPeriod := cooldownPeriodDefault(entry)
 			var exemptPackages []string
 
 			// Extract values from the settings object
@@ -747,15 +1157,192 @@ func (r *githubChecksResource) convertToCreateRequest(ctx context.Context, plan
 			}
 
 			checkConfig.Settings = map[string]any{
-				"cooldown_period_in_days": cooldownPeriod,
+				stepsecurityapi.CooldownPeriodSetting: cooldownPeriod,
 			}
 			if len(exemptPackages) > 0 {
-				checkConfig.Settings["exempted_packages"] = exemptPackages
+				checkConfig.Settings[stepsecurityapi.ExemptedPackagesSetting] = exemptPackages
+			}
+		}
+
+		for key, value := range typedSettingsToAPI(entry, control.Settings) {
+			if checkConfig.Settings == nil {
+				checkConfig.Settings = map[string]any{}
 			}
+			checkConfig.Settings[key] = value
 		}
-		prChecksConfig.Checks[stepsecurityapi.AvailableControls[controlName]] = checkConfig
+
+		values, _ := controlSettingValues(control.Settings)
+		for key, value := range values {
+			setting, ok := entry.Setting(key)
+			if !ok {
+				return nil, fmt.Errorf("control %s has no setting %q", controlName, key)
+			}
+			converted, err := controlSettingValueToAPI(setting, value)
+			if err != nil {
+				return nil, fmt.Errorf("setting %q of control %s: %w", key, controlName, err)
+			}
+			if checkConfig.Settings == nil {
+				checkConfig.Settings = map[string]any{}
+			}
+			checkConfig.Settings[key] = converted
+		}
+
+		prChecksConfig.Checks[controlCheck(controlName)] = checkConfig
 	}
 
+	repos, allRepos := checksRepos(requiredChecks, optionalChecks, baselineCheck)
+	prChecksConfig.EnableBaselineCheckForAllNewRepos = newReposEnrollment(plan.EnableBaselineCheckForNewRepos, allRepos.Baseline)
+	prChecksConfig.EnableRequiredChecksForAllNewRepos = newReposEnrollment(plan.EnableRequiredChecksForNewRepos, allRepos.RunRequiredChecks)
+	prChecksConfig.EnableOptionalChecksForAllNewRepos = newReposEnrollment(plan.EnableOptionalChecksForNewRepos, allRepos.RunOptionalChecks)
+	prChecksConfig.Repos = repos
+	if !plan.CustomDescription.IsNull() && !plan.CustomDescription.IsUnknown() {
+		prChecksConfig.CustomDescription = plan.CustomDescription.ValueString()
+	}
+	return &prChecksConfig, nil
+}
+
+// decodeEnrollment decodes the required_checks, optional_checks and baseline_check blocks of model.
+func decodeEnrollment(ctx context.Context, model githubChecksModel) (requiredChecks, optionalChecks, baselineCheck *checksConfig, diags diag.Diagnostics) {
+	requiredChecks, d := decodeChecksConfig(ctx, model.RequiredChecks)
+	diags.Append(d...)
+	optionalChecks, d = decodeChecksConfig(ctx, model.OptionalChecks)
+	diags.Append(d...)
+	baselineCheck, d = decodeChecksConfig(ctx, model.BaselineCheck)
+	diags.Append(d...)
+	return requiredChecks, optionalChecks, baselineCheck, diags
+}
+
+// checksConfigKnown reports whether the repository lists of c are fully known.
+func checksConfigKnown(c *checksConfig) bool {
+	if c == nil {
+		return true
+	}
+	for _, list := range []types.List{c.Repos, c.OmitRepos} {
+		if list.IsUnknown() {
+			return false
+		}
+		for _, element := range list.Elements() {
+			if element.IsUnknown() {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// validateNewReposEnrollment checks that an enable_*_for_new_repos attribute is only set
+// along with its block, and only true when the block's repos is '*'.
+func validateNewReposEnrollment(diags *diag.Diagnostics, attribute, block string, value types.Bool, checks *checksConfig, allRepos bool) {
+	if value.IsNull() || value.IsUnknown() {
+		return
+	}
+	if checks == nil {
+		diags.AddError(
+			"can't provide "+attribute+" without "+block,
+			attribute+" can only be provided along with "+block,
+		)
+		return
+	}
+	if value.ValueBool() && !checks.Repos.IsUnknown() && !allRepos {
+		diags.AddError(
+			"can't enable "+attribute+" without enabling "+block+" for all repos",
+			attribute+" can only be true when "+block+" repos is set to '*'",
+		)
+	}
+}
+
+// planRepoEnrollment plans the enable_*_for_new_repos attributes config leaves unset, which
+// follow whether repos is '*', and enrolled_repos, from the repositories the owner has.
+// When enrollment is unmanaged, enrolled_repos keeps the framework's plan. It reports
+// whether plan changed.
+func (r *githubChecksResource) planRepoEnrollment(ctx context.Context, config githubChecksModel, plan *githubChecksModel) (bool, diag.Diagnostics) {
+	requiredChecks, optionalChecks, baselineCheck, diags := decodeEnrollment(ctx, *plan)
+	if diags.HasError() {
+		return false, diags
+	}
+	unmanaged := repoEnrollmentUnmanaged(*plan)
+	known := checksConfigKnown(requiredChecks) && checksConfigKnown(optionalChecks) && checksConfigKnown(baselineCheck)
+	repos, allRepos := checksRepos(requiredChecks, optionalChecks, baselineCheck)
+
+	changed := false
+	planNewRepos := func(configured types.Bool, planned *types.Bool, value bool) {
+		if configured.IsNull() && planned.IsUnknown() {
+			*planned = types.BoolValue(value)
+			changed = true
+		}
+	}
+	if !unmanaged && known {
+		planNewRepos(config.EnableRequiredChecksForNewRepos, &plan.EnableRequiredChecksForNewRepos, allRepos.RunRequiredChecks)
+		planNewRepos(config.EnableOptionalChecksForNewRepos, &plan.EnableOptionalChecksForNewRepos, allRepos.RunOptionalChecks)
+		planNewRepos(config.EnableBaselineCheckForNewRepos, &plan.EnableBaselineCheckForNewRepos, allRepos.Baseline)
+	}
+
+	if r.client == nil || plan.Owner.IsUnknown() || (!unmanaged && !known) {
+		return changed, diags
+	}
+
+	existing, err := r.client.GetPRChecksConfig(ctx, plan.Owner.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Error reading GitHub Checks",
+			"Could not read the repositories of "+plan.Owner.ValueString()+": "+err.Error(),
+		)
+		return changed, diags
+	}
+	if unmanaged {
+		// stepsecurity_github_checks_repo resources manage enrollment; it is carried over.
+		// They can change it in the same apply, so enrolled_repos is left unknown.
+		planNewRepos(config.EnableRequiredChecksForNewRepos, &plan.EnableRequiredChecksForNewRepos, getBoolPointerValue(existing.EnableRequiredChecksForAllNewRepos))
+		planNewRepos(config.EnableOptionalChecksForNewRepos, &plan.EnableOptionalChecksForNewRepos, getBoolPointerValue(existing.EnableOptionalChecksForAllNewRepos))
+		planNewRepos(config.EnableBaselineCheckForNewRepos, &plan.EnableBaselineCheckForNewRepos, getBoolPointerValue(existing.EnableBaselineCheckForAllNewRepos))
+		return changed, diags
+	}
+	enrollAllRepos(repos, allRepos, existing.Repos)
+	plan.EnrolledRepos = enrolledReposValue(repos)
+	return true, diags
+}
+
+// keepAllReposWildcard keeps repos '*' of prior in state for checks that do not enroll new
+// repositories: StepSecurity then reports the repositories '*' enrolled one by one.
+func keepAllReposWildcard(ctx context.Context, prior githubChecksModel, state *githubChecksModel, repos map[string]stepsecurityapi.CheckOptions) {
+	keep := func(priorBlock types.Object, stateBlock *types.Object, newRepos types.Bool, enrolled func(stepsecurityapi.CheckOptions) bool) {
+		priorChecks, diags := decodeChecksConfig(ctx, priorBlock)
+		if diags.HasError() || priorChecks == nil || newRepos.ValueBool() {
+			return
+		}
+		priorRepos := priorChecks.Repos.Elements()
+		if len(priorRepos) != 1 || !priorRepos[0].Equal(types.StringValue("*")) {
+			return
+		}
+		var omitRepos []attr.Value
+		for _, repo := range slices.Sorted(maps.Keys(repos)) {
+			if !enrolled(repos[repo]) {
+				omitRepos = append(omitRepos, types.StringValue(repo))
+			}
+		}
+		checks := &checksConfig{
+			Repos:     types.ListValueMust(types.StringType, priorRepos),
+			OmitRepos: types.ListNull(types.StringType),
+		}
+		if len(omitRepos) > 0 {
+			checks.OmitRepos = types.ListValueMust(types.StringType, omitRepos)
+		}
+		*stateBlock = encodeChecksConfig(ctx, checks)
+	}
+	keep(prior.RequiredChecks, &state.RequiredChecks, state.EnableRequiredChecksForNewRepos, func(o stepsecurityapi.CheckOptions) bool { return o.RunRequiredChecks })
+	keep(prior.OptionalChecks, &state.OptionalChecks, state.EnableOptionalChecksForNewRepos, func(o stepsecurityapi.CheckOptions) bool { return o.RunOptionalChecks })
+	keep(prior.BaselineCheck, &state.BaselineCheck, state.EnableBaselineCheckForNewRepos, func(o stepsecurityapi.CheckOptions) bool { return o.Baseline })
+}
+
+// getBoolPointerValue returns the value b points to, or false when b is nil.
+func getBoolPointerValue(b *bool) bool {
+	return b != nil && *b
+}
+
+// checksRepos returns the repository entries the required_checks, optional_checks and
+// baseline_check blocks configure, and the options of the repositories they do not list:
+// those are enrolled in the checks whose repos is '*'.
+func checksRepos(requiredChecks, optionalChecks, baselineCheck *checksConfig) (map[string]stepsecurityapi.CheckOptions, stepsecurityapi.CheckOptions) {
 	isRequiredCheckAppliedForAllRepos := false
 	isOptionalCheckAppliedForAllRepos := false
 	isBaselineCheckAppliedForAllRepos := false
@@ -890,14 +1477,33 @@ func (r *githubChecksResource) convertToCreateRequest(ctx context.Context, plan
 		}
 	}
 
-	prChecksConfig.EnableBaselineCheckForAllNewRepos = &isBaselineCheckAppliedForAllRepos
-	prChecksConfig.EnableRequiredChecksForAllNewRepos = &isRequiredCheckAppliedForAllRepos
-	prChecksConfig.EnableOptionalChecksForAllNewRepos = &isOptionalCheckAppliedForAllRepos
-	prChecksConfig.Repos = repos
-	if !plan.CustomDescription.IsNull() && !plan.CustomDescription.IsUnknown() {
-		prChecksConfig.CustomDescription = plan.CustomDescription.ValueString()
+	return repos, stepsecurityapi.CheckOptions{
+		Baseline:          isBaselineCheckAppliedForAllRepos,
+		RunRequiredChecks: isRequiredCheckAppliedForAllRepos,
+		RunOptionalChecks: isOptionalCheckAppliedForAllRepos,
+	}
+}
+
+// newReposEnrollment returns whether repositories created later are enrolled in a check:
+// the configured enable_*_for_new_repos value, or whether its repos is '*'.
+func newReposEnrollment(configured types.Bool, allRepos bool) *bool {
+	if !configured.IsNull() && !configured.IsUnknown() {
+		allRepos = configured.ValueBool()
+	}
+	return &allRepos
+}
+
+// enrollAllRepos adds the existing repositories repos does not list, with the options of
+// allRepos, so checks whose repos is '*' apply to every repository of the owner.
+func enrollAllRepos(repos map[string]stepsecurityapi.CheckOptions, allRepos stepsecurityapi.CheckOptions, existing map[string]stepsecurityapi.CheckOptions) {
+	if allRepos == (stepsecurityapi.CheckOptions{}) {
+		return
+	}
+	for repo := range existing {
+		if _, ok := repos[repo]; !ok {
+			repos[repo] = allRepos
+		}
 	}
-	return &prChecksConfig, nil
 }
 
 func (r *githubChecksResource) convertToState(ctx context.Context, owner string, config stepsecurityapi.GitHubPRChecksConfig) githubChecksModel {
@@ -916,7 +1522,7 @@ func (r *githubChecksResource) convertToState(ctx context.Context, owner string,
 
 	// Controls
 	for checkName := range config.Checks {
-		controlName := stepsecurityapi.GetControlName(checkName)
+		controlName := controlNameForCheck(checkName)
 		checkConfig := config.Checks[checkName]
 
 		c := control{
@@ -926,11 +1532,14 @@ func (r *githubChecksResource) convertToState(ctx context.Context, owner string,
 		}
 
 		// Handle settings for cooldown controls
-		if (controlName == "NPM Package Cooldown" || controlName == "PyPI Package Cooldown" || controlName == "Maven Package Cooldown" || controlName == "NuGet Package Cooldown") && checkConfig.Settings != nil {
+		_, hasCooldownPeriod := checkConfig.Settings[stepsecurityapi.CooldownPeriodSetting]
+		entry, builtin := builtinControl(controlName)
+		isCooldownControl := (builtin && hasCooldownSettings(entry)) || hasCooldownPeriod
+		if isCooldownControl && checkConfig.Settings != nil {
 			var cooldownPeriod types.Int64
 			var packagesList types.List
 
-			if cooldownValue, ok := checkConfig.Settings["cooldown_period_in_days"]; ok {
+			if cooldownValue, ok := checkConfig.Settings[stepsecurityapi.CooldownPeriodSetting]; ok {
 				if period, ok := cooldownValue.(int64); ok {
 					cooldownPeriod = types.Int64Value(period)
 				} else if period, ok := cooldownValue.(float64); ok {
@@ -945,7 +1554,7 @@ func (r *githubChecksResource) convertToState(ctx context.Context, owner string,
 			}
 
 			// Handle packages_to_exempt_in_cooldown_check
-			if exemptPackages, ok := checkConfig.Settings["exempted_packages"]; ok {
+			if exemptPackages, ok := checkConfig.Settings[stepsecurityapi.ExemptedPackagesSetting]; ok {
 				var elements []attr.Value
 				// Handle both []string and []any types from API response
 				if packages, ok := exemptPackages.([]string); ok && len(packages) > 0 {
@@ -970,14 +1579,16 @@ func (r *githubChecksResource) convertToState(ctx context.Context, owner string,
 			}
 
 			// Create object with settings
-			settingsMap := map[string]attr.Value{
+			c.Settings = newControlSettings(map[string]attr.Value{
 				"cool_down_period":                     cooldownPeriod,
 				"packages_to_exempt_in_cooldown_check": packagesList,
-			}
-			c.Settings, _ = types.ObjectValue(controlSettingsAttrTypes(), settingsMap)
+				"values":                               settingValuesFromAPI(checkConfig.Settings),
+			})
 		} else {
-			// For non-NPM controls or controls without settings, set to null
-			c.Settings = types.ObjectNull(controlSettingsAttrTypes())
+			// Controls without settings get a null settings object
+			settingsMap := typedSettingsFromAPI(checkConfig.Settings, isCooldownControl)
+			settingsMap["values"] = settingValuesFromAPI(checkConfig.Settings)
+			c.Settings = newControlSettings(settingsMap)
 		}
 
 		controls = append(controls, c)
@@ -1135,6 +1746,11 @@ func (r *githubChecksResource) convertToState(ctx context.Context, owner string,
 	model.OptionalChecks = encodeChecksConfig(ctx, optionalChecks)
 	model.BaselineCheck = encodeChecksConfig(ctx, baselineCheck)
 
+	model.EnableRequiredChecksForNewRepos = types.BoolValue(isRequiredAll)
+	model.EnableOptionalChecksForNewRepos = types.BoolValue(isOptionalAll)
+	model.EnableBaselineCheckForNewRepos = types.BoolValue(isBaselineAll)
+	model.EnrolledRepos = enrolledReposValue(config.Repos)
+
 	return model
 }
 
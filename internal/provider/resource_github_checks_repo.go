@@ -0,0 +1,269 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &githubChecksRepoResource{}
+	_ resource.ResourceWithConfigure   = &githubChecksRepoResource{}
+	_ resource.ResourceWithImportState = &githubChecksRepoResource{}
+)
+
+// NewGitHubChecksRepoResource is a helper function to simplify the provider implementation.
+func NewGitHubChecksRepoResource() resource.Resource {
+	return &githubChecksRepoResource{}
+}
+
+// githubChecksRepoResource manages a single repository's entry in an owner's GitHub checks
+// configuration. Every write reads the current configuration, changes only this
+// repository's entry and writes it back while holding the owner's lock, so several
+// instances (and stepsecurity_github_checks managing controls) can share one owner.
+type githubChecksRepoResource struct {
+	client stepsecurityapi.Client
+}
+
+type githubChecksRepoModel struct {
+	Owner             types.String `tfsdk:"owner"`
+	Repo              types.String `tfsdk:"repo"`
+	Baseline          types.Bool   `tfsdk:"baseline"`
+	RunRequiredChecks types.Bool   `tfsdk:"run_required_checks"`
+	RunOptionalChecks types.Bool   `tfsdk:"run_optional_checks"`
+}
+
+// Metadata returns the resource type name.
+func (r *githubChecksRepoResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_github_checks_repo"
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *githubChecksRepoResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(stepsecurityapi.Client)
+	if !ok || client == nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected stepsecurityapi.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Schema defines the schema for the resource.
+func (r *githubChecksRepoResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Enrolls a single repository in StepSecurity GitHub checks. Only this repository's entry of the owner's checks configuration is read and written, so teams can manage their own repositories independently while `stepsecurity_github_checks` manages the controls. Do not also list the repository under `required_checks`, `optional_checks` or `baseline_check` of `stepsecurity_github_checks`. Destroying the resource removes the repository's entry, so it falls back to the owner's settings for new repositories.",
+		Attributes: map[string]schema.Attribute{
+			"owner": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The GitHub organization or user that owns the repository.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"repo": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The repository name, without the owner.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.NoneOf("*"),
+				},
+			},
+			"baseline": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Whether the baseline check runs on the repository.",
+			},
+			"run_required_checks": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Whether the enabled controls of type `required` run on the repository.",
+			},
+			"run_optional_checks": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Whether the enabled controls of type `optional` run on the repository.",
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *githubChecksRepoResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan githubChecksRepoModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.writeRepoOptions(ctx, plan); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating GitHub checks repository enrollment",
+			err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *githubChecksRepoResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state githubChecksRepoModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := r.client.GetPRChecksConfig(ctx, state.Owner.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading GitHub checks repository enrollment",
+			err.Error(),
+		)
+		return
+	}
+
+	options, ok := config.Repos[state.Repo.ValueString()]
+	if !ok {
+		tflog.Info(ctx, "Repository no longer enrolled in GitHub checks, removing from state", map[string]any{
+			"owner": state.Owner.ValueString(),
+			"repo":  state.Repo.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.Baseline = types.BoolValue(options.Baseline)
+	state.RunRequiredChecks = types.BoolValue(options.RunRequiredChecks)
+	state.RunOptionalChecks = types.BoolValue(options.RunOptionalChecks)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *githubChecksRepoResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan githubChecksRepoModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.writeRepoOptions(ctx, plan); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating GitHub checks repository enrollment",
+			err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete removes the repository's entry, so it falls back to the owner's settings for new
+// repositories.
+func (r *githubChecksRepoResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state githubChecksRepoModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	owner, repo := state.Owner.ValueString(), state.Repo.ValueString()
+	err := r.modifyChecksConfig(ctx, owner, func(config *stepsecurityapi.GitHubPRChecksConfig) bool {
+		if _, ok := config.Repos[repo]; !ok {
+			return false
+		}
+		delete(config.Repos, repo)
+		return true
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting GitHub checks repository enrollment",
+			err.Error(),
+		)
+	}
+}
+
+// ImportState imports the resource using an owner/repo identifier.
+func (r *githubChecksRepoResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected owner/repo, got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("owner"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("repo"), parts[1])...)
+}
+
+// writeRepoOptions sets the repository's entry to the options in model.
+func (r *githubChecksRepoResource) writeRepoOptions(ctx context.Context, model githubChecksRepoModel) error {
+	options := stepsecurityapi.CheckOptions{
+		Baseline:          model.Baseline.ValueBool(),
+		RunRequiredChecks: model.RunRequiredChecks.ValueBool(),
+		RunOptionalChecks: model.RunOptionalChecks.ValueBool(),
+	}
+	repo := model.Repo.ValueString()
+
+	return r.modifyChecksConfig(ctx, model.Owner.ValueString(), func(config *stepsecurityapi.GitHubPRChecksConfig) bool {
+		if existing, ok := config.Repos[repo]; ok && existing == options {
+			return false
+		}
+		if config.Repos == nil {
+			config.Repos = make(map[string]stepsecurityapi.CheckOptions)
+		}
+		config.Repos[repo] = options
+		return true
+	})
+}
+
+// modifyChecksConfig reads the owner's checks configuration, applies modify and writes
+// the result back if modify reports a change. The owner's lock is held throughout so
+// concurrent writers in this provider process never lose each other's updates.
+func (r *githubChecksRepoResource) modifyChecksConfig(ctx context.Context, owner string, modify func(config *stepsecurityapi.GitHubPRChecksConfig) bool) error {
+	githubChecksConfigLocks.Lock(owner)
+	defer githubChecksConfigLocks.Unlock(owner)
+
+	config, err := r.client.GetPRChecksConfig(ctx, owner)
+	if err != nil {
+		return err
+	}
+	if !modify(&config) {
+		return nil
+	}
+	return r.client.UpdatePRChecksConfig(ctx, owner, config)
+}
@@ -0,0 +1,205 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
+)
+
+func testGithubChecksRepoSchemaAndModel(t *testing.T, repo string, required bool) (fwresource.SchemaResponse, githubChecksRepoModel) {
+	t.Helper()
+
+	schemaResp := fwresource.SchemaResponse{}
+	(&githubChecksRepoResource{}).Schema(context.Background(), fwresource.SchemaRequest{}, &schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	return schemaResp, githubChecksRepoModel{
+		Owner:             types.StringValue("test-org"),
+		Repo:              types.StringValue(repo),
+		Baseline:          types.BoolValue(true),
+		RunRequiredChecks: types.BoolValue(required),
+		RunOptionalChecks: types.BoolValue(false),
+	}
+}
+
+func testGithubChecksConfigWithRepos(repos map[string]stepsecurityapi.CheckOptions) stepsecurityapi.GitHubPRChecksConfig {
+	return stepsecurityapi.GitHubPRChecksConfig{
+		ChecksConfig: stepsecurityapi.ChecksConfig{
+			Checks: map[string]stepsecurityapi.CheckConfig{
+				"pwn_request_check": {Enabled: true, Type: "required"},
+			},
+		},
+		Repos: repos,
+	}
+}
+
+func TestGithubChecksRepoResource_Metadata(t *testing.T) {
+	t.Parallel()
+
+	resp := &fwresource.MetadataResponse{}
+	NewGitHubChecksRepoResource().Metadata(context.Background(), fwresource.MetadataRequest{ProviderTypeName: "stepsecurity"}, resp)
+	assert.Equal(t, "stepsecurity_github_checks_repo", resp.TypeName)
+}
+
+func TestGithubChecksRepoResource_CreateOnlyTouchesOwnRepo(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	schemaResp, model := testGithubChecksRepoSchemaAndModel(t, "repo-a", true)
+
+	mockClient := &stepsecurityapi.MockStepSecurityClient{}
+	mockClient.On("GetPRChecksConfig", mock.Anything, "test-org").Return(testGithubChecksConfigWithRepos(map[string]stepsecurityapi.CheckOptions{
+		"repo-b": {RunOptionalChecks: true},
+	}), nil).Once()
+	mockClient.On("UpdatePRChecksConfig", mock.Anything, "test-org", mock.MatchedBy(func(req stepsecurityapi.GitHubPRChecksConfig) bool {
+		return assert.ObjectsAreEqual(map[string]stepsecurityapi.CheckOptions{
+			"repo-a": {Baseline: true, RunRequiredChecks: true},
+			"repo-b": {RunOptionalChecks: true},
+		}, req.Repos) && req.Checks["pwn_request_check"].Enabled
+	})).Return(nil).Once()
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(ctx, model).HasError())
+
+	r := &githubChecksRepoResource{client: mockClient}
+	resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, resp)
+
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGithubChecksRepoResource_UpdateSkipsWriteWhenUnchanged(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	schemaResp, model := testGithubChecksRepoSchemaAndModel(t, "repo-a", false)
+
+	mockClient := &stepsecurityapi.MockStepSecurityClient{}
+	mockClient.On("GetPRChecksConfig", mock.Anything, "test-org").Return(testGithubChecksConfigWithRepos(map[string]stepsecurityapi.CheckOptions{
+		"repo-a": {Baseline: true},
+	}), nil).Once()
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(ctx, model).HasError())
+
+	r := &githubChecksRepoResource{client: mockClient}
+	resp := &fwresource.UpdateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: plan}, resp)
+
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNotCalled(t, "UpdatePRChecksConfig", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGithubChecksRepoResource_Read(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("refreshes options", func(t *testing.T) {
+		t.Parallel()
+
+		schemaResp, model := testGithubChecksRepoSchemaAndModel(t, "repo-a", true)
+		state := tfsdk.State{Schema: schemaResp.Schema}
+		require.False(t, state.Set(ctx, model).HasError())
+
+		mockClient := &stepsecurityapi.MockStepSecurityClient{}
+		mockClient.On("GetPRChecksConfig", mock.Anything, "test-org").Return(testGithubChecksConfigWithRepos(map[string]stepsecurityapi.CheckOptions{
+			"repo-a": {RunOptionalChecks: true},
+		}), nil).Once()
+
+		r := &githubChecksRepoResource{client: mockClient}
+		resp := &fwresource.ReadResponse{State: state}
+		r.Read(ctx, fwresource.ReadRequest{State: state}, resp)
+		require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+
+		var got githubChecksRepoModel
+		require.False(t, resp.State.Get(ctx, &got).HasError())
+		assert.False(t, got.Baseline.ValueBool())
+		assert.False(t, got.RunRequiredChecks.ValueBool())
+		assert.True(t, got.RunOptionalChecks.ValueBool())
+	})
+
+	t.Run("removes missing repo", func(t *testing.T) {
+		t.Parallel()
+
+		schemaResp, model := testGithubChecksRepoSchemaAndModel(t, "repo-a", true)
+		state := tfsdk.State{Schema: schemaResp.Schema}
+		require.False(t, state.Set(ctx, model).HasError())
+
+		mockClient := &stepsecurityapi.MockStepSecurityClient{}
+		mockClient.On("GetPRChecksConfig", mock.Anything, "test-org").Return(testGithubChecksConfigWithRepos(nil), nil).Once()
+
+		r := &githubChecksRepoResource{client: mockClient}
+		resp := &fwresource.ReadResponse{State: state}
+		r.Read(ctx, fwresource.ReadRequest{State: state}, resp)
+		require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+		assert.True(t, resp.State.Raw.IsNull())
+	})
+}
+
+func TestGithubChecksRepoResource_Delete(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	schemaResp, model := testGithubChecksRepoSchemaAndModel(t, "repo-a", true)
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, state.Set(ctx, model).HasError())
+
+	mockClient := &stepsecurityapi.MockStepSecurityClient{}
+	mockClient.On("GetPRChecksConfig", mock.Anything, "test-org").Return(testGithubChecksConfigWithRepos(map[string]stepsecurityapi.CheckOptions{
+		"repo-a": {Baseline: true, RunRequiredChecks: true},
+		"repo-b": {RunOptionalChecks: true},
+	}), nil).Once()
+	mockClient.On("UpdatePRChecksConfig", mock.Anything, "test-org", mock.MatchedBy(func(req stepsecurityapi.GitHubPRChecksConfig) bool {
+		_, stillThere := req.Repos["repo-a"]
+		return !stillThere && req.Repos["repo-b"].RunOptionalChecks
+	})).Return(errors.New("boom")).Once()
+
+	r := &githubChecksRepoResource{client: mockClient}
+	resp := &fwresource.DeleteResponse{State: state}
+	r.Delete(ctx, fwresource.DeleteRequest{State: state}, resp)
+
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "boom")
+	mockClient.AssertExpectations(t)
+}
+
+func TestGithubChecksRepoResource_ImportState(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	schemaResp, _ := testGithubChecksRepoSchemaAndModel(t, "repo-a", false)
+	r := &githubChecksRepoResource{}
+
+	newState := func() tfsdk.State {
+		return tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		}
+	}
+
+	resp := &fwresource.ImportStateResponse{State: newState()}
+	r.ImportState(ctx, fwresource.ImportStateRequest{ID: "test-org/repo-a"}, resp)
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+
+	var repo types.String
+	require.False(t, resp.State.GetAttribute(ctx, path.Root("repo"), &repo).HasError())
+	assert.Equal(t, "repo-a", repo.ValueString())
+
+	resp = &fwresource.ImportStateResponse{State: newState()}
+	r.ImportState(ctx, fwresource.ImportStateRequest{ID: "test-org"}, resp)
+	assert.True(t, resp.Diagnostics.HasError())
+}
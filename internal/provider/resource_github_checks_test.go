@@ -7,11 +7,14 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	res "github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
 )
@@ -22,12 +25,10 @@ var (
 
 // Helper function to create settings object for tests
 func createSettingsObject(cooldownPeriod *int64, packages []string) types.Object {
-	settingsMap := map[string]attr.Value{}
+	settingsMap := nullControlSettings()
 
 	if cooldownPeriod != nil {
 		settingsMap["cool_down_period"] = types.Int64Value(*cooldownPeriod)
-	} else {
-		settingsMap["cool_down_period"] = types.Int64Null()
 	}
 
 	if packages != nil {
@@ -36,27 +37,26 @@ func createSettingsObject(cooldownPeriod *int64, packages []string) types.Object
 			elements[i] = types.StringValue(pkg)
 		}
 		settingsMap["packages_to_exempt_in_cooldown_check"], _ = types.ListValue(types.StringType, elements)
-	} else {
-		settingsMap["packages_to_exempt_in_cooldown_check"] = types.ListNull(types.StringType)
 	}
 
-	settingsType := types.ObjectType{
-		AttrTypes: map[string]attr.Type{
-			"cool_down_period":                     types.Int64Type,
-			"packages_to_exempt_in_cooldown_check": types.ListType{ElemType: types.StringType},
-		},
-	}
-
-	obj, _ := types.ObjectValue(settingsType.AttrTypes, settingsMap)
+	obj, _ := types.ObjectValue(controlSettingsAttrTypes(), settingsMap)
 	return obj
 }
 
+// createWorkflowSettingsObject returns a settings object for the PWN Request and Script
+// Injection checks. An empty severity leaves severity_threshold null.
+func createWorkflowSettingsObject(workflows []string, severity string) types.Object {
+	settingsMap := nullControlSettings()
+	settingsMap["exempted_workflows"] = types.ListValueMust(types.StringType, stringsToAttrValues(workflows))
+	if severity != "" {
+		settingsMap["severity_threshold"] = types.StringValue(severity)
+	}
+	return types.ObjectValueMust(controlSettingsAttrTypes(), settingsMap)
+}
+
 // Helper function to create null settings object for tests (for controls without settings)
 func createNullSettingsObject() types.Object {
-	return types.ObjectNull(map[string]attr.Type{
-		"cool_down_period":                     types.Int64Type,
-		"packages_to_exempt_in_cooldown_check": types.ListType{ElemType: types.StringType},
-	})
+	return types.ObjectNull(controlSettingsAttrTypes())
 }
 
 // mustControlsList converts a []control slice into the types.List value that the
@@ -92,6 +92,11 @@ func mustChecksConfigObject(cfg *checksConfig) types.Object {
 // exercise the real ValidateConfig rather than a copy of its logic. Test cases leave attributes
 // they don't care about as the Go zero value; those carry no element/attribute type, which the
 // framework's strict type check rejects, so they are normalized to typed nulls here.
+// nullEnrolledRepos returns a null enrolled_repos value.
+func nullEnrolledRepos() types.Map {
+	return types.MapNull(types.ObjectType{AttrTypes: enrolledRepoAttrTypes()})
+}
+
 func mustValidateConfigRequest(t *testing.T, ctx context.Context, r *githubChecksResource, model githubChecksModel) resource.ValidateConfigRequest {
 	t.Helper()
 
@@ -109,6 +114,9 @@ func mustValidateConfigRequest(t *testing.T, ctx context.Context, r *githubCheck
 			*obj = types.ObjectNull(checksConfigAttrTypes())
 		}
 	}
+	if model.EnrolledRepos.IsNull() && model.EnrolledRepos.ElementType(ctx) == nil {
+		model.EnrolledRepos = nullEnrolledRepos()
+	}
 
 	plan := tfsdk.Plan{Schema: schemaResp.Schema}
 	if diags := plan.Set(ctx, model); diags.HasError() {
@@ -662,6 +670,141 @@ func TestGithubChecksResource_ValidateConfig(t *testing.T) {
 			expectedError: true,
 			errorContains: "can't provide settings",
 		},
+		{
+			name: "check_name_of_catalog_control",
+			config: githubChecksModel{
+				Owner: types.StringValue(testOwner),
+				Controls: mustControlsList([]control{
+					{
+						Control:  types.StringValue("action_pinning_check"),
+						Enable:   types.BoolValue(true),
+						Type:     types.StringValue("optional"),
+						Settings: createValuesSettingsObject(map[string]string{"max_unpinned": "3"}),
+					},
+				}),
+			},
+			expectedError: false,
+		},
+		{
+			name: "values_for_builtin_control",
+			config: githubChecksModel{
+				Owner: types.StringValue(testOwner),
+				Controls: mustControlsList([]control{
+					{
+						Control:  types.StringValue("PWN Request"),
+						Enable:   types.BoolValue(true),
+						Type:     types.StringValue("required"),
+						Settings: createValuesSettingsObject(map[string]string{"comment_on_pr": "true"}),
+					},
+				}),
+			},
+			expectedError: false,
+		},
+		{
+			name: "typed_setting_in_values",
+			config: githubChecksModel{
+				Owner: types.StringValue(testOwner),
+				Controls: mustControlsList([]control{
+					{
+						Control:  types.StringValue("NPM Package Cooldown"),
+						Enable:   types.BoolValue(true),
+						Type:     types.StringValue("required"),
+						Settings: createValuesSettingsObject(map[string]string{"cooldown_period_in_days": "5"}),
+					},
+				}),
+			},
+			expectedError: true,
+			errorContains: "configured with cool_down_period",
+		},
+		{
+			name: "workflow_settings_for_pwn_request",
+			config: githubChecksModel{
+				Owner: types.StringValue(testOwner),
+				Controls: mustControlsList([]control{
+					{
+						Control:  types.StringValue("PWN Request"),
+						Enable:   types.BoolValue(true),
+						Type:     types.StringValue("required"),
+						Settings: createWorkflowSettingsObject([]string{".github/workflows/release.yml"}, "high"),
+					},
+				}),
+			},
+			expectedError: false,
+		},
+		{
+			name: "workflow_settings_for_cooldown_control",
+			config: githubChecksModel{
+				Owner: types.StringValue(testOwner),
+				Controls: mustControlsList([]control{
+					{
+						Control:  types.StringValue("NPM Package Cooldown"),
+						Enable:   types.BoolValue(true),
+						Type:     types.StringValue("required"),
+						Settings: createWorkflowSettingsObject([]string{".github/workflows/release.yml"}, ""),
+					},
+				}),
+			},
+			expectedError: true,
+			errorContains: "can't provide exempted_workflows for control NPM Package Cooldown",
+		},
+		{
+			name: "new_repos_enrollment_without_wildcard",
+			config: githubChecksModel{
+				Owner: types.StringValue(testOwner),
+				Controls: mustControlsList([]control{
+					{
+						Control:  types.StringValue("PWN Request"),
+						Enable:   types.BoolValue(true),
+						Type:     types.StringValue("required"),
+						Settings: createNullSettingsObject(),
+					},
+				}),
+				RequiredChecks: mustChecksConfigObject(&checksConfig{
+					Repos:     types.ListValueMust(types.StringType, stringsToAttrValues([]string{"repo-a"})),
+					OmitRepos: types.ListNull(types.StringType),
+				}),
+				EnableRequiredChecksForNewRepos: types.BoolValue(true),
+			},
+			expectedError: true,
+			errorContains: "enable_required_checks_for_new_repos can only be true when required_checks repos is set to '*'",
+		},
+		{
+			name: "new_repos_enrollment_without_block",
+			config: githubChecksModel{
+				Owner: types.StringValue(testOwner),
+				Controls: mustControlsList([]control{
+					{
+						Control:  types.StringValue("PWN Request"),
+						Enable:   types.BoolValue(true),
+						Type:     types.StringValue("required"),
+						Settings: createNullSettingsObject(),
+					},
+				}),
+				EnableBaselineCheckForNewRepos: types.BoolValue(false),
+			},
+			expectedError: true,
+			errorContains: "enable_baseline_check_for_new_repos can only be provided along with baseline_check",
+		},
+		{
+			name: "wildcard_without_new_repos_enrollment",
+			config: githubChecksModel{
+				Owner: types.StringValue(testOwner),
+				Controls: mustControlsList([]control{
+					{
+						Control:  types.StringValue("PWN Request"),
+						Enable:   types.BoolValue(true),
+						Type:     types.StringValue("required"),
+						Settings: createNullSettingsObject(),
+					},
+				}),
+				RequiredChecks: mustChecksConfigObject(&checksConfig{
+					Repos:     types.ListValueMust(types.StringType, stringsToAttrValues([]string{"*"})),
+					OmitRepos: types.ListNull(types.StringType),
+				}),
+				EnableRequiredChecksForNewRepos: types.BoolValue(false),
+			},
+			expectedError: false,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -726,6 +869,7 @@ func TestGithubChecksResource_ValidateConfig_UnknownControlsList(t *testing.T) {
 		RequiredChecks: types.ObjectNull(checksConfigAttrTypes()),
 		OptionalChecks: types.ObjectNull(checksConfigAttrTypes()),
 		BaselineCheck:  types.ObjectNull(checksConfigAttrTypes()),
+		EnrolledRepos:  nullEnrolledRepos(),
 	}
 
 	plan := tfsdk.Plan{Schema: schemaResp.Schema}
@@ -781,6 +925,7 @@ func TestGithubChecksResource_ValidateConfig_UnknownControlsListWithKnownRepos(t
 			Repos:     starList,
 			OmitRepos: types.ListNull(types.StringType),
 		}),
+		EnrolledRepos: nullEnrolledRepos(),
 	}
 
 	plan := tfsdk.Plan{Schema: schemaResp.Schema}
@@ -1588,6 +1733,12 @@ func TestGithubChecksResource_ConvertToState(t *testing.T) {
 			r := &githubChecksResource{}
 			got := r.convertToState(context.Background(), tc.owner, tc.input)
 
+			// The enrollment attributes mirror the API response; TestEnrolledRepos covers them.
+			tc.expected.EnableRequiredChecksForNewRepos = types.BoolValue(getBoolPointerValue(tc.input.EnableRequiredChecksForAllNewRepos))
+			tc.expected.EnableOptionalChecksForNewRepos = types.BoolValue(getBoolPointerValue(tc.input.EnableOptionalChecksForAllNewRepos))
+			tc.expected.EnableBaselineCheckForNewRepos = types.BoolValue(getBoolPointerValue(tc.input.EnableBaselineCheckForAllNewRepos))
+			tc.expected.EnrolledRepos = enrolledReposValue(tc.input.Repos)
+
 			// Use Equal to compare the entire result structure
 			assert.Equal(t, tc.expected, got)
 		})
@@ -2322,3 +2473,636 @@ func TestGithubChecksResource_UpdateStateListsWithOrderFromPlan(t *testing.T) {
 		})
 	}
 }
+
+func TestGithubChecksResource_CreateLeavesUnmanagedRepoEnrollment(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := &githubChecksResource{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	controls, diags := types.ListValueFrom(ctx, controlObjectType(), []control{{
+		Control:  types.StringValue("PWN Request"),
+		Enable:   types.BoolValue(true),
+		Type:     types.StringValue("required"),
+		Settings: types.ObjectNull(controlSettingsAttrTypes()),
+	}})
+	assert.False(t, diags.HasError())
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	diags = plan.Set(ctx, githubChecksModel{
+		Owner:             types.StringValue("test-org"),
+		Controls:          controls,
+		RequiredChecks:    types.ObjectNull(checksConfigAttrTypes()),
+		OptionalChecks:    types.ObjectNull(checksConfigAttrTypes()),
+		BaselineCheck:     types.ObjectNull(checksConfigAttrTypes()),
+		CustomDescription: types.StringNull(),
+		EnrolledRepos:     nullEnrolledRepos(),
+	})
+	assert.False(t, diags.HasError())
+
+	// Entries written by stepsecurity_github_checks_repo must survive a controls-only apply.
+	existingRepos := map[string]stepsecurityapi.CheckOptions{
+		"repo-a": {RunRequiredChecks: true},
+	}
+	mockClient := &stepsecurityapi.MockStepSecurityClient{}
+	mockClient.On("GetPRChecksConfig", mock.Anything, "test-org").Return(stepsecurityapi.GitHubPRChecksConfig{Repos: existingRepos}, nil).Once()
+	mockClient.On("UpdatePRChecksConfig", mock.Anything, "test-org", mock.MatchedBy(func(req stepsecurityapi.GitHubPRChecksConfig) bool {
+		return assert.ObjectsAreEqual(existingRepos, req.Repos) && req.Checks["pwn_request_check"].Enabled
+	})).Return(nil).Once()
+	r.client = mockClient
+
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, resp)
+	assert.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+	mockClient.AssertExpectations(t)
+
+	var state githubChecksModel
+	assert.False(t, resp.State.Get(ctx, &state).HasError())
+	assert.True(t, state.RequiredChecks.IsNull())
+	assert.True(t, state.OptionalChecks.IsNull())
+	assert.True(t, state.BaselineCheck.IsNull())
+}
+
+// testChecksModifyPlan runs ModifyPlan for controls, with cool_down_period defaulted in the
+// plan wherever settings are set, as the framework does.
+func testChecksModifyPlan(t *testing.T, client stepsecurityapi.Client, controls []control) (*resource.ModifyPlanResponse, resource.SchemaResponse) {
+	t.Helper()
+
+	ctx := context.Background()
+	r := &githubChecksResource{client: client}
+	if mockClient, ok := client.(*stepsecurityapi.MockStepSecurityClient); ok {
+		// Repository enrollment is planned from the owner's repositories.
+		mockClient.On("GetPRChecksConfig", mock.Anything, "test-org").Return(stepsecurityapi.GitHubPRChecksConfig{}, nil).Maybe()
+	}
+	schemaResp := resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	model := githubChecksModel{
+		Owner:             types.StringValue("test-org"),
+		Controls:          mustControlsList(controls),
+		RequiredChecks:    types.ObjectNull(checksConfigAttrTypes()),
+		OptionalChecks:    types.ObjectNull(checksConfigAttrTypes()),
+		BaselineCheck:     types.ObjectNull(checksConfigAttrTypes()),
+		CustomDescription: types.StringNull(),
+		EnrolledRepos:     nullEnrolledRepos(),
+	}
+	config := tfsdk.Config{Schema: schemaResp.Schema}
+	configPlan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, configPlan.Set(ctx, model).HasError())
+	config.Raw = configPlan.Raw
+
+	planned := make([]control, len(controls))
+	for i, c := range controls {
+		if !c.Settings.IsNull() {
+			attrs := c.Settings.Attributes()
+			if attrs["cool_down_period"].IsNull() {
+				attrs["cool_down_period"] = types.Int64Value(2)
+			}
+			c.Settings = types.ObjectValueMust(controlSettingsAttrTypes(), attrs)
+		}
+		planned[i] = c
+	}
+	model.Controls = mustControlsList(planned)
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(ctx, model).HasError())
+
+	resp := &resource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(ctx, resource.ModifyPlanRequest{Config: config, Plan: plan}, resp)
+	return resp, schemaResp
+}
+
+func TestGithubChecksResource_ModifyPlanWithControlCatalog(t *testing.T) {
+	t.Parallel()
+
+	t.Run("built-in controls do not read the catalog", func(t *testing.T) {
+		t.Parallel()
+
+		mockClient := &stepsecurityapi.MockStepSecurityClient{}
+		resp, _ := testChecksModifyPlan(t, mockClient, []control{{
+			Control:  types.StringValue("NPM Package Cooldown"),
+			Enable:   types.BoolValue(true),
+			Type:     types.StringValue("required"),
+			Settings: createNullSettingsObject(),
+		}})
+		require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+		mockClient.AssertNotCalled(t, "GetChecksControlCatalog", mock.Anything, mock.Anything)
+
+		var plan githubChecksModel
+		require.False(t, resp.Plan.Get(context.Background(), &plan).HasError())
+		controls := controlsListToSlice(plan.Controls)
+		assert.Equal(t, int64(2), controls[0].Settings.Attributes()["cool_down_period"].(types.Int64).ValueInt64())
+	})
+
+	t.Run("catalog control drops the cooldown default", func(t *testing.T) {
+		t.Parallel()
+
+		mockClient := &stepsecurityapi.MockStepSecurityClient{}
+		mockClient.On("GetChecksControlCatalog", mock.Anything, "test-org").Return(testControlCatalog(), nil).Once()
+		resp, _ := testChecksModifyPlan(t, mockClient, []control{{
+			Control:  types.StringValue("action_pinning_check"),
+			Enable:   types.BoolValue(true),
+			Type:     types.StringValue("optional"),
+			Settings: createValuesSettingsObject(map[string]string{"max_unpinned": "3"}),
+		}})
+		require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+		mockClient.AssertExpectations(t)
+
+		var plan githubChecksModel
+		require.False(t, resp.Plan.Get(context.Background(), &plan).HasError())
+		controls := controlsListToSlice(plan.Controls)
+		assert.True(t, controls[0].Settings.Attributes()["cool_down_period"].IsNull())
+	})
+
+	t.Run("invalid against catalog", func(t *testing.T) {
+		t.Parallel()
+
+		mockClient := &stepsecurityapi.MockStepSecurityClient{}
+		mockClient.On("GetChecksControlCatalog", mock.Anything, "test-org").Return(testControlCatalog(), nil).Once()
+		resp, _ := testChecksModifyPlan(t, mockClient, []control{
+			{
+				Control:  types.StringValue("unknown_check"),
+				Enable:   types.BoolValue(true),
+				Type:     types.StringValue("required"),
+				Settings: createNullSettingsObject(),
+			},
+			{
+				Control:  types.StringValue("action_pinning_check"),
+				Enable:   types.BoolValue(true),
+				Type:     types.StringValue("required"),
+				Settings: createValuesSettingsObject(map[string]string{"max_unpinned": "30"}),
+			},
+		})
+		require.True(t, resp.Diagnostics.HasError())
+
+		var details []string
+		for _, d := range resp.Diagnostics.Errors() {
+			details = append(details, d.Detail())
+		}
+		joined := strings.Join(details, "\n")
+		assert.Contains(t, joined, "unknown_check is not a control of test-org")
+		assert.Contains(t, joined, "can only run as optional")
+		assert.Contains(t, joined, "must be between 1 and 10")
+	})
+}
+
+func TestGithubChecksResource_CreateWithSettingValues(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := &githubChecksResource{}
+	schemaResp := resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(ctx, githubChecksModel{
+		Owner: types.StringValue("test-org"),
+		Controls: mustControlsList([]control{{
+			Control: types.StringValue("action_pinning_check"),
+			Enable:  types.BoolValue(true),
+			Type:    types.StringValue("optional"),
+			Settings: createValuesSettingsObject(map[string]string{
+				"allow_tags":     "true",
+				"max_unpinned":   "3",
+				"trusted_owners": `["actions", "github"]`,
+			}),
+		}}),
+		RequiredChecks:    types.ObjectNull(checksConfigAttrTypes()),
+		OptionalChecks:    types.ObjectNull(checksConfigAttrTypes()),
+		BaselineCheck:     types.ObjectNull(checksConfigAttrTypes()),
+		CustomDescription: types.StringNull(),
+		EnrolledRepos:     nullEnrolledRepos(),
+	}).HasError())
+
+	mockClient := &stepsecurityapi.MockStepSecurityClient{}
+	mockClient.On("GetChecksControlCatalog", mock.Anything, "test-org").Return(testControlCatalog(), nil).Once()
+	mockClient.On("GetPRChecksConfig", mock.Anything, "test-org").Return(stepsecurityapi.GitHubPRChecksConfig{}, nil).Once()
+	mockClient.On("UpdatePRChecksConfig", mock.Anything, "test-org", mock.MatchedBy(func(req stepsecurityapi.GitHubPRChecksConfig) bool {
+		return assert.ObjectsAreEqual(map[string]any{
+			"allow_tags":     true,
+			"max_unpinned":   int64(3),
+			"trusted_owners": []string{"actions", "github"},
+		}, req.Checks["action_pinning_check"].Settings)
+	})).Return(nil).Once()
+	r.client = mockClient
+
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, resp)
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+	mockClient.AssertExpectations(t)
+
+	var state githubChecksModel
+	require.False(t, resp.State.Get(ctx, &state).HasError())
+	controls := controlsListToSlice(state.Controls)
+	require.Len(t, controls, 1)
+	assert.Equal(t, "action_pinning_check", controls[0].Control.ValueString())
+	values, _ := controlSettingValues(controls[0].Settings)
+	assert.Equal(t, map[string]string{
+		"allow_tags":     "true",
+		"max_unpinned":   "3",
+		"trusted_owners": `["actions", "github"]`,
+	}, values)
+}
+
+func TestGithubChecksResource_CreateWithTypedSettings(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := &githubChecksResource{}
+	schemaResp := resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	compromisedSettings := nullControlSettings()
+	compromisedSettings["packages_to_exempt_in_compromised_updates_check"] = types.ListValueMust(types.StringType, stringsToAttrValues([]string{"left-pad"}))
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(ctx, githubChecksModel{
+		Owner: types.StringValue("test-org"),
+		Controls: mustControlsList([]control{
+			{
+				Control:  types.StringValue("NPM Package Compromised Updates"),
+				Enable:   types.BoolValue(true),
+				Type:     types.StringValue("required"),
+				Settings: types.ObjectValueMust(controlSettingsAttrTypes(), compromisedSettings),
+			},
+			{
+				Control:  types.StringValue("PWN Request"),
+				Enable:   types.BoolValue(true),
+				Type:     types.StringValue("required"),
+				Settings: createWorkflowSettingsObject([]string{".github/workflows/release.yml"}, "high"),
+			},
+		}),
+		RequiredChecks:    types.ObjectNull(checksConfigAttrTypes()),
+		OptionalChecks:    types.ObjectNull(checksConfigAttrTypes()),
+		BaselineCheck:     types.ObjectNull(checksConfigAttrTypes()),
+		CustomDescription: types.StringNull(),
+		EnrolledRepos:     nullEnrolledRepos(),
+	}).HasError())
+
+	mockClient := &stepsecurityapi.MockStepSecurityClient{}
+	mockClient.On("GetPRChecksConfig", mock.Anything, "test-org").Return(stepsecurityapi.GitHubPRChecksConfig{}, nil).Once()
+	mockClient.On("UpdatePRChecksConfig", mock.Anything, "test-org", mock.MatchedBy(func(req stepsecurityapi.GitHubPRChecksConfig) bool {
+		return assert.ObjectsAreEqual(map[string]any{
+			stepsecurityapi.ExemptedPackagesSetting: []string{"left-pad"},
+		}, req.Checks["npm_package_compromised_updates"].Settings) && assert.ObjectsAreEqual(map[string]any{
+			stepsecurityapi.ExemptedWorkflowsSetting: []string{".github/workflows/release.yml"},
+			stepsecurityapi.SeverityThresholdSetting: "high",
+		}, req.Checks["pwn_request_check"].Settings)
+	})).Return(nil).Once()
+	r.client = mockClient
+
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, resp)
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+	mockClient.AssertExpectations(t)
+
+	var state githubChecksModel
+	require.False(t, resp.State.Get(ctx, &state).HasError())
+	controls := controlsListToSlice(state.Controls)
+	require.Len(t, controls, 2)
+	assert.True(t, controls[0].Settings.Equal(types.ObjectValueMust(controlSettingsAttrTypes(), compromisedSettings)), "got %v", controls[0].Settings)
+	assert.True(t, controls[1].Settings.Equal(createWorkflowSettingsObject([]string{".github/workflows/release.yml"}, "high")), "got %v", controls[1].Settings)
+}
+
+// testPrivateState is an in-memory privateState.
+type testPrivateState map[string][]byte
+
+func (p testPrivateState) GetKey(_ context.Context, key string) ([]byte, diag.Diagnostics) {
+	return p[key], nil
+}
+
+func (p testPrivateState) SetKey(_ context.Context, key string, value []byte) diag.Diagnostics {
+	p[key] = value
+	return nil
+}
+
+func TestPreviousChecksConfig(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	private := testPrivateState{}
+
+	previous, diags := previousChecksConfig(ctx, private)
+	require.False(t, diags.HasError())
+	assert.Nil(t, previous)
+
+	saved := stepsecurityapi.GitHubPRChecksConfig{
+		ChecksConfig: stepsecurityapi.ChecksConfig{
+			Checks: map[string]stepsecurityapi.CheckConfig{
+				"pwn_request_check": {Enabled: true, Type: "required"},
+			},
+			CustomDescription: "Contact secops",
+		},
+		Repos: map[string]stepsecurityapi.CheckOptions{"repo-a": {Baseline: true}},
+	}
+	require.False(t, savePreviousChecksConfig(ctx, private, saved).HasError())
+
+	previous, diags = previousChecksConfig(ctx, private)
+	require.False(t, diags.HasError())
+	assert.Equal(t, &saved, previous)
+}
+
+func TestRestoredChecksConfig(t *testing.T) {
+	t.Parallel()
+
+	enabled := true
+	previous := stepsecurityapi.GitHubPRChecksConfig{
+		ChecksConfig: stepsecurityapi.ChecksConfig{
+			Checks: map[string]stepsecurityapi.CheckConfig{
+				"pwn_request_check": {Enabled: true, Type: "optional"},
+			},
+			EnableBaselineCheckForAllNewRepos: &enabled,
+		},
+		Repos: map[string]stepsecurityapi.CheckOptions{"repo-a": {Baseline: true}},
+	}
+	current := stepsecurityapi.GitHubPRChecksConfig{
+		ChecksConfig: stepsecurityapi.ChecksConfig{
+			Checks: map[string]stepsecurityapi.CheckConfig{
+				"pwn_request_check":      {Enabled: true, Type: "required"},
+				"script_injection_check": {Enabled: true, Type: "required", Settings: map[string]any{"severity_threshold": "high"}},
+			},
+		},
+		Repos: map[string]stepsecurityapi.CheckOptions{
+			"repo-a": {RunRequiredChecks: true},
+			"repo-b": {RunRequiredChecks: true},
+		},
+	}
+
+	restored := restoredChecksConfig(current, previous)
+	assert.Equal(t, map[string]stepsecurityapi.CheckConfig{
+		"pwn_request_check":      {Enabled: true, Type: "optional"},
+		"script_injection_check": {Type: "required"},
+	}, restored.Checks)
+	assert.Equal(t, map[string]stepsecurityapi.CheckOptions{
+		"repo-a": {Baseline: true},
+		"repo-b": {},
+	}, restored.Repos)
+	assert.True(t, *restored.EnableBaselineCheckForAllNewRepos)
+}
+
+func TestGithubChecksResource_DeleteOnDestroy(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name         string
+		onDestroy    string
+		expectDelete bool
+		expectWarn   bool
+	}{
+		{name: "disable_all", onDestroy: onDestroyDisableAll, expectDelete: true},
+		{name: "leave", onDestroy: onDestroyLeave},
+		{name: "restore_previous_without_snapshot", onDestroy: onDestroyRestorePrevious, expectDelete: true, expectWarn: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			r := &githubChecksResource{}
+			schemaResp := resource.SchemaResponse{}
+			r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+			require.False(t, schemaResp.Diagnostics.HasError())
+
+			state := tfsdk.State{Schema: schemaResp.Schema}
+			require.False(t, state.Set(ctx, githubChecksModel{
+				Owner:             types.StringValue("test-org"),
+				Controls:          types.ListNull(controlObjectType()),
+				RequiredChecks:    types.ObjectNull(checksConfigAttrTypes()),
+				OptionalChecks:    types.ObjectNull(checksConfigAttrTypes()),
+				BaselineCheck:     types.ObjectNull(checksConfigAttrTypes()),
+				CustomDescription: types.StringNull(),
+				EnrolledRepos:     nullEnrolledRepos(),
+				OnDestroy:         types.StringValue(tc.onDestroy),
+			}).HasError())
+
+			mockClient := &stepsecurityapi.MockStepSecurityClient{}
+			if tc.expectDelete {
+				mockClient.On("DeletePRChecksConfig", mock.Anything, "test-org").Return(nil).Once()
+			}
+			r.client = mockClient
+
+			resp := &resource.DeleteResponse{}
+			r.Delete(ctx, resource.DeleteRequest{State: state}, resp)
+			require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+			assert.Equal(t, tc.expectWarn, resp.Diagnostics.WarningsCount() > 0)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEnrolledRepos(t *testing.T) {
+	t.Parallel()
+
+	required := &checksConfig{
+		Repos:     types.ListValueMust(types.StringType, stringsToAttrValues([]string{"*"})),
+		OmitRepos: types.ListValueMust(types.StringType, stringsToAttrValues([]string{"repo-b"})),
+	}
+	baseline := &checksConfig{
+		Repos:     types.ListValueMust(types.StringType, stringsToAttrValues([]string{"repo-c"})),
+		OmitRepos: types.ListNull(types.StringType),
+	}
+	repos, allRepos := checksRepos(required, nil, baseline)
+	assert.Equal(t, stepsecurityapi.CheckOptions{RunRequiredChecks: true}, allRepos)
+
+	enrollAllRepos(repos, allRepos, map[string]stepsecurityapi.CheckOptions{
+		"repo-a": {},
+		"repo-b": {RunRequiredChecks: true},
+		"repo-c": {},
+	})
+	assert.Equal(t, map[string]stepsecurityapi.CheckOptions{
+		"repo-a": {RunRequiredChecks: true},
+		"repo-b": {},
+		"repo-c": {Baseline: true, RunRequiredChecks: true},
+	}, repos)
+
+	// Nothing is added when no check uses '*'.
+	repos, allRepos = checksRepos(nil, nil, baseline)
+	enrollAllRepos(repos, allRepos, map[string]stepsecurityapi.CheckOptions{"repo-a": {}})
+	assert.Equal(t, map[string]stepsecurityapi.CheckOptions{"repo-c": {Baseline: true}}, repos)
+}
+
+func TestGithubChecksResource_ModifyPlanRepoEnrollment(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mockClient := &stepsecurityapi.MockStepSecurityClient{}
+	mockClient.On("GetPRChecksConfig", mock.Anything, "test-org").Return(stepsecurityapi.GitHubPRChecksConfig{
+		Repos: map[string]stepsecurityapi.CheckOptions{
+			"repo-a": {},
+			"repo-b": {RunRequiredChecks: true},
+		},
+	}, nil).Once()
+	r := &githubChecksResource{client: mockClient}
+	schemaResp := resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	model := githubChecksModel{
+		Owner: types.StringValue("test-org"),
+		Controls: mustControlsList([]control{{
+			Control:  types.StringValue("PWN Request"),
+			Enable:   types.BoolValue(true),
+			Type:     types.StringValue("required"),
+			Settings: createNullSettingsObject(),
+		}}),
+		RequiredChecks: mustChecksConfigObject(&checksConfig{
+			Repos:     types.ListValueMust(types.StringType, stringsToAttrValues([]string{"*"})),
+			OmitRepos: types.ListValueMust(types.StringType, stringsToAttrValues([]string{"repo-b"})),
+		}),
+		OptionalChecks:    types.ObjectNull(checksConfigAttrTypes()),
+		BaselineCheck:     types.ObjectNull(checksConfigAttrTypes()),
+		CustomDescription: types.StringNull(),
+		OnDestroy:         types.StringValue(onDestroyDisableAll),
+		EnrolledRepos:     nullEnrolledRepos(),
+	}
+	config := tfsdk.Config{Schema: schemaResp.Schema}
+	configPlan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, configPlan.Set(ctx, model).HasError())
+	config.Raw = configPlan.Raw
+
+	model.EnableRequiredChecksForNewRepos = types.BoolUnknown()
+	model.EnableOptionalChecksForNewRepos = types.BoolUnknown()
+	model.EnableBaselineCheckForNewRepos = types.BoolUnknown()
+	model.EnrolledRepos = types.MapUnknown(types.ObjectType{AttrTypes: enrolledRepoAttrTypes()})
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(ctx, model).HasError())
+
+	resp := &resource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(ctx, resource.ModifyPlanRequest{Config: config, Plan: plan}, resp)
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+	mockClient.AssertExpectations(t)
+
+	var planned githubChecksModel
+	require.False(t, resp.Plan.Get(ctx, &planned).HasError())
+	assert.True(t, planned.EnableRequiredChecksForNewRepos.ValueBool())
+	assert.False(t, planned.EnableOptionalChecksForNewRepos.ValueBool())
+	assert.False(t, planned.EnableBaselineCheckForNewRepos.IsUnknown())
+	assert.True(t, planned.EnrolledRepos.Equal(enrolledReposValue(map[string]stepsecurityapi.CheckOptions{
+		"repo-a": {RunRequiredChecks: true},
+		"repo-b": {},
+	})), "got %v", planned.EnrolledRepos)
+}
+
+func TestGithubChecksResource_ModifyPlanUnmanagedRepoEnrollment(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mockClient := &stepsecurityapi.MockStepSecurityClient{}
+	mockClient.On("GetPRChecksConfig", mock.Anything, "test-org").Return(stepsecurityapi.GitHubPRChecksConfig{
+		ChecksConfig: stepsecurityapi.ChecksConfig{
+			EnableRequiredChecksForAllNewRepos: func() *bool { b := true; return &b }(),
+		},
+		Repos: map[string]stepsecurityapi.CheckOptions{
+			"repo-a": {RunRequiredChecks: true},
+		},
+	}, nil).Once()
+	r := &githubChecksResource{client: mockClient}
+	schemaResp := resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	model := githubChecksModel{
+		Owner: types.StringValue("test-org"),
+		Controls: mustControlsList([]control{{
+			Control:  types.StringValue("PWN Request"),
+			Enable:   types.BoolValue(true),
+			Type:     types.StringValue("required"),
+			Settings: createNullSettingsObject(),
+		}}),
+		RequiredChecks:    types.ObjectNull(checksConfigAttrTypes()),
+		OptionalChecks:    types.ObjectNull(checksConfigAttrTypes()),
+		BaselineCheck:     types.ObjectNull(checksConfigAttrTypes()),
+		CustomDescription: types.StringNull(),
+		OnDestroy:         types.StringValue(onDestroyDisableAll),
+		EnrolledRepos:     nullEnrolledRepos(),
+	}
+	config := tfsdk.Config{Schema: schemaResp.Schema}
+	configPlan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, configPlan.Set(ctx, model).HasError())
+	config.Raw = configPlan.Raw
+
+	model.EnableRequiredChecksForNewRepos = types.BoolUnknown()
+	model.EnableOptionalChecksForNewRepos = types.BoolUnknown()
+	model.EnableBaselineCheckForNewRepos = types.BoolUnknown()
+	model.EnrolledRepos = types.MapUnknown(types.ObjectType{AttrTypes: enrolledRepoAttrTypes()})
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(ctx, model).HasError())
+
+	resp := &resource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(ctx, resource.ModifyPlanRequest{Config: config, Plan: plan}, resp)
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+	mockClient.AssertExpectations(t)
+
+	var planned githubChecksModel
+	require.False(t, resp.Plan.Get(ctx, &planned).HasError())
+	assert.True(t, planned.EnableRequiredChecksForNewRepos.ValueBool())
+	// stepsecurity_github_checks_repo resources in the same apply may still change it.
+	assert.True(t, planned.EnrolledRepos.IsUnknown())
+}
+
+func TestGithubChecksResource_CreateEnrollsExistingRepos(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := &githubChecksResource{}
+	schemaResp := resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(ctx, githubChecksModel{
+		Owner: types.StringValue("test-org"),
+		Controls: mustControlsList([]control{{
+			Control:  types.StringValue("PWN Request"),
+			Enable:   types.BoolValue(true),
+			Type:     types.StringValue("required"),
+			Settings: createNullSettingsObject(),
+		}}),
+		RequiredChecks: mustChecksConfigObject(&checksConfig{
+			Repos:     types.ListValueMust(types.StringType, stringsToAttrValues([]string{"*"})),
+			OmitRepos: types.ListNull(types.StringType),
+		}),
+		OptionalChecks:                  types.ObjectNull(checksConfigAttrTypes()),
+		BaselineCheck:                   types.ObjectNull(checksConfigAttrTypes()),
+		CustomDescription:               types.StringNull(),
+		OnDestroy:                       types.StringValue(onDestroyDisableAll),
+		EnableRequiredChecksForNewRepos: types.BoolValue(false),
+		EnableOptionalChecksForNewRepos: types.BoolValue(false),
+		EnableBaselineCheckForNewRepos:  types.BoolValue(false),
+		EnrolledRepos: enrolledReposValue(map[string]stepsecurityapi.CheckOptions{
+			"repo-a": {RunRequiredChecks: true},
+		}),
+	}).HasError())
+
+	mockClient := &stepsecurityapi.MockStepSecurityClient{}
+	mockClient.On("GetPRChecksConfig", mock.Anything, "test-org").Return(stepsecurityapi.GitHubPRChecksConfig{
+		Repos: map[string]stepsecurityapi.CheckOptions{"repo-a": {}},
+	}, nil).Once()
+	mockClient.On("UpdatePRChecksConfig", mock.Anything, "test-org", mock.MatchedBy(func(req stepsecurityapi.GitHubPRChecksConfig) bool {
+		return !*req.EnableRequiredChecksForAllNewRepos && assert.ObjectsAreEqual(map[string]stepsecurityapi.CheckOptions{
+			"repo-a": {RunRequiredChecks: true},
+		}, req.Repos)
+	})).Return(nil).Once()
+	r.client = mockClient
+
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, resp)
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+	mockClient.AssertExpectations(t)
+
+	var state githubChecksModel
+	require.False(t, resp.State.Get(ctx, &state).HasError())
+	// '*' is kept even though StepSecurity reports repo-a by name, since new repositories
+	// are not enrolled.
+	required := checksConfigFromObject(state.RequiredChecks)
+	require.NotNil(t, required)
+	assert.Equal(t, []string{"*"}, r.listToStringSlice(required.Repos))
+	assert.True(t, required.OmitRepos.IsNull())
+	assert.False(t, state.EnableRequiredChecksForNewRepos.ValueBool())
+	assert.Len(t, state.EnrolledRepos.Elements(), 1)
+}
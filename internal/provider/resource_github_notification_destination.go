@@ -0,0 +1,450 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &githubNotificationDestinationResource{}
+	_ resource.ResourceWithConfigure      = &githubNotificationDestinationResource{}
+	_ resource.ResourceWithImportState    = &githubNotificationDestinationResource{}
+	_ resource.ResourceWithValidateConfig = &githubNotificationDestinationResource{}
+)
+
+var (
+	pagerDutyRoutingKeyPattern = regexp.MustCompile(`^[A-Za-z0-9]{32}$`)
+	opsgenieAPIKeyPattern      = regexp.MustCompile(`^[0-9A-Fa-f]{8}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{12}$`)
+)
+
+// NewGithubNotificationDestinationResource is a helper function to simplify the provider implementation.
+func NewGithubNotificationDestinationResource() resource.Resource {
+	return &githubNotificationDestinationResource{}
+}
+
+// githubNotificationDestinationResource manages a single notification destination. An
+// owner can have any number of destinations, each subscribed to its own events.
+type githubNotificationDestinationResource struct {
+	client stepsecurityapi.Client
+}
+
+type githubNotificationDestinationModel struct {
+	DestinationID          types.String `tfsdk:"destination_id"`
+	Owner                  types.String `tfsdk:"owner"`
+	Name                   types.String `tfsdk:"name"`
+	Type                   types.String `tfsdk:"type"`
+	Target                 types.String `tfsdk:"target"`
+	Events                 types.Set    `tfsdk:"events"`
+	Repos                  types.Set    `tfsdk:"repos"`
+	SigningSecret          types.String `tfsdk:"signing_secret"`
+	SigningSecretWO        types.String `tfsdk:"signing_secret_wo"`
+	SigningSecretWOVersion types.Int64  `tfsdk:"signing_secret_wo_version"`
+	Region                 types.String `tfsdk:"region"`
+}
+
+// Metadata returns the resource type name.
+func (r *githubNotificationDestinationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_github_notification_destination"
+}
+
+// Schema defines the schema for the resource.
+func (r *githubNotificationDestinationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a notification destination: a Slack or Microsoft Teams channel, an email address, an HTTPS webhook, a PagerDuty service or an Opsgenie team that receives the subscribed events. " +
+			"Unlike `stepsecurity_github_org_notification_settings`, an owner can have any number of destinations, so different events can be routed to different channels.",
+		Attributes: map[string]schema.Attribute{
+			"destination_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the notification destination.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"owner": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The GitHub organization or user the destination belongs to.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "A name for the destination, shown in the StepSecurity dashboard.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"type": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The destination type: `slack`, `teams`, `email`, `webhook`, `pagerduty` or `opsgenie`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(stepsecurityapi.NotificationDestinationTypes...),
+				},
+			},
+			"target": schema.StringAttribute{
+				Required:  true,
+				Sensitive: true,
+				MarkdownDescription: "Where notifications are sent: the incoming webhook URL for `slack` and `teams`, " +
+					"the email address for `email`, the HTTPS endpoint for `webhook`, the Events API v2 routing key of the service for `pagerduty` " +
+					"and the API integration key for `opsgenie`.",
+			},
+			"events": schema.SetAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				MarkdownDescription: "The events sent to the destination. Valid values are `" +
+					strings.Join(stepsecurityapi.NotificationEvents, "`, `") + "`.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(stepsecurityapi.NotificationEvents...)),
+				},
+			},
+			"repos": schema.SetAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Only send events from these repositories. Events from all repositories are sent when unset.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"signing_secret": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+				MarkdownDescription: "The secret `webhook` deliveries are signed with. Each request carries an `X-StepSecurity-Signature-256` header holding the hex HMAC-SHA256 of the body. " +
+					"StepSecurity never returns the secret, so changes made outside Terraform are not detected. Prefer `signing_secret_wo`, which keeps the secret out of the state.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.ConflictsWith(path.MatchRoot("signing_secret_wo")),
+					stringvalidator.PreferWriteOnlyAttribute(path.MatchRoot("signing_secret_wo")),
+				},
+			},
+			"signing_secret_wo": schema.StringAttribute{
+				Optional:  true,
+				WriteOnly: true,
+				Sensitive: true,
+				MarkdownDescription: "Write-only variant of `signing_secret` that is never stored in the plan or state. Requires Terraform 1.11 or later and `signing_secret_wo_version`. " +
+					"The secret is only sent when the destination is created or `signing_secret_wo_version` changes.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.AlsoRequires(path.MatchRoot("signing_secret_wo_version")),
+				},
+			},
+			"signing_secret_wo_version": schema.Int64Attribute{
+				Optional: true,
+				MarkdownDescription: "The version of `signing_secret_wo`. As the secret is not stored, Terraform can't detect when it changes or is removed; change the version to send a new secret. " +
+					"Required with `signing_secret_wo`.",
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("signing_secret_wo")),
+				},
+			},
+			"region": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The region of the Opsgenie account, `us` or `eu`. StepSecurity uses `us` when unset. Only valid for `opsgenie`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(stepsecurityapi.OpsgenieRegions...),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *githubNotificationDestinationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(stepsecurityapi.Client)
+	if !ok || client == nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected stepsecurityapi.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// ValidateConfig checks that target and the type specific attributes fit the destination type.
+func (r *githubNotificationDestinationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config githubNotificationDestinationModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Type.IsUnknown() || config.Type.IsNull() {
+		return
+	}
+	destinationType := config.Type.ValueString()
+
+	if !config.Target.IsUnknown() && !config.Target.IsNull() {
+		if problem := notificationTargetProblem(destinationType, config.Target.ValueString()); problem != "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("target"),
+				"Invalid Notification Target",
+				problem,
+			)
+		}
+	}
+
+	if destinationType != stepsecurityapi.NotificationDestinationWebhook {
+		for name, value := range map[string]types.String{"signing_secret": config.SigningSecret, "signing_secret_wo": config.SigningSecretWO} {
+			if !value.IsNull() {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(name),
+					"Invalid Attribute Combination",
+					fmt.Sprintf("%s can only be set for a webhook destination, not for a %s destination.", name, destinationType),
+				)
+			}
+		}
+	}
+	if destinationType != stepsecurityapi.NotificationDestinationOpsgenie && !config.Region.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("region"),
+			"Invalid Attribute Combination",
+			fmt.Sprintf("region can only be set for an opsgenie destination, not for a %s destination.", destinationType),
+		)
+	}
+}
+
+// notificationTargetProblem describes why target can't be used for a destination of
+// the given type, or returns "" when it can.
+func notificationTargetProblem(destinationType, target string) string {
+	switch destinationType {
+	case stepsecurityapi.NotificationDestinationEmail:
+		address, err := mail.ParseAddress(target)
+		if err != nil || address.Address != target {
+			return fmt.Sprintf("target %q must be an email address for an email destination.", target)
+		}
+	case stepsecurityapi.NotificationDestinationPagerDuty:
+		// Keys are secrets, so they are left out of the messages.
+		if !pagerDutyRoutingKeyPattern.MatchString(target) {
+			return "target must be the 32 character Events API v2 routing key of a PagerDuty service for a pagerduty destination."
+		}
+	case stepsecurityapi.NotificationDestinationOpsgenie:
+		if !opsgenieAPIKeyPattern.MatchString(target) {
+			return "target must be the API key of an Opsgenie API integration, a UUID, for an opsgenie destination."
+		}
+	default:
+		parsed, err := url.Parse(target)
+		if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+			return fmt.Sprintf("target must be an https:// URL for a %s destination.", destinationType)
+		}
+	}
+	return ""
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *githubNotificationDestinationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan githubNotificationDestinationModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var signingSecretWO types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("signing_secret_wo"), &signingSecretWO)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	destination := r.notificationDestinationFromModel(ctx, plan)
+	switch {
+	case !signingSecretWO.IsNull():
+		destination.SigningSecret = signingSecretWO.ValueStringPointer()
+	case !plan.SigningSecret.IsNull():
+		destination.SigningSecret = plan.SigningSecret.ValueStringPointer()
+	}
+
+	created, err := r.client.CreateNotificationDestination(ctx, plan.Owner.ValueString(), destination)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating notification destination",
+			"Could not create notification destination, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	r.updateModelFromAPI(&plan, created)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *githubNotificationDestinationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state githubNotificationDestinationModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	destination, err := r.client.GetNotificationDestination(ctx, state.Owner.ValueString(), state.DestinationID.ValueString())
+	if err != nil && stepsecurityapi.IsNotFound(err) {
+		tflog.Info(ctx, "Notification destination no longer exists, removing from state", map[string]any{
+			"owner":          state.Owner.ValueString(),
+			"destination_id": state.DestinationID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading notification destination",
+			"Could not read notification destination ID "+state.DestinationID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	r.updateModelFromAPI(&state, destination)
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *githubNotificationDestinationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan githubNotificationDestinationModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state githubNotificationDestinationModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	var signingSecretWO types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("signing_secret_wo"), &signingSecretWO)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	destination := r.notificationDestinationFromModel(ctx, plan)
+	destination.SigningSecret = signingSecretUpdate(plan, state, signingSecretWO)
+	if err := r.client.UpdateNotificationDestination(ctx, plan.Owner.ValueString(), destination); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating notification destination",
+			"Could not update notification destination ID "+plan.DestinationID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	updated, err := r.client.GetNotificationDestination(ctx, plan.Owner.ValueString(), plan.DestinationID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading notification destination",
+			"Could not read notification destination ID "+plan.DestinationID.ValueString()+" after update: "+err.Error(),
+		)
+		return
+	}
+
+	r.updateModelFromAPI(&plan, updated)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *githubNotificationDestinationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state githubNotificationDestinationModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteNotificationDestination(ctx, state.Owner.ValueString(), state.DestinationID.ValueString())
+	if err != nil && !stepsecurityapi.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error deleting notification destination",
+			"Could not delete notification destination, unexpected error: "+err.Error(),
+		)
+	}
+}
+
+// ImportState imports the resource using an owner/destination_id identifier.
+func (r *githubNotificationDestinationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected owner/destination_id, got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("owner"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("destination_id"), parts[1])...)
+}
+
+// signingSecretUpdate returns the signing secret to send when updating a destination:
+// nil keeps the secret StepSecurity has and an empty string removes it. A write-only
+// secret is only sent when its version changes, as its previous value is unknown.
+func signingSecretUpdate(plan, state githubNotificationDestinationModel, signingSecretWO types.String) *string {
+	switch {
+	case !signingSecretWO.IsNull():
+		if plan.SigningSecretWOVersion.Equal(state.SigningSecretWOVersion) && state.SigningSecret.IsNull() {
+			return nil
+		}
+		return signingSecretWO.ValueStringPointer()
+	case !plan.SigningSecret.IsNull():
+		return plan.SigningSecret.ValueStringPointer()
+	case !state.SigningSecret.IsNull() || !state.SigningSecretWOVersion.IsNull():
+		removed := ""
+		return &removed
+	default:
+		return nil
+	}
+}
+
+// notificationDestinationFromModel converts the model to the API representation.
+func (r *githubNotificationDestinationResource) notificationDestinationFromModel(ctx context.Context, model githubNotificationDestinationModel) stepsecurityapi.NotificationDestination {
+	destination := stepsecurityapi.NotificationDestination{
+		ID:     model.DestinationID.ValueString(),
+		Name:   model.Name.ValueString(),
+		Type:   model.Type.ValueString(),
+		Target: model.Target.ValueString(),
+		Region: model.Region.ValueString(),
+	}
+	model.Events.ElementsAs(ctx, &destination.Events, false)
+	if !model.Repos.IsNull() && !model.Repos.IsUnknown() {
+		model.Repos.ElementsAs(ctx, &destination.Repos, false)
+	}
+	return destination
+}
+
+// updateModelFromAPI writes the destination returned by the API into the model. The
+// signing secret is never returned, so the model keeps its value, and region is only
+// refreshed when configured to avoid a diff on the default StepSecurity applies.
+func (r *githubNotificationDestinationResource) updateModelFromAPI(model *githubNotificationDestinationModel, destination *stepsecurityapi.NotificationDestination) {
+	model.DestinationID = types.StringValue(destination.ID)
+	model.Name = types.StringValue(destination.Name)
+	model.Type = types.StringValue(destination.Type)
+	model.Target = types.StringValue(destination.Target)
+	model.Events = types.SetValueMust(types.StringType, stringsToAttrValues(destination.Events))
+	if len(destination.Repos) == 0 {
+		model.Repos = types.SetNull(types.StringType)
+	} else {
+		model.Repos = types.SetValueMust(types.StringType, stringsToAttrValues(destination.Repos))
+	}
+	if !model.Region.IsNull() {
+		model.Region = optionalStringValue(destination.Region)
+	}
+	model.SigningSecretWO = types.StringNull()
+}
@@ -0,0 +1,473 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
+)
+
+func TestGithubNotificationDestinationResource_Metadata(t *testing.T) {
+	t.Parallel()
+
+	r := &githubNotificationDestinationResource{}
+	resp := &resource.MetadataResponse{}
+	r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "stepsecurity"}, resp)
+
+	assert.Equal(t, "stepsecurity_github_notification_destination", resp.TypeName)
+}
+
+func TestGithubNotificationDestinationResource_Schema(t *testing.T) {
+	t.Parallel()
+
+	r := &githubNotificationDestinationResource{}
+	resp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), resource.SchemaRequest{}, resp)
+	require.False(t, resp.Diagnostics.HasError())
+
+	for _, name := range []string{"destination_id", "owner", "name", "type", "target", "events", "repos"} {
+		assert.Contains(t, resp.Schema.Attributes, name)
+	}
+	assert.True(t, resp.Schema.Attributes["destination_id"].IsComputed())
+	assert.True(t, resp.Schema.Attributes["events"].IsRequired())
+	assert.True(t, resp.Schema.Attributes["repos"].IsOptional())
+}
+
+func TestNotificationTargetProblem(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		destinationType string
+		target          string
+		valid           bool
+	}{
+		{destinationType: "slack", target: "https://hooks.slack.com/services/T000/B000/XXXX", valid: true},
+		{destinationType: "teams", target: "https://example.webhook.office.com/webhookb2/abc", valid: true},
+		{destinationType: "webhook", target: "https://alerts.example.com/stepsecurity", valid: true},
+		{destinationType: "email", target: "security@example.com", valid: true},
+		{destinationType: "slack", target: "http://hooks.slack.com/services/T000/B000/XXXX"},
+		{destinationType: "webhook", target: "alerts.example.com"},
+		{destinationType: "email", target: "https://example.com"},
+		{destinationType: "email", target: "Security <security@example.com>"},
+		{destinationType: "pagerduty", target: "R0UT1NGK3Y0000000000000000000000", valid: true},
+		{destinationType: "pagerduty", target: "https://events.pagerduty.com/v2/enqueue"},
+		{destinationType: "opsgenie", target: "9a1b2c3d-4e5f-4a6b-8c7d-0e1f2a3b4c5d", valid: true},
+		{destinationType: "opsgenie", target: "not-a-key"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.destinationType+"_"+tc.target, func(t *testing.T) {
+			t.Parallel()
+
+			problem := notificationTargetProblem(tc.destinationType, tc.target)
+			if tc.valid {
+				assert.Empty(t, problem)
+			} else {
+				assert.NotEmpty(t, problem)
+			}
+		})
+	}
+}
+
+func TestGithubNotificationDestinationResource_ValidateConfig(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		modify        func(*githubNotificationDestinationModel)
+		expectedPaths []string
+	}{
+		{
+			name: "webhook_with_signing_secret",
+			modify: func(m *githubNotificationDestinationModel) {
+				m.Type = types.StringValue("webhook")
+				m.Target = types.StringValue("https://alerts.example.com/stepsecurity")
+				m.SigningSecretWO = types.StringValue("s3cr3t")
+				m.SigningSecretWOVersion = types.Int64Value(1)
+			},
+		},
+		{
+			name: "opsgenie_with_region",
+			modify: func(m *githubNotificationDestinationModel) {
+				m.Type = types.StringValue("opsgenie")
+				m.Target = types.StringValue("9a1b2c3d-4e5f-4a6b-8c7d-0e1f2a3b4c5d")
+				m.Region = types.StringValue("eu")
+			},
+		},
+		{
+			name: "signing_secret_on_slack",
+			modify: func(m *githubNotificationDestinationModel) {
+				m.SigningSecret = types.StringValue("s3cr3t")
+			},
+			expectedPaths: []string{"signing_secret"},
+		},
+		{
+			name: "region_on_pagerduty",
+			modify: func(m *githubNotificationDestinationModel) {
+				m.Type = types.StringValue("pagerduty")
+				m.Target = types.StringValue("R0UT1NGK3Y0000000000000000000000")
+				m.Region = types.StringValue("us")
+			},
+			expectedPaths: []string{"region"},
+		},
+		{
+			name: "email_target_for_webhook",
+			modify: func(m *githubNotificationDestinationModel) {
+				m.Type = types.StringValue("webhook")
+				m.Target = types.StringValue("security@example.com")
+			},
+			expectedPaths: []string{"target"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			model := testNotificationDestinationModel("")
+			tc.modify(&model)
+
+			r := &githubNotificationDestinationResource{}
+			resp := &resource.ValidateConfigResponse{}
+			r.ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: testResourceConfig(t, &githubNotificationDestinationResource{}, model)}, resp)
+
+			var paths []string
+			for _, d := range resp.Diagnostics.Errors() {
+				if withPath, ok := d.(diag.DiagnosticWithPath); ok {
+					paths = append(paths, withPath.Path().String())
+				}
+			}
+			assert.ElementsMatch(t, tc.expectedPaths, paths)
+		})
+	}
+}
+
+func TestSigningSecretUpdate(t *testing.T) {
+	t.Parallel()
+
+	withVersion := func(m githubNotificationDestinationModel, version int64) githubNotificationDestinationModel {
+		m.SigningSecretWOVersion = types.Int64Value(version)
+		return m
+	}
+	withSecret := func(m githubNotificationDestinationModel, secret string) githubNotificationDestinationModel {
+		m.SigningSecret = types.StringValue(secret)
+		return m
+	}
+	base := testNotificationDestinationModel("dest-123")
+
+	testCases := []struct {
+		name     string
+		plan     githubNotificationDestinationModel
+		state    githubNotificationDestinationModel
+		wo       types.String
+		expected *string
+	}{
+		{name: "no_secret", plan: base, state: base, wo: types.StringNull()},
+		{name: "secret_sent", plan: withSecret(base, "new"), state: withSecret(base, "old"), wo: types.StringNull(), expected: stringPtr("new")},
+		{name: "secret_removed", plan: base, state: withSecret(base, "old"), wo: types.StringNull(), expected: stringPtr("")},
+		{name: "wo_version_unchanged", plan: withVersion(base, 1), state: withVersion(base, 1), wo: types.StringValue("new")},
+		{name: "wo_version_changed", plan: withVersion(base, 2), state: withVersion(base, 1), wo: types.StringValue("new"), expected: stringPtr("new")},
+		{name: "secret_moved_to_wo", plan: base, state: withSecret(base, "old"), wo: types.StringValue("new"), expected: stringPtr("new")},
+		{name: "wo_removed", plan: base, state: withVersion(base, 1), wo: types.StringNull(), expected: stringPtr("")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, signingSecretUpdate(tc.plan, tc.state, tc.wo))
+		})
+	}
+}
+
+func TestGithubNotificationDestinationResource_SigningSecretWORequiresVersion(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		version     types.Int64
+		expectError bool
+	}{
+		{name: "with_version", version: types.Int64Value(1)},
+		{name: "without_version", version: types.Int64Null(), expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			model := testNotificationDestinationModel("")
+			model.Type = types.StringValue("webhook")
+			model.Target = types.StringValue("https://alerts.example.com/stepsecurity")
+			model.SigningSecretWO = types.StringValue("s3cr3t")
+			model.SigningSecretWOVersion = tc.version
+
+			attrPath := path.Root("signing_secret_wo")
+			req := validator.StringRequest{
+				Config:         testResourceConfig(t, &githubNotificationDestinationResource{}, model),
+				ConfigValue:    model.SigningSecretWO,
+				Path:           attrPath,
+				PathExpression: attrPath.Expression(),
+			}
+			resp := &validator.StringResponse{}
+			for _, v := range testResourceSchema(t, &githubNotificationDestinationResource{}).Attributes["signing_secret_wo"].(resourceschema.StringAttribute).Validators {
+				v.ValidateString(ctx, req, resp)
+			}
+
+			assert.Equal(t, tc.expectError, resp.Diagnostics.HasError(), "diags: %v", resp.Diagnostics)
+		})
+	}
+}
+
+func TestGithubNotificationDestinationResource_UpdateRemovesWriteOnlySecret(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mockClient := &stepsecurityapi.MockStepSecurityClient{}
+	mockClient.On("UpdateNotificationDestination", mock.Anything, "test-org", mock.MatchedBy(func(dest stepsecurityapi.NotificationDestination) bool {
+		return dest.SigningSecret != nil && *dest.SigningSecret == ""
+	})).Return(nil)
+	mockClient.On("GetNotificationDestination", mock.Anything, "test-org", "dest-123").Return(&stepsecurityapi.NotificationDestination{
+		ID:     "dest-123",
+		Name:   "security-alerts",
+		Type:   "webhook",
+		Target: "https://alerts.example.com/stepsecurity",
+		Events: []string{"secrets_detected"},
+	}, nil)
+
+	state := testNotificationDestinationModel("dest-123")
+	state.Type = types.StringValue("webhook")
+	state.Target = types.StringValue("https://alerts.example.com/stepsecurity")
+	state.SigningSecretWOVersion = types.Int64Value(1)
+	// signing_secret_wo and its version are removed from the configuration.
+	plan := state
+	plan.SigningSecretWOVersion = types.Int64Null()
+
+	r := &githubNotificationDestinationResource{client: mockClient}
+	resp := &resource.UpdateResponse{State: tfsdk.State{Schema: testResourceSchema(t, &githubNotificationDestinationResource{})}}
+	r.Update(ctx, resource.UpdateRequest{
+		Plan:   testResourcePlan(t, &githubNotificationDestinationResource{}, plan),
+		Config: testResourceConfig(t, &githubNotificationDestinationResource{}, plan),
+		State:  testResourceState(t, &githubNotificationDestinationResource{}, state),
+	}, resp)
+
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGithubNotificationDestinationResource_CreateWithWriteOnlySecret(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mockClient := &stepsecurityapi.MockStepSecurityClient{}
+	mockClient.On("CreateNotificationDestination", mock.Anything, "test-org", mock.MatchedBy(func(dest stepsecurityapi.NotificationDestination) bool {
+		return dest.SigningSecret != nil && *dest.SigningSecret == "s3cr3t"
+	})).Return(&stepsecurityapi.NotificationDestination{
+		ID:     "dest-123",
+		Name:   "security-alerts",
+		Type:   "webhook",
+		Target: "https://alerts.example.com/stepsecurity",
+		Events: []string{"secrets_detected"},
+	}, nil)
+
+	config := testNotificationDestinationModel("")
+	config.Type = types.StringValue("webhook")
+	config.Target = types.StringValue("https://alerts.example.com/stepsecurity")
+	config.SigningSecretWO = types.StringValue("s3cr3t")
+	config.SigningSecretWOVersion = types.Int64Value(1)
+	plan := config
+	plan.SigningSecretWO = types.StringNull()
+
+	r := &githubNotificationDestinationResource{client: mockClient}
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: testResourceSchema(t, &githubNotificationDestinationResource{})}}
+	r.Create(ctx, resource.CreateRequest{Plan: testResourcePlan(t, &githubNotificationDestinationResource{}, plan), Config: testResourceConfig(t, &githubNotificationDestinationResource{}, config)}, resp)
+
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+	mockClient.AssertExpectations(t)
+
+	var state githubNotificationDestinationModel
+	require.False(t, resp.State.Get(ctx, &state).HasError())
+	assert.True(t, state.SigningSecretWO.IsNull())
+	assert.True(t, state.SigningSecret.IsNull())
+	assert.Equal(t, int64(1), state.SigningSecretWOVersion.ValueInt64())
+}
+
+func TestGithubNotificationDestinationResource_Create(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mockClient := &stepsecurityapi.MockStepSecurityClient{}
+	mockClient.On("CreateNotificationDestination", mock.Anything, "test-org", stepsecurityapi.NotificationDestination{
+		Name:   "security-alerts",
+		Type:   "slack",
+		Target: "https://hooks.slack.com/services/T000/B000/XXXX",
+		Events: []string{"secrets_detected"},
+	}).Return(&stepsecurityapi.NotificationDestination{
+		ID:     "dest-123",
+		Name:   "security-alerts",
+		Type:   "slack",
+		Target: "https://hooks.slack.com/services/T000/B000/XXXX",
+		Events: []string{"secrets_detected"},
+	}, nil)
+
+	r := &githubNotificationDestinationResource{client: mockClient}
+	plan := testNotificationDestinationModel("")
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: testResourceSchema(t, &githubNotificationDestinationResource{})}}
+	r.Create(ctx, resource.CreateRequest{Plan: testResourcePlan(t, &githubNotificationDestinationResource{}, plan), Config: testResourceConfig(t, &githubNotificationDestinationResource{}, plan)}, resp)
+
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+	mockClient.AssertExpectations(t)
+
+	var state githubNotificationDestinationModel
+	require.False(t, resp.State.Get(ctx, &state).HasError())
+	assert.Equal(t, "dest-123", state.DestinationID.ValueString())
+	assert.True(t, state.Repos.IsNull())
+}
+
+func TestGithubNotificationDestinationResource_UpdateUsesServerState(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mockClient := &stepsecurityapi.MockStepSecurityClient{}
+	mockClient.On("UpdateNotificationDestination", mock.Anything, "test-org", mock.MatchedBy(func(dest stepsecurityapi.NotificationDestination) bool {
+		return dest.ID == "dest-123" && len(dest.Repos) == 1 && dest.Repos[0] == "api"
+	})).Return(nil)
+	mockClient.On("GetNotificationDestination", mock.Anything, "test-org", "dest-123").Return(&stepsecurityapi.NotificationDestination{
+		ID:     "dest-123",
+		Name:   "security-alerts",
+		Type:   "slack",
+		Target: "https://hooks.slack.com/services/T000/B000/XXXX",
+		Events: []string{"secrets_detected", "domain_blocked"},
+		Repos:  []string{"api"},
+	}, nil)
+
+	r := &githubNotificationDestinationResource{client: mockClient}
+	plan := testNotificationDestinationModel("dest-123")
+	plan.Repos = types.SetValueMust(types.StringType, stringsToAttrValues([]string{"api"}))
+	resp := &resource.UpdateResponse{State: tfsdk.State{Schema: testResourceSchema(t, &githubNotificationDestinationResource{})}}
+	r.Update(ctx, resource.UpdateRequest{
+		Plan:   testResourcePlan(t, &githubNotificationDestinationResource{}, plan),
+		Config: testResourceConfig(t, &githubNotificationDestinationResource{}, plan),
+		State:  testResourceState(t, &githubNotificationDestinationResource{}, testNotificationDestinationModel("dest-123")),
+	}, resp)
+
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+	mockClient.AssertExpectations(t)
+
+	var state githubNotificationDestinationModel
+	require.False(t, resp.State.Get(ctx, &state).HasError())
+	assert.ElementsMatch(t, []string{"secrets_detected", "domain_blocked"}, setStrings(t, state.Events))
+	assert.Equal(t, []string{"api"}, setStrings(t, state.Repos))
+}
+
+func TestGithubNotificationDestinationResource_ReadRemovesDeleted(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mockClient := &stepsecurityapi.MockStepSecurityClient{}
+	mockClient.On("GetNotificationDestination", mock.Anything, "test-org", "dest-123").
+		Return((*stepsecurityapi.NotificationDestination)(nil), fmt.Errorf("failed to get notification destination: %w", &stepsecurityapi.APIError{StatusCode: http.StatusNotFound, Body: "not found"}))
+
+	r := &githubNotificationDestinationResource{client: mockClient}
+	state := tfsdk.State{Schema: testResourceSchema(t, &githubNotificationDestinationResource{})}
+	require.False(t, state.Set(ctx, testNotificationDestinationModel("dest-123")).HasError())
+	resp := &resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, resp)
+
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+	assert.True(t, resp.State.Raw.IsNull())
+}
+
+func TestGithubNotificationDestinationResource_Delete(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mockClient := &stepsecurityapi.MockStepSecurityClient{}
+	mockClient.On("DeleteNotificationDestination", mock.Anything, "test-org", "dest-123").Return(nil)
+
+	r := &githubNotificationDestinationResource{client: mockClient}
+	state := tfsdk.State{Schema: testResourceSchema(t, &githubNotificationDestinationResource{})}
+	require.False(t, state.Set(ctx, testNotificationDestinationModel("dest-123")).HasError())
+	resp := &resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, resp)
+
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGithubNotificationDestinationResource_ImportState(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		importID      string
+		expectedError bool
+	}{
+		{name: "valid", importID: "test-org/dest-123"},
+		{name: "missing_id", importID: "test-org/", expectedError: true},
+		{name: "too_many_parts", importID: "test-org/dest-123/extra", expectedError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			r := &githubNotificationDestinationResource{}
+			schema := testResourceSchema(t, &githubNotificationDestinationResource{})
+			resp := &resource.ImportStateResponse{
+				State: tfsdk.State{Schema: schema, Raw: tftypes.NewValue(schema.Type().TerraformType(ctx), nil)},
+			}
+			r.ImportState(ctx, resource.ImportStateRequest{ID: tc.importID}, resp)
+
+			if tc.expectedError {
+				require.True(t, resp.Diagnostics.HasError())
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+
+			var owner, destinationID types.String
+			resp.State.GetAttribute(ctx, path.Root("owner"), &owner)
+			resp.State.GetAttribute(ctx, path.Root("destination_id"), &destinationID)
+			parts := strings.Split(tc.importID, "/")
+			assert.Equal(t, parts[0], owner.ValueString())
+			assert.Equal(t, parts[1], destinationID.ValueString())
+		})
+	}
+}
+
+func testNotificationDestinationModel(destinationID string) githubNotificationDestinationModel {
+	id := types.StringUnknown()
+	if destinationID != "" {
+		id = types.StringValue(destinationID)
+	}
+	return githubNotificationDestinationModel{
+		DestinationID:          id,
+		Owner:                  types.StringValue("test-org"),
+		Name:                   types.StringValue("security-alerts"),
+		Type:                   types.StringValue("slack"),
+		Target:                 types.StringValue("https://hooks.slack.com/services/T000/B000/XXXX"),
+		Events:                 types.SetValueMust(types.StringType, stringsToAttrValues([]string{"secrets_detected"})),
+		Repos:                  types.SetNull(types.StringType),
+		SigningSecret:          types.StringNull(),
+		SigningSecretWO:        types.StringNull(),
+		SigningSecretWOVersion: types.Int64Null(),
+		Region:                 types.StringNull(),
+	}
+}
@@ -3,11 +3,15 @@ package provider
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"strings"
 
 	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
-	"github.com/step-security/terraform-provider-stepsecurity/internal/utilities"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -15,17 +19,28 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &GithubRepoNotificationSettingsResource{}
-	_ resource.ResourceWithConfigure   = &GithubRepoNotificationSettingsResource{}
-	_ resource.ResourceWithImportState = &GithubRepoNotificationSettingsResource{}
+	_ resource.Resource                 = &GithubRepoNotificationSettingsResource{}
+	_ resource.ResourceWithConfigure    = &GithubRepoNotificationSettingsResource{}
+	_ resource.ResourceWithImportState  = &GithubRepoNotificationSettingsResource{}
+	_ resource.ResourceWithUpgradeState = &GithubRepoNotificationSettingsResource{}
+	_ resource.ResourceWithModifyPlan   = &GithubRepoNotificationSettingsResource{}
 )
 
+// notBlankPattern matches values with at least one non-whitespace character. Blank
+// values were how version 0 of the schema represented an unset channel.
+var notBlankPattern = regexp.MustCompile(`\S`)
+
+// defaultSlackNotificationMethod is used by StepSecurity when no method is set.
+const defaultSlackNotificationMethod = "webhook"
+
 // NewOrderResource is a helper function to simplify the provider implementation.
 func NewGithubRepoNotificationSettingsResource() resource.Resource {
 	return &GithubRepoNotificationSettingsResource{}
@@ -44,6 +59,7 @@ func (r *GithubRepoNotificationSettingsResource) Metadata(_ context.Context, req
 // Schema defines the schema for the resource.
 func (r *GithubRepoNotificationSettingsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
@@ -56,38 +72,82 @@ func (r *GithubRepoNotificationSettingsResource) Schema(_ context.Context, _ res
 				Required:    true,
 				Description: "The owner/organization name.",
 			},
+			"verify_on_apply": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to send a test message to every configured channel when the settings are created or updated. The settings are saved even when a channel doesn't receive it; the delivery error is reported as a warning and verification_pending is set, so the next plan retries the test until every channel receives it.",
+			},
+			"verification_pending": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the test message sent for verify_on_apply has not yet reached every configured channel. While it is true and verify_on_apply is set, every plan includes an update that sends the test message again.",
+			},
 			"notification_channels": schema.SingleNestedAttribute{
 				Required: true,
 				Attributes: map[string]schema.Attribute{
 					"slack_webhook_url": schema.StringAttribute{
 						Optional:    true,
-						Computed:    true,
-						Description: "The Slack webhook URL to receive notifications. If not provided, no notifications will be sent to Slack.",
-						Default:     stringdefault.StaticString(" "),
+						Sensitive:   true,
+						Description: "The Slack webhook URL to receive notifications. If not provided, no notifications will be sent to Slack. The URL grants access to post to the channel; prefer slack_webhook_url_wo, which keeps it out of the state.",
+						Validators: append(notBlankValidators(),
+							stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("slack_webhook_url_wo")),
+							stringvalidator.PreferWriteOnlyAttribute(path.MatchRelative().AtParent().AtName("slack_webhook_url_wo")),
+						),
+					},
+					"slack_webhook_url_wo": schema.StringAttribute{
+						Optional:    true,
+						Sensitive:   true,
+						WriteOnly:   true,
+						Description: "Write-only variant of slack_webhook_url that is never stored in the plan or state. Requires Terraform 1.11 or later and slack_webhook_url_wo_version.",
+						Validators: append(notBlankValidators(),
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("slack_webhook_url_wo_version")),
+						),
+					},
+					"slack_webhook_url_wo_version": schema.Int64Attribute{
+						Optional:    true,
+						Description: "The version of slack_webhook_url_wo. As the URL is not stored, Terraform can't detect when it changes; change the version to send the new URL.",
+						Validators: []validator.Int64{
+							int64validator.AlsoRequires(path.MatchRelative().AtParent().AtName("slack_webhook_url_wo")),
+						},
 					},
 					"teams_webhook_url": schema.StringAttribute{
 						Optional:    true,
-						Computed:    true,
-						Description: "The Microsoft Teams webhook URL to receive notifications. If not provided, no notifications will be sent to Microsoft Teams.",
-						Default:     stringdefault.StaticString(" "),
+						Sensitive:   true,
+						Description: "The Microsoft Teams webhook URL to receive notifications. If not provided, no notifications will be sent to Microsoft Teams. The URL grants access to post to the channel; prefer teams_webhook_url_wo, which keeps it out of the state.",
+						Validators: append(notBlankValidators(),
+							stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("teams_webhook_url_wo")),
+							stringvalidator.PreferWriteOnlyAttribute(path.MatchRelative().AtParent().AtName("teams_webhook_url_wo")),
+						),
+					},
+					"teams_webhook_url_wo": schema.StringAttribute{
+						Optional:    true,
+						Sensitive:   true,
+						WriteOnly:   true,
+						Description: "Write-only variant of teams_webhook_url that is never stored in the plan or state. Requires Terraform 1.11 or later and teams_webhook_url_wo_version.",
+						Validators: append(notBlankValidators(),
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("teams_webhook_url_wo_version")),
+						),
+					},
+					"teams_webhook_url_wo_version": schema.Int64Attribute{
+						Optional:    true,
+						Description: "The version of teams_webhook_url_wo. As the URL is not stored, Terraform can't detect when it changes; change the version to send the new URL.",
+						Validators: []validator.Int64{
+							int64validator.AlsoRequires(path.MatchRelative().AtParent().AtName("teams_webhook_url_wo")),
+						},
 					},
 					"email": schema.StringAttribute{
 						Optional:    true,
-						Computed:    true,
 						Description: "The email address to receive notifications. If not provided, no notifications will be sent to the email address.",
-						Default:     stringdefault.StaticString(" "),
+						Validators:  notBlankValidators(),
 					},
 					"slack_channel_id": schema.StringAttribute{
 						Optional:    true,
-						Computed:    true,
 						Description: "The Slack channel ID to post notifications to when using OAuth method. Required when slack_notification_method is 'oauth'.",
-						Default:     stringdefault.StaticString(" "),
+						Validators:  notBlankValidators(),
 					},
 					"slack_notification_method": schema.StringAttribute{
 						Optional:    true,
 						Computed:    true,
 						Description: "The method to use for sending Slack notifications. Valid values are 'webhook' (default) or 'oauth'.",
-						Default:     stringdefault.StaticString("webhook"),
+						Default:     stringdefault.StaticString(defaultSlackNotificationMethod),
 					},
 				},
 			},
@@ -238,16 +298,22 @@ func (r *GithubRepoNotificationSettingsResource) ImportState(ctx context.Context
 type githubNotificationSettingsModel struct {
 	ID                   types.String `tfsdk:"id"`
 	Owner                types.String `tfsdk:"owner"`
+	VerifyOnApply        types.Bool   `tfsdk:"verify_on_apply"`
+	VerificationPending  types.Bool   `tfsdk:"verification_pending"`
 	NotificationChannels types.Object `tfsdk:"notification_channels"`
 	NotificationEvents   types.Object `tfsdk:"notification_events"`
 }
 
 type githubNotificationChannelsModel struct {
-	SlackWebhookURL         types.String `tfsdk:"slack_webhook_url"`
-	TeamsWebhookURL         types.String `tfsdk:"teams_webhook_url"`
-	Email                   types.String `tfsdk:"email"`
-	SlackChannelID          types.String `tfsdk:"slack_channel_id"`
-	SlackNotificationMethod types.String `tfsdk:"slack_notification_method"`
+	SlackWebhookURL          types.String `tfsdk:"slack_webhook_url"`
+	SlackWebhookURLWO        types.String `tfsdk:"slack_webhook_url_wo"`
+	SlackWebhookURLWOVersion types.Int64  `tfsdk:"slack_webhook_url_wo_version"`
+	TeamsWebhookURL          types.String `tfsdk:"teams_webhook_url"`
+	TeamsWebhookURLWO        types.String `tfsdk:"teams_webhook_url_wo"`
+	TeamsWebhookURLWOVersion types.Int64  `tfsdk:"teams_webhook_url_wo_version"`
+	Email                    types.String `tfsdk:"email"`
+	SlackChannelID           types.String `tfsdk:"slack_channel_id"`
+	SlackNotificationMethod  types.String `tfsdk:"slack_notification_method"`
 }
 
 type githubNotificationEventsModel struct {
@@ -277,46 +343,15 @@ func (r *GithubRepoNotificationSettingsResource) Create(ctx context.Context, req
 		return
 	}
 
-	// Extract notification channels
-	var channels githubNotificationChannelsModel
-	diags = plan.NotificationChannels.As(ctx, &channels, basetypes.ObjectAsOptions{})
+	settings, diags := notificationSettingsFromModel(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(applyWriteOnlyWebhookURLs(ctx, req.Config, &settings)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-
-	// Extract notification events
-	var events githubNotificationEventsModel
-	diags = plan.NotificationEvents.As(ctx, &events, basetypes.ObjectAsOptions{})
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
 	request := stepsecurityapi.GitHubNotificationSettingsRequest{
-		Owner: plan.Owner.ValueString(),
-		NotificationSettings: stepsecurityapi.NotificationSettings{
-			SlackWebhookURL:                   channels.SlackWebhookURL.ValueString(),
-			TeamsWebhookURL:                   channels.TeamsWebhookURL.ValueString(),
-			Email:                             channels.Email.ValueString(),
-			SlackChannelID:                    channels.SlackChannelID.ValueString(),
-			SlackNotificationMethod:           channels.SlackNotificationMethod.ValueString(),
-			NotifyWhenDomainBlocked:           utilities.ConvertBoolToString(events.DomainBlocked.ValueBool()),
-			NotifyOnFileOverwrite:             utilities.ConvertBoolToString(events.FileOverwrite.ValueBool()),
-			NotifyWhenEndpointDiscovered:      utilities.ConvertBoolToString(events.NewEndpointDiscovered.ValueBool()),
-			NotifyForHttpsDetections:          utilities.ConvertBoolToString(events.HttpsDetections.ValueBool()),
-			NotifyForSecretsDetection:         utilities.ConvertBoolToString(events.SecretsDetected.ValueBool()),
-			NotifyForArtifactSecretsDetection: utilities.ConvertBoolToString(events.ArtifactsSecretsDetected.ValueBool()),
-			NotifyForImposterCommitsDetection: utilities.ConvertBoolToString(events.ImposterCommitsDetected.ValueBool()),
-			NotifyForSuspiciousNetworkCall:    utilities.ConvertBoolToString(events.SuspiciousNetworkCallDetected.ValueBool()),
-			NotifyForSuspiciousProcessEvents:  utilities.ConvertBoolToString(events.SuspiciousProcessEventsDetected.ValueBool()),
-			NotifyForHardenRunnerConfigChange: utilities.ConvertBoolToString(events.HardenRunnerConfigChangesDetected.ValueBool()),
-			NotifyForNonCompliantArtifacts:    utilities.ConvertBoolToString(events.NonCompliantArtifactDetected.ValueBool()),
-			NotifyForBlockedRunPolicy:         utilities.ConvertBoolToString(events.RunBlockedByPolicy.ValueBool()),
-			NotifyForBaselineCheckFailures:    utilities.ConvertBoolToString(events.BaselineCheckFailures.ValueBool()),
-			NotifyForRequiredCheckFailures:    utilities.ConvertBoolToString(events.RequiredCheckFailures.ValueBool()),
-			NotifyForOptionalCheckFailures:    utilities.ConvertBoolToString(events.OptionalCheckFailures.ValueBool()),
-		},
+		Owner:                plan.Owner.ValueString(),
+		NotificationSettings: settings,
 	}
 
 	// Create notification settings in StepSecurity
@@ -332,6 +367,15 @@ func (r *GithubRepoNotificationSettingsResource) Create(ctx context.Context, req
 	// Set the ID (use owner as the unique identifier)
 	plan.ID = types.StringValue(plan.Owner.ValueString())
 
+	// The settings are saved, so a failed test notification is only remembered and
+	// retried on the next apply.
+	plan.VerificationPending = types.BoolValue(false)
+	if plan.VerifyOnApply.ValueBool() {
+		delivered, verifyDiags := r.verifyNotificationDelivery(ctx, plan.Owner.ValueString())
+		resp.Diagnostics.Append(verifyDiags...)
+		plan.VerificationPending = types.BoolValue(!delivered)
+	}
+
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -362,64 +406,17 @@ func (r *GithubRepoNotificationSettingsResource) Read(ctx context.Context, req r
 
 	// Update state with latest data
 	state.ID = types.StringValue(state.Owner.ValueString())
+	if state.VerificationPending.IsNull() {
+		state.VerificationPending = types.BoolValue(false)
+	}
 
-	// Create notification channels object
-	channelsObj, _ := types.ObjectValue(
-		map[string]attr.Type{
-			"slack_webhook_url":         types.StringType,
-			"teams_webhook_url":         types.StringType,
-			"email":                     types.StringType,
-			"slack_channel_id":          types.StringType,
-			"slack_notification_method": types.StringType,
-		},
-		map[string]attr.Value{
-			"slack_webhook_url":         types.StringValue(settings.SlackWebhookURL),
-			"teams_webhook_url":         types.StringValue(settings.TeamsWebhookURL),
-			"email":                     types.StringValue(settings.Email),
-			"slack_channel_id":          types.StringValue(settings.SlackChannelID),
-			"slack_notification_method": types.StringValue(settings.SlackNotificationMethod),
-		},
-	)
-	state.NotificationChannels = channelsObj
-
-	// Create notification events object
-	eventsObj, _ := types.ObjectValue(
-		map[string]attr.Type{
-			"domain_blocked":                        types.BoolType,
-			"file_overwrite":                        types.BoolType,
-			"new_endpoint_discovered":               types.BoolType,
-			"https_detections":                      types.BoolType,
-			"secrets_detected":                      types.BoolType,
-			"artifacts_secrets_detected":            types.BoolType,
-			"imposter_commits_detected":             types.BoolType,
-			"suspicious_network_call_detected":      types.BoolType,
-			"suspicious_process_events_detected":    types.BoolType,
-			"harden_runner_config_changes_detected": types.BoolType,
-			"non_compliant_artifact_detected":       types.BoolType,
-			"run_blocked_by_policy":                 types.BoolType,
-			"baseline_check_failures":               types.BoolType,
-			"required_check_failures":               types.BoolType,
-			"optional_check_failures":               types.BoolType,
-		},
-		map[string]attr.Value{
-			"domain_blocked":                        types.BoolValue(utilities.ConvertStringToBool(settings.NotifyWhenDomainBlocked)),
-			"file_overwrite":                        types.BoolValue(utilities.ConvertStringToBool(settings.NotifyOnFileOverwrite)),
-			"new_endpoint_discovered":               types.BoolValue(utilities.ConvertStringToBool(settings.NotifyWhenEndpointDiscovered)),
-			"https_detections":                      types.BoolValue(utilities.ConvertStringToBool(settings.NotifyForHttpsDetections)),
-			"secrets_detected":                      types.BoolValue(utilities.ConvertStringToBool(settings.NotifyForSecretsDetection)),
-			"artifacts_secrets_detected":            types.BoolValue(utilities.ConvertStringToBool(settings.NotifyForArtifactSecretsDetection)),
-			"imposter_commits_detected":             types.BoolValue(utilities.ConvertStringToBool(settings.NotifyForImposterCommitsDetection)),
-			"suspicious_network_call_detected":      types.BoolValue(utilities.ConvertStringToBool(settings.NotifyForSuspiciousNetworkCall)),
-			"suspicious_process_events_detected":    types.BoolValue(utilities.ConvertStringToBool(settings.NotifyForSuspiciousProcessEvents)),
-			"harden_runner_config_changes_detected": types.BoolValue(utilities.ConvertStringToBool(settings.NotifyForHardenRunnerConfigChange)),
-			"non_compliant_artifact_detected":       types.BoolValue(utilities.ConvertStringToBool(settings.NotifyForNonCompliantArtifacts)),
-			"run_blocked_by_policy":                 types.BoolValue(utilities.ConvertStringToBool(settings.NotifyForBlockedRunPolicy)),
-			"baseline_check_failures":               types.BoolValue(utilities.ConvertStringToBool(settings.NotifyForBaselineCheckFailures)),
-			"required_check_failures":               types.BoolValue(utilities.ConvertStringToBool(settings.NotifyForRequiredCheckFailures)),
-			"optional_check_failures":               types.BoolValue(utilities.ConvertStringToBool(settings.NotifyForOptionalCheckFailures)),
-		},
-	)
-	state.NotificationEvents = eventsObj
+	var priorChannels githubNotificationChannelsModel
+	resp.Diagnostics.Append(state.NotificationChannels.As(ctx, &priorChannels, basetypes.ObjectAsOptions{UnhandledNullAsEmpty: true})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.NotificationChannels, state.NotificationEvents = notificationSettingsObjects(settings, priorChannels)
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, state)
@@ -438,46 +435,15 @@ func (r *GithubRepoNotificationSettingsResource) Update(ctx context.Context, req
 		return
 	}
 
-	// Extract notification channels
-	var channels githubNotificationChannelsModel
-	diags = plan.NotificationChannels.As(ctx, &channels, basetypes.ObjectAsOptions{})
+	settings, diags := notificationSettingsFromModel(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(applyWriteOnlyWebhookURLs(ctx, req.Config, &settings)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-
-	// Extract notification events
-	var events githubNotificationEventsModel
-	diags = plan.NotificationEvents.As(ctx, &events, basetypes.ObjectAsOptions{})
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
 	request := stepsecurityapi.GitHubNotificationSettingsRequest{
-		Owner: plan.Owner.ValueString(),
-		NotificationSettings: stepsecurityapi.NotificationSettings{
-			SlackWebhookURL:                   channels.SlackWebhookURL.ValueString(),
-			TeamsWebhookURL:                   channels.TeamsWebhookURL.ValueString(),
-			Email:                             channels.Email.ValueString(),
-			SlackChannelID:                    channels.SlackChannelID.ValueString(),
-			SlackNotificationMethod:           channels.SlackNotificationMethod.ValueString(),
-			NotifyWhenDomainBlocked:           utilities.ConvertBoolToString(events.DomainBlocked.ValueBool()),
-			NotifyOnFileOverwrite:             utilities.ConvertBoolToString(events.FileOverwrite.ValueBool()),
-			NotifyWhenEndpointDiscovered:      utilities.ConvertBoolToString(events.NewEndpointDiscovered.ValueBool()),
-			NotifyForHttpsDetections:          utilities.ConvertBoolToString(events.HttpsDetections.ValueBool()),
-			NotifyForSecretsDetection:         utilities.ConvertBoolToString(events.SecretsDetected.ValueBool()),
-			NotifyForArtifactSecretsDetection: utilities.ConvertBoolToString(events.ArtifactsSecretsDetected.ValueBool()),
-			NotifyForImposterCommitsDetection: utilities.ConvertBoolToString(events.ImposterCommitsDetected.ValueBool()),
-			NotifyForSuspiciousNetworkCall:    utilities.ConvertBoolToString(events.SuspiciousNetworkCallDetected.ValueBool()),
-			NotifyForSuspiciousProcessEvents:  utilities.ConvertBoolToString(events.SuspiciousProcessEventsDetected.ValueBool()),
-			NotifyForHardenRunnerConfigChange: utilities.ConvertBoolToString(events.HardenRunnerConfigChangesDetected.ValueBool()),
-			NotifyForNonCompliantArtifacts:    utilities.ConvertBoolToString(events.NonCompliantArtifactDetected.ValueBool()),
-			NotifyForBlockedRunPolicy:         utilities.ConvertBoolToString(events.RunBlockedByPolicy.ValueBool()),
-			NotifyForBaselineCheckFailures:    utilities.ConvertBoolToString(events.BaselineCheckFailures.ValueBool()),
-			NotifyForRequiredCheckFailures:    utilities.ConvertBoolToString(events.RequiredCheckFailures.ValueBool()),
-			NotifyForOptionalCheckFailures:    utilities.ConvertBoolToString(events.OptionalCheckFailures.ValueBool()),
-		},
+		Owner:                plan.Owner.ValueString(),
+		NotificationSettings: settings,
 	}
 
 	// Update notification settings in StepSecurity
@@ -493,6 +459,15 @@ func (r *GithubRepoNotificationSettingsResource) Update(ctx context.Context, req
 	// Set the ID (use owner as the unique identifier)
 	plan.ID = types.StringValue(plan.Owner.ValueString())
 
+	// The settings are saved, so a failed test notification is only remembered and
+	// retried on the next apply.
+	plan.VerificationPending = types.BoolValue(false)
+	if plan.VerifyOnApply.ValueBool() {
+		delivered, verifyDiags := r.verifyNotificationDelivery(ctx, plan.Owner.ValueString())
+		resp.Diagnostics.Append(verifyDiags...)
+		plan.VerificationPending = types.BoolValue(!delivered)
+	}
+
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -520,3 +495,295 @@ func (r *GithubRepoNotificationSettingsResource) Delete(ctx context.Context, req
 		return
 	}
 }
+
+// ModifyPlan plans an update while a test notification sent for verify_on_apply is
+// still pending, so that the next apply sends it again.
+func (r *GithubRepoNotificationSettingsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to retry on create or destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var state, plan githubNotificationSettingsModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.VerificationPending.ValueBool() && plan.VerifyOnApply.ValueBool() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("verification_pending"), types.BoolUnknown())...)
+	}
+}
+
+// verifyNotificationDelivery sends a test notification to the owner's channels and
+// reports whether every channel received it. The settings are already saved at this
+// point, so channels that didn't receive it are reported as warnings.
+func (r *GithubRepoNotificationSettingsResource) verifyNotificationDelivery(ctx context.Context, owner string) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	results, err := r.client.SendTestNotification(ctx, owner)
+	if err != nil {
+		diags.AddAttributeWarning(
+			path.Root("verify_on_apply"),
+			"Unable to Send Test Notification",
+			fmt.Sprintf("The notification settings of %s were saved, but the test notification could not be sent and will be retried on the next apply: %s", owner, err),
+		)
+		return false, diags
+	}
+
+	if len(results) == 0 {
+		diags.AddAttributeWarning(
+			path.Root("verify_on_apply"),
+			"No Notification Channel to Verify",
+			fmt.Sprintf("No notification channel is configured for %s, so no test notification was sent.", owner),
+		)
+	}
+	delivered := true
+	for _, result := range results {
+		if result.Delivered {
+			continue
+		}
+		delivered = false
+		diags.AddAttributeWarning(
+			path.Root("notification_channels"),
+			"Test Notification Not Delivered",
+			fmt.Sprintf("The test notification to the %s channel of %s was not delivered and will be retried on the next apply: %s", result.Channel, owner, result.Error),
+		)
+	}
+	return delivered, diags
+}
+
+// notificationSettingsFromModel converts the resource model to the API settings.
+func notificationSettingsFromModel(ctx context.Context, model githubNotificationSettingsModel) (stepsecurityapi.NotificationSettings, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var channels githubNotificationChannelsModel
+	diags.Append(model.NotificationChannels.As(ctx, &channels, basetypes.ObjectAsOptions{})...)
+	var events githubNotificationEventsModel
+	diags.Append(model.NotificationEvents.As(ctx, &events, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return stepsecurityapi.NotificationSettings{}, diags
+	}
+
+	return stepsecurityapi.NotificationSettings{
+		SlackWebhookURL:                   channels.SlackWebhookURL.ValueStringPointer(),
+		TeamsWebhookURL:                   channels.TeamsWebhookURL.ValueStringPointer(),
+		Email:                             channels.Email.ValueStringPointer(),
+		SlackChannelID:                    channels.SlackChannelID.ValueStringPointer(),
+		SlackNotificationMethod:           channels.SlackNotificationMethod.ValueStringPointer(),
+		NotifyWhenDomainBlocked:           events.DomainBlocked.ValueBoolPointer(),
+		NotifyOnFileOverwrite:             events.FileOverwrite.ValueBoolPointer(),
+		NotifyWhenEndpointDiscovered:      events.NewEndpointDiscovered.ValueBoolPointer(),
+		NotifyForHttpsDetections:          events.HttpsDetections.ValueBoolPointer(),
+		NotifyForSecretsDetection:         events.SecretsDetected.ValueBoolPointer(),
+		NotifyForArtifactSecretsDetection: events.ArtifactsSecretsDetected.ValueBoolPointer(),
+		NotifyForImposterCommitsDetection: events.ImposterCommitsDetected.ValueBoolPointer(),
+		NotifyForSuspiciousNetworkCall:    events.SuspiciousNetworkCallDetected.ValueBoolPointer(),
+		NotifyForSuspiciousProcessEvents:  events.SuspiciousProcessEventsDetected.ValueBoolPointer(),
+		NotifyForHardenRunnerConfigChange: events.HardenRunnerConfigChangesDetected.ValueBoolPointer(),
+		NotifyForNonCompliantArtifacts:    events.NonCompliantArtifactDetected.ValueBoolPointer(),
+		NotifyForBlockedRunPolicy:         events.RunBlockedByPolicy.ValueBoolPointer(),
+		NotifyForBaselineCheckFailures:    events.BaselineCheckFailures.ValueBoolPointer(),
+		NotifyForRequiredCheckFailures:    events.RequiredCheckFailures.ValueBoolPointer(),
+		NotifyForOptionalCheckFailures:    events.OptionalCheckFailures.ValueBoolPointer(),
+	}, diags
+}
+
+// notificationSettingsObjects converts the API settings to the notification_channels
+// and notification_events objects. Channels that are not configured are null and
+// events that are not configured are off. A webhook URL managed through its write-only
+// attribute, as recorded by the version in prior, is left out.
+func notificationSettingsObjects(settings *stepsecurityapi.NotificationSettings, prior githubNotificationChannelsModel) (types.Object, types.Object) {
+	slackNotificationMethod := types.StringValue(defaultSlackNotificationMethod)
+	if settings.SlackNotificationMethod != nil {
+		slackNotificationMethod = types.StringValue(*settings.SlackNotificationMethod)
+	}
+	slackWebhookURL := types.StringPointerValue(settings.SlackWebhookURL)
+	if !prior.SlackWebhookURLWOVersion.IsNull() {
+		slackWebhookURL = types.StringNull()
+	}
+	teamsWebhookURL := types.StringPointerValue(settings.TeamsWebhookURL)
+	if !prior.TeamsWebhookURLWOVersion.IsNull() {
+		teamsWebhookURL = types.StringNull()
+	}
+
+	channels := types.ObjectValueMust(notificationChannelsAttrTypes(), map[string]attr.Value{
+		"slack_webhook_url":            slackWebhookURL,
+		"slack_webhook_url_wo":         types.StringNull(),
+		"slack_webhook_url_wo_version": prior.SlackWebhookURLWOVersion,
+		"teams_webhook_url":            teamsWebhookURL,
+		"teams_webhook_url_wo":         types.StringNull(),
+		"teams_webhook_url_wo_version": prior.TeamsWebhookURLWOVersion,
+		"email":                        types.StringPointerValue(settings.Email),
+		"slack_channel_id":             types.StringPointerValue(settings.SlackChannelID),
+		"slack_notification_method":    slackNotificationMethod,
+	})
+
+	events := types.ObjectValueMust(notificationEventsAttrTypes(), map[string]attr.Value{
+		"domain_blocked":                        notificationEventValue(settings.NotifyWhenDomainBlocked),
+		"file_overwrite":                        notificationEventValue(settings.NotifyOnFileOverwrite),
+		"new_endpoint_discovered":               notificationEventValue(settings.NotifyWhenEndpointDiscovered),
+		"https_detections":                      notificationEventValue(settings.NotifyForHttpsDetections),
+		"secrets_detected":                      notificationEventValue(settings.NotifyForSecretsDetection),
+		"artifacts_secrets_detected":            notificationEventValue(settings.NotifyForArtifactSecretsDetection),
+		"imposter_commits_detected":             notificationEventValue(settings.NotifyForImposterCommitsDetection),
+		"suspicious_network_call_detected":      notificationEventValue(settings.NotifyForSuspiciousNetworkCall),
+		"suspicious_process_events_detected":    notificationEventValue(settings.NotifyForSuspiciousProcessEvents),
+		"harden_runner_config_changes_detected": notificationEventValue(settings.NotifyForHardenRunnerConfigChange),
+		"non_compliant_artifact_detected":       notificationEventValue(settings.NotifyForNonCompliantArtifacts),
+		"run_blocked_by_policy":                 notificationEventValue(settings.NotifyForBlockedRunPolicy),
+		"baseline_check_failures":               notificationEventValue(settings.NotifyForBaselineCheckFailures),
+		"required_check_failures":               notificationEventValue(settings.NotifyForRequiredCheckFailures),
+		"optional_check_failures":               notificationEventValue(settings.NotifyForOptionalCheckFailures),
+	})
+
+	return channels, events
+}
+
+func notificationEventValue(enabled *bool) types.Bool {
+	return types.BoolValue(enabled != nil && *enabled)
+}
+
+// applyWriteOnlyWebhookURLs sets the webhook URLs configured through write-only
+// attributes, which are only available in config. They are sent on every create and
+// update, so the settings never lose a URL Terraform doesn't know.
+func applyWriteOnlyWebhookURLs(ctx context.Context, config tfsdk.Config, settings *stepsecurityapi.NotificationSettings) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var slackWebhookURL, teamsWebhookURL types.String
+	diags.Append(config.GetAttribute(ctx, path.Root("notification_channels").AtName("slack_webhook_url_wo"), &slackWebhookURL)...)
+	diags.Append(config.GetAttribute(ctx, path.Root("notification_channels").AtName("teams_webhook_url_wo"), &teamsWebhookURL)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	if !slackWebhookURL.IsNull() && !slackWebhookURL.IsUnknown() {
+		settings.SlackWebhookURL = slackWebhookURL.ValueStringPointer()
+	}
+	if !teamsWebhookURL.IsNull() && !teamsWebhookURL.IsUnknown() {
+		settings.TeamsWebhookURL = teamsWebhookURL.ValueStringPointer()
+	}
+	return diags
+}
+
+func notificationChannelsAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"slack_webhook_url":            types.StringType,
+		"slack_webhook_url_wo":         types.StringType,
+		"slack_webhook_url_wo_version": types.Int64Type,
+		"teams_webhook_url":            types.StringType,
+		"teams_webhook_url_wo":         types.StringType,
+		"teams_webhook_url_wo_version": types.Int64Type,
+		"email":                        types.StringType,
+		"slack_channel_id":             types.StringType,
+		"slack_notification_method":    types.StringType,
+	}
+}
+
+// notificationEventsAttrTypes returns the attribute types of notification_events,
+// which has a bool attribute per notification event.
+func notificationEventsAttrTypes() map[string]attr.Type {
+	attrTypes := make(map[string]attr.Type, len(stepsecurityapi.NotificationEvents))
+	for _, event := range stepsecurityapi.NotificationEvents {
+		attrTypes[event] = types.BoolType
+	}
+	return attrTypes
+}
+
+func notBlankValidators() []validator.String {
+	return []validator.String{
+		stringvalidator.RegexMatches(notBlankPattern, "must not be blank; omit the attribute to leave the channel unconfigured"),
+	}
+}
+
+// UpgradeState upgrades state from earlier schema versions.
+func (r *GithubRepoNotificationSettingsResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 stored unset channels as a single space.
+		0: {
+			PriorSchema:   notificationSettingsSchemaV0(),
+			StateUpgrader: upgradeNotificationSettingsStateV0,
+		},
+	}
+}
+
+func notificationSettingsSchemaV0() *schema.Schema {
+	eventAttributes := make(map[string]schema.Attribute, len(stepsecurityapi.NotificationEvents))
+	for _, event := range stepsecurityapi.NotificationEvents {
+		eventAttributes[event] = schema.BoolAttribute{Optional: true, Computed: true}
+	}
+
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":    schema.StringAttribute{Computed: true},
+			"owner": schema.StringAttribute{Required: true},
+			"notification_channels": schema.SingleNestedAttribute{
+				Required: true,
+				Attributes: map[string]schema.Attribute{
+					"slack_webhook_url":         schema.StringAttribute{Optional: true, Computed: true},
+					"teams_webhook_url":         schema.StringAttribute{Optional: true, Computed: true},
+					"email":                     schema.StringAttribute{Optional: true, Computed: true},
+					"slack_channel_id":          schema.StringAttribute{Optional: true, Computed: true},
+					"slack_notification_method": schema.StringAttribute{Optional: true, Computed: true},
+				},
+			},
+			"notification_events": schema.SingleNestedAttribute{
+				Required:   true,
+				Attributes: eventAttributes,
+			},
+		},
+	}
+}
+
+type githubNotificationSettingsModelV0 struct {
+	ID                   types.String `tfsdk:"id"`
+	Owner                types.String `tfsdk:"owner"`
+	NotificationChannels types.Object `tfsdk:"notification_channels"`
+	NotificationEvents   types.Object `tfsdk:"notification_events"`
+}
+
+// upgradeNotificationSettingsStateV0 replaces the blank values version 0 stored for
+// unset channels with null.
+func upgradeNotificationSettingsStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior githubNotificationSettingsModelV0
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state := githubNotificationSettingsModel{
+		ID:                   prior.ID,
+		Owner:                prior.Owner,
+		VerifyOnApply:        types.BoolNull(),
+		VerificationPending:  types.BoolValue(false),
+		NotificationChannels: prior.NotificationChannels,
+		NotificationEvents:   prior.NotificationEvents,
+	}
+
+	channels := state.NotificationChannels.Attributes()
+	unsetBlank := func(name string) types.String {
+		value, ok := channels[name].(types.String)
+		if !ok || value.IsNull() || value.IsUnknown() || strings.TrimSpace(value.ValueString()) == "" {
+			return types.StringNull()
+		}
+		return value
+	}
+	slackNotificationMethod := unsetBlank("slack_notification_method")
+	if slackNotificationMethod.IsNull() {
+		slackNotificationMethod = types.StringValue(defaultSlackNotificationMethod)
+	}
+
+	state.NotificationChannels = types.ObjectValueMust(notificationChannelsAttrTypes(), map[string]attr.Value{
+		"slack_webhook_url":            unsetBlank("slack_webhook_url"),
+		"slack_webhook_url_wo":         types.StringNull(),
+		"slack_webhook_url_wo_version": types.Int64Null(),
+		"teams_webhook_url":            unsetBlank("teams_webhook_url"),
+		"teams_webhook_url_wo":         types.StringNull(),
+		"teams_webhook_url_wo_version": types.Int64Null(),
+		"email":                        unsetBlank("email"),
+		"slack_channel_id":             unsetBlank("slack_channel_id"),
+		"slack_notification_method":    slackNotificationMethod,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
@@ -8,9 +8,15 @@ import (
 
 	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	res "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAccGithubRepoNotificationSettingsResource(t *testing.T) {
@@ -258,8 +264,8 @@ func TestGithubNotificationResource_ClientInteraction(t *testing.T) {
 		{
 			name: "successful_get",
 			mockResponse: &stepsecurityapi.NotificationSettings{
-				SlackWebhookURL: "https://hooks.slack.com/test",
-				Email:           "admin@example.com",
+				SlackWebhookURL: stringPtr("https://hooks.slack.com/test"),
+				Email:           stringPtr("admin@example.com"),
 			},
 			mockError:     nil,
 			expectedError: false,
@@ -295,8 +301,8 @@ func TestGithubNotificationResource_ClientInteraction(t *testing.T) {
 
 				if settings == nil {
 					t.Error("Expected settings but got nil")
-				} else if settings.SlackWebhookURL != "https://hooks.slack.com/test" {
-					t.Errorf("Expected slack webhook 'https://hooks.slack.com/test', got '%s'", settings.SlackWebhookURL)
+				} else if settings.SlackWebhookURL == nil || *settings.SlackWebhookURL != "https://hooks.slack.com/test" {
+					t.Errorf("Expected slack webhook 'https://hooks.slack.com/test', got %v", settings.SlackWebhookURL)
 				}
 			}
 
@@ -317,29 +323,29 @@ func TestGithubNotificationResource_NotificationSettingsValidation(t *testing.T)
 		{
 			name: "valid_slack_only",
 			settings: stepsecurityapi.NotificationSettings{
-				SlackWebhookURL: "https://hooks.slack.com/test",
+				SlackWebhookURL: stringPtr("https://hooks.slack.com/test"),
 			},
 			valid: true,
 		},
 		{
 			name: "valid_teams_only",
 			settings: stepsecurityapi.NotificationSettings{
-				TeamsWebhookURL: "https://outlook.office.com/webhook/test",
+				TeamsWebhookURL: stringPtr("https://outlook.office.com/webhook/test"),
 			},
 			valid: true,
 		},
 		{
 			name: "valid_email_only",
 			settings: stepsecurityapi.NotificationSettings{
-				Email: "admin@example.com",
+				Email: stringPtr("admin@example.com"),
 			},
 			valid: true,
 		},
 		{
 			name: "valid_multiple_channels",
 			settings: stepsecurityapi.NotificationSettings{
-				SlackWebhookURL: "https://hooks.slack.com/test",
-				Email:           "admin@example.com",
+				SlackWebhookURL: stringPtr("https://hooks.slack.com/test"),
+				Email:           stringPtr("admin@example.com"),
 			},
 			valid: true,
 		},
@@ -364,6 +370,298 @@ func TestGithubNotificationResource_NotificationSettingsValidation(t *testing.T)
 	}
 }
 
+func TestGithubNotificationResource_ReadUnsetChannelsAreNull(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	enabled := true
+	mockClient := &stepsecurityapi.MockStepSecurityClient{}
+	mockClient.On("GetNotificationSettings", mock.Anything, "tf-acc-test").Return(&stepsecurityapi.NotificationSettings{
+		Email:                     stringPtr("admin@example.com"),
+		NotifyForSecretsDetection: &enabled,
+	}, nil)
+
+	r := &GithubRepoNotificationSettingsResource{client: mockClient}
+	state := testNotificationSettingsState(t, map[string]attr.Value{"email": types.StringValue("admin@example.com")})
+	resp := &resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, resp)
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+
+	var model githubNotificationSettingsModel
+	require.False(t, resp.State.Get(ctx, &model).HasError())
+	channels := model.NotificationChannels.Attributes()
+	assert.Equal(t, types.StringValue("admin@example.com"), channels["email"])
+	assert.True(t, channels["slack_webhook_url"].IsNull())
+	assert.True(t, channels["teams_webhook_url"].IsNull())
+	assert.True(t, channels["slack_channel_id"].IsNull())
+	assert.Equal(t, types.StringValue("webhook"), channels["slack_notification_method"])
+	events := model.NotificationEvents.Attributes()
+	assert.Equal(t, types.BoolValue(true), events["secrets_detected"])
+	assert.Equal(t, types.BoolValue(false), events["domain_blocked"])
+}
+
+func TestGithubNotificationResource_CreateSendsUnsetChannelsAsNil(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mockClient := &stepsecurityapi.MockStepSecurityClient{}
+	mockClient.On("CreateNotificationSettings", mock.Anything, mock.MatchedBy(func(req stepsecurityapi.GitHubNotificationSettingsRequest) bool {
+		return req.Owner == "tf-acc-test" &&
+			req.Email != nil && *req.Email == "admin@example.com" &&
+			req.SlackWebhookURL == nil && req.TeamsWebhookURL == nil && req.SlackChannelID == nil &&
+			req.NotifyForSecretsDetection != nil && !*req.NotifyForSecretsDetection
+	})).Return(nil)
+
+	r := &GithubRepoNotificationSettingsResource{client: mockClient}
+	state := testNotificationSettingsState(t, map[string]attr.Value{"email": types.StringValue("admin@example.com")})
+	plan := tfsdk.Plan{Schema: state.Schema, Raw: state.Raw}
+	config := tfsdk.Config{Schema: state.Schema, Raw: state.Raw}
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: state.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan, Config: config}, resp)
+
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGithubNotificationResource_VerifyOnApply(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name            string
+		results         []stepsecurityapi.NotificationTestResult
+		sendErr         error
+		expectedPending bool
+		expectedWarning string
+	}{
+		{
+			name: "delivered",
+			results: []stepsecurityapi.NotificationTestResult{
+				{Channel: "slack", Delivered: true},
+			},
+		},
+		{
+			name: "bounced",
+			results: []stepsecurityapi.NotificationTestResult{
+				{Channel: "slack", Error: "channel_not_found"},
+			},
+			expectedPending: true,
+			expectedWarning: "channel_not_found",
+		},
+		{
+			name:            "send_fails",
+			sendErr:         fmt.Errorf("status: 500, body: internal error"),
+			expectedPending: true,
+			expectedWarning: "internal error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			mockClient := &stepsecurityapi.MockStepSecurityClient{}
+			mockClient.On("CreateNotificationSettings", mock.Anything, mock.Anything).Return(nil)
+			mockClient.On("SendTestNotification", mock.Anything, "tf-acc-test").Return(tc.results, tc.sendErr)
+
+			r := &GithubRepoNotificationSettingsResource{client: mockClient}
+			state := testNotificationSettingsState(t, map[string]attr.Value{
+				"slack_notification_method": types.StringValue("oauth"),
+				"slack_channel_id":          types.StringValue("C0123456789"),
+			})
+			require.False(t, state.SetAttribute(ctx, path.Root("verify_on_apply"), true).HasError())
+			plan := tfsdk.Plan{Schema: state.Schema, Raw: state.Raw}
+			config := tfsdk.Config{Schema: state.Schema, Raw: state.Raw}
+			resp := &resource.CreateResponse{State: tfsdk.State{Schema: state.Schema}}
+			r.Create(ctx, resource.CreateRequest{Plan: plan, Config: config}, resp)
+
+			mockClient.AssertExpectations(t)
+			// The settings were saved, so a failed delivery doesn't fail the create.
+			require.False(t, resp.Diagnostics.HasError(), "diags: %v", resp.Diagnostics)
+			if tc.expectedWarning != "" {
+				require.Equal(t, 1, resp.Diagnostics.WarningsCount())
+				assert.Contains(t, resp.Diagnostics.Warnings()[0].Detail(), tc.expectedWarning)
+			}
+
+			var saved githubNotificationSettingsModel
+			require.False(t, resp.State.Get(ctx, &saved).HasError())
+			assert.Equal(t, types.BoolValue(tc.expectedPending), saved.VerificationPending)
+		})
+	}
+}
+
+func TestGithubNotificationResource_ModifyPlanRetriesPendingVerification(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name            string
+		pending         bool
+		verifyOnApply   bool
+		expectedUnknown bool
+	}{
+		{name: "pending", pending: true, verifyOnApply: true, expectedUnknown: true},
+		{name: "verified", verifyOnApply: true},
+		{name: "verify_on_apply_unset", pending: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			state := testNotificationSettingsState(t, nil)
+			require.False(t, state.SetAttribute(ctx, path.Root("verification_pending"), tc.pending).HasError())
+			if tc.verifyOnApply {
+				require.False(t, state.SetAttribute(ctx, path.Root("verify_on_apply"), true).HasError())
+			}
+			plan := tfsdk.Plan{Schema: state.Schema, Raw: state.Raw}
+
+			r := &GithubRepoNotificationSettingsResource{}
+			resp := &resource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, resource.ModifyPlanRequest{
+				State:  state,
+				Plan:   plan,
+				Config: tfsdk.Config{Schema: state.Schema, Raw: state.Raw},
+			}, resp)
+			require.False(t, resp.Diagnostics.HasError(), "diags: %v", resp.Diagnostics)
+
+			var planned githubNotificationSettingsModel
+			require.False(t, resp.Plan.Get(ctx, &planned).HasError())
+			assert.Equal(t, tc.expectedUnknown, planned.VerificationPending.IsUnknown())
+		})
+	}
+}
+
+func TestGithubNotificationResource_UpgradeStateV0(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	priorSchema := notificationSettingsSchemaV0()
+	prior := tfsdk.State{Schema: *priorSchema}
+	require.False(t, prior.Set(ctx, githubNotificationSettingsModelV0{
+		ID:    types.StringValue("tf-acc-test"),
+		Owner: types.StringValue("tf-acc-test"),
+		NotificationChannels: types.ObjectValueMust(map[string]attr.Type{
+			"slack_webhook_url":         types.StringType,
+			"teams_webhook_url":         types.StringType,
+			"email":                     types.StringType,
+			"slack_channel_id":          types.StringType,
+			"slack_notification_method": types.StringType,
+		}, map[string]attr.Value{
+			"slack_webhook_url":         types.StringValue("https://hooks.slack.com/test"),
+			"teams_webhook_url":         types.StringValue(" "),
+			"email":                     types.StringValue(" "),
+			"slack_channel_id":          types.StringValue(" "),
+			"slack_notification_method": types.StringValue("webhook"),
+		}),
+		NotificationEvents: testNotificationEventsValue(),
+	}).HasError())
+
+	r := &GithubRepoNotificationSettingsResource{}
+	upgrader, ok := r.UpgradeState(ctx)[0]
+	require.True(t, ok)
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	resp := &resource.UpgradeStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	upgrader.StateUpgrader(ctx, resource.UpgradeStateRequest{State: &prior}, resp)
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+
+	var model githubNotificationSettingsModel
+	require.False(t, resp.State.Get(ctx, &model).HasError())
+	channels := model.NotificationChannels.Attributes()
+	assert.Equal(t, types.StringValue("https://hooks.slack.com/test"), channels["slack_webhook_url"])
+	assert.True(t, channels["teams_webhook_url"].IsNull())
+	assert.True(t, channels["email"].IsNull())
+	assert.True(t, channels["slack_channel_id"].IsNull())
+	assert.True(t, channels["slack_webhook_url_wo_version"].IsNull())
+	assert.True(t, model.NotificationEvents.Equal(testNotificationEventsValue()))
+}
+
+func TestGithubNotificationResource_WriteOnlyWebhookURL(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	slackURL := "https://hooks.slack.com/services/T000/B000/XXXX"
+	mockClient := &stepsecurityapi.MockStepSecurityClient{}
+	mockClient.On("UpdateNotificationSettings", mock.Anything, mock.MatchedBy(func(req stepsecurityapi.GitHubNotificationSettingsRequest) bool {
+		return req.SlackWebhookURL != nil && *req.SlackWebhookURL == slackURL
+	})).Return(nil)
+	mockClient.On("GetNotificationSettings", mock.Anything, "tf-acc-test").Return(&stepsecurityapi.NotificationSettings{
+		SlackWebhookURL: &slackURL,
+	}, nil)
+
+	r := &GithubRepoNotificationSettingsResource{client: mockClient}
+	plan := testNotificationSettingsState(t, map[string]attr.Value{"slack_webhook_url_wo_version": types.Int64Value(2)})
+	config := testNotificationSettingsState(t, map[string]attr.Value{
+		"slack_webhook_url_wo":         types.StringValue(slackURL),
+		"slack_webhook_url_wo_version": types.Int64Value(2),
+	})
+
+	updateResp := &resource.UpdateResponse{State: tfsdk.State{Schema: plan.Schema}}
+	r.Update(ctx, resource.UpdateRequest{
+		Plan:   tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw},
+		Config: tfsdk.Config{Schema: config.Schema, Raw: config.Raw},
+		State:  testNotificationSettingsState(t, nil),
+	}, updateResp)
+	require.False(t, updateResp.Diagnostics.HasError(), "unexpected diags: %v", updateResp.Diagnostics)
+
+	// The URL the API returns must not land in state while the write-only attribute manages it.
+	readResp := &resource.ReadResponse{State: updateResp.State}
+	r.Read(ctx, resource.ReadRequest{State: updateResp.State}, readResp)
+	require.False(t, readResp.Diagnostics.HasError(), "unexpected diags: %v", readResp.Diagnostics)
+
+	var model githubNotificationSettingsModel
+	require.False(t, readResp.State.Get(ctx, &model).HasError())
+	channels := model.NotificationChannels.Attributes()
+	assert.True(t, channels["slack_webhook_url"].IsNull())
+	assert.True(t, channels["slack_webhook_url_wo"].IsNull())
+	assert.Equal(t, types.Int64Value(2), channels["slack_webhook_url_wo_version"])
+	mockClient.AssertExpectations(t)
+}
+
+// testNotificationSettingsState returns a state for tf-acc-test with the given
+// channels set and every event off.
+func testNotificationSettingsState(t *testing.T, channels map[string]attr.Value) tfsdk.State {
+	t.Helper()
+
+	values := map[string]attr.Value{
+		"slack_webhook_url":            types.StringNull(),
+		"slack_webhook_url_wo":         types.StringNull(),
+		"slack_webhook_url_wo_version": types.Int64Null(),
+		"teams_webhook_url":            types.StringNull(),
+		"teams_webhook_url_wo":         types.StringNull(),
+		"teams_webhook_url_wo_version": types.Int64Null(),
+		"email":                        types.StringNull(),
+		"slack_channel_id":             types.StringNull(),
+		"slack_notification_method":    types.StringValue("webhook"),
+	}
+	for name, value := range channels {
+		values[name] = value
+	}
+
+	r := &GithubRepoNotificationSettingsResource{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), resource.SchemaRequest{}, schemaResp)
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, state.Set(context.Background(), githubNotificationSettingsModel{
+		ID:                   types.StringValue("tf-acc-test"),
+		Owner:                types.StringValue("tf-acc-test"),
+		NotificationChannels: types.ObjectValueMust(notificationChannelsAttrTypes(), values),
+		NotificationEvents:   testNotificationEventsValue(),
+	}).HasError())
+
+	return state
+}
+
+func testNotificationEventsValue() types.Object {
+	values := make(map[string]attr.Value, len(stepsecurityapi.NotificationEvents))
+	for _, event := range stepsecurityapi.NotificationEvents {
+		values[event] = types.BoolValue(false)
+	}
+	return types.ObjectValueMust(notificationEventsAttrTypes(), values)
+}
+
 func testAccGithubNotificationResourceConfig(owner, slackWebhook, email string) string {
 	return testProviderConfig() + fmt.Sprintf(`
 resource "stepsecurity_github_org_notification_settings" "test" {
@@ -5,24 +5,28 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &githubPolicyStoreResource{}
-	_ resource.ResourceWithConfigure   = &githubPolicyStoreResource{}
-	_ resource.ResourceWithImportState = &githubPolicyStoreResource{}
+	_ resource.Resource                   = &githubPolicyStoreResource{}
+	_ resource.ResourceWithConfigure      = &githubPolicyStoreResource{}
+	_ resource.ResourceWithImportState    = &githubPolicyStoreResource{}
+	_ resource.ResourceWithValidateConfig = &githubPolicyStoreResource{}
 )
 
 // NewOrderResource is a helper function to simplify the provider implementation.
@@ -54,28 +58,38 @@ func (r *githubPolicyStoreResource) Schema(_ context.Context, _ resource.SchemaR
 			"owner": schema.StringAttribute{
 				Required:    true,
 				Description: "Github Organization(owner) name",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"policy_name": schema.StringAttribute{
 				Required:    true,
 				Description: "Name of the policy",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"egress_policy": schema.StringAttribute{
 				Required:    true,
 				Description: "Egress policy mode. Can be 'audit' or 'block'",
 			},
-			"allowed_endpoints": schema.ListAttribute{
+			"allowed_endpoints": schema.SetAttribute{
 				ElementType: types.StringType,
 				Optional:    true,
 				Computed:    true,
-				Default: listdefault.StaticValue(
-					types.ListValueMust(
+				Default: setdefault.StaticValue(
+					types.SetValueMust(
 						types.StringType,
 						[]attr.Value{
 							types.StringValue("github.com:443"),
 						},
 					),
 				),
-				Description: "List of allowed endpoints. This specifies list of enpoints to allow when egress policy is set to 'block' mode",
+				Description: "Set of allowed endpoints. This specifies the endpoints to allow when egress policy is set to 'block' mode. " +
+					"Each endpoint is of the form `host:port`, `*.domain:port` (any subdomain of domain) or `ip:port`, with IPv6 addresses in brackets.",
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(allowedEndpointValidator{}),
+				},
 			},
 			"disable_telemetry": schema.BoolAttribute{
 				Optional:    true,
@@ -156,7 +170,7 @@ type githubPolicyStoreModel struct {
 	Owner                 types.String `tfsdk:"owner"`
 	PolicyName            types.String `tfsdk:"policy_name"`
 	EgressPolicy          types.String `tfsdk:"egress_policy"`
-	AllowedEndpoints      types.List   `tfsdk:"allowed_endpoints"`
+	AllowedEndpoints      types.Set    `tfsdk:"allowed_endpoints"`
 	DisableTelemetry      types.Bool   `tfsdk:"disable_telemetry"`
 	DisableSudo           types.Bool   `tfsdk:"disable_sudo"`
 	DisableFileMonitoring types.Bool   `tfsdk:"disable_file_monitoring"`
@@ -170,6 +184,51 @@ type lockdownConfigModel struct {
 	ReverseShell           types.Bool `tfsdk:"reverse_shell"`
 }
 
+func lockdownAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"enabled":                   types.BoolType,
+		"privileged_container":      types.BoolType,
+		"runner_worker_memory_read": types.BoolType,
+		"reverse_shell":             types.BoolType,
+	}
+}
+
+// ValidateConfig warns when block mode is combined with allowed endpoints that
+// leave out endpoints most workflows depend on.
+func (r *githubPolicyStoreResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config githubPolicyStoreModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.EgressPolicy.IsUnknown() || config.EgressPolicy.ValueString() != "block" || config.AllowedEndpoints.IsUnknown() {
+		return
+	}
+
+	// Without allowed_endpoints the default of github.com:443 applies.
+	allowed := []string{"github.com:443"}
+	if !config.AllowedEndpoints.IsNull() {
+		allowed = nil
+		for _, element := range config.AllowedEndpoints.Elements() {
+			endpoint, ok := element.(types.String)
+			if !ok || endpoint.IsUnknown() {
+				return
+			}
+			allowed = append(allowed, endpoint.ValueString())
+		}
+	}
+
+	if missing := missingBlockModeEndpoints(allowed); len(missing) > 0 {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("allowed_endpoints"),
+			"Allowed endpoints may be incomplete",
+			fmt.Sprintf("egress_policy is \"block\" but allowed_endpoints does not allow %s. "+
+				"Workflows that depend on them will fail when attached to this policy.", strings.Join(missing, ", ")),
+		)
+	}
+}
+
 // ImportState implements resource.ResourceWithImportState.
 func (r *githubPolicyStoreResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	// The import ID should be the owner name
@@ -255,6 +314,14 @@ func (r *githubPolicyStoreResource) Read(ctx context.Context, req resource.ReadR
 	}
 
 	policy, err := r.client.GetGitHubPolicyStorePolicy(ctx, state.Owner.ValueString(), state.PolicyName.ValueString())
+	if err != nil && stepsecurityapi.IsNotFound(err) {
+		tflog.Info(ctx, "Policy no longer exists, removing from state", map[string]any{
+			"owner":       state.Owner.ValueString(),
+			"policy_name": state.PolicyName.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to read policy",
@@ -283,7 +350,7 @@ func (r *githubPolicyStoreResource) Update(ctx context.Context, req resource.Upd
 	}
 
 	policy := r.getGitHubPolicyStorePolicy(ctx, plan)
-	if err := r.client.CreateGitHubPolicyStorePolicy(ctx, policy); err != nil {
+	if err := r.client.UpdateGitHubPolicyStorePolicy(ctx, policy); err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to update policy",
 			fmt.Sprintf("Error updating policy: %s", err),
@@ -301,13 +368,8 @@ func (r *githubPolicyStoreResource) Update(ctx context.Context, req resource.Upd
 		return
 	}
 
-	// update the state
-	var state githubPolicyStoreModel
-	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-	r.updateGitHubPolicyStorePolicyState(policy, &state)
+	// update the state from what the server stored
+	r.updateGitHubPolicyStorePolicyState(policy, &plan)
 
 	// Set state to fully populated data
 	diags := resp.State.Set(ctx, plan)
@@ -338,14 +400,19 @@ func (r *githubPolicyStoreResource) Delete(ctx context.Context, req resource.Del
 func (r *githubPolicyStoreResource) updateGitHubPolicyStorePolicyState(policy *stepsecurityapi.GitHubPolicyStorePolicy, state *githubPolicyStoreModel) {
 
 	var allowedEndpoints []attr.Value
+	seen := make(map[string]bool, len(policy.AllowedEndpoints))
 	for _, endpoint := range policy.AllowedEndpoints {
+		if seen[endpoint] {
+			continue
+		}
+		seen[endpoint] = true
 		allowedEndpoints = append(allowedEndpoints, types.StringValue(endpoint))
 	}
 
 	state.ID = types.StringValue(policy.Owner + ":::" + policy.PolicyName)
 	state.Owner = types.StringValue(policy.Owner)
 	state.PolicyName = types.StringValue(policy.PolicyName)
-	state.AllowedEndpoints = types.ListValueMust(
+	state.AllowedEndpoints = types.SetValueMust(
 		types.StringType,
 		allowedEndpoints,
 	)
@@ -354,26 +421,29 @@ func (r *githubPolicyStoreResource) updateGitHubPolicyStorePolicyState(policy *s
 	state.DisableSudo = types.BoolValue(policy.DisableSudo)
 	state.DisableFileMonitoring = types.BoolValue(policy.DisableFileMonitoring)
 
-	lockdownAttrTypes := map[string]attr.Type{
-		"enabled":                   types.BoolType,
-		"privileged_container":      types.BoolType,
-		"runner_worker_memory_read": types.BoolType,
-		"reverse_shell":             types.BoolType,
+	lockdown := policy.Lockdown
+	if lockdown == nil && !state.Lockdown.IsNull() {
+		// The server omits lockdown once it is disabled; keep the
+		// configured block so a change to it still shows as drift.
+		lockdown = &stepsecurityapi.LockdownConfig{}
+	}
+	if lockdown != nil && state.Lockdown.IsNull() && !lockdown.Enabled && len(lockdown.Detections) == 0 {
+		lockdown = nil
 	}
-	if policy.Lockdown != nil {
+	if lockdown != nil {
 		detectionSet := make(map[string]bool)
-		for _, d := range policy.Lockdown.Detections {
+		for _, d := range lockdown.Detections {
 			detectionSet[d] = true
 		}
-		lockdownObj, _ := types.ObjectValue(lockdownAttrTypes, map[string]attr.Value{
-			"enabled":                   types.BoolValue(policy.Lockdown.Enabled),
+		lockdownObj, _ := types.ObjectValue(lockdownAttrTypes(), map[string]attr.Value{
+			"enabled":                   types.BoolValue(lockdown.Enabled),
 			"privileged_container":      types.BoolValue(detectionSet["Privileged-Container"]),
 			"runner_worker_memory_read": types.BoolValue(detectionSet["Runner-Worker-Memory-Read"]),
 			"reverse_shell":             types.BoolValue(detectionSet["Reverse-Shell"]),
 		})
 		state.Lockdown = lockdownObj
 	} else {
-		state.Lockdown = types.ObjectNull(lockdownAttrTypes)
+		state.Lockdown = types.ObjectNull(lockdownAttrTypes())
 	}
 }
 
@@ -3,9 +3,14 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -125,6 +130,7 @@ var (
 	_ resource.Resource                = &githubPolicyStoreAttachmentResource{}
 	_ resource.ResourceWithConfigure   = &githubPolicyStoreAttachmentResource{}
 	_ resource.ResourceWithImportState = &githubPolicyStoreAttachmentResource{}
+	_ resource.ResourceWithModifyPlan  = &githubPolicyStoreAttachmentResource{}
 )
 
 // NewGithubPolicyStoreAttachmentResource is a helper function to simplify the provider implementation.
@@ -215,7 +221,63 @@ func (r *githubPolicyStoreAttachmentResource) Schema(_ context.Context, _ resour
 			"clusters": schema.ListAttribute{
 				ElementType: types.StringType,
 				Optional:    true,
-				Description: "List of cluster names for cluster-level attachments",
+				Description: "List of cluster names for cluster-level attachments. Names must match a cluster known to StepSecurity; see the stepsecurity_github_clusters data source.",
+				Validators: []validator.List{
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"cluster_scopes": schema.ListNestedAttribute{
+				Optional:    true,
+				Description: "List of attachments to some namespaces or runner scale sets of a cluster. A cluster listed here cannot also be listed in clusters. Cluster names, namespaces and runner scale sets must be known to StepSecurity; see the stepsecurity_github_clusters data source.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required:    true,
+							Description: "Cluster name",
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"namespaces": schema.ListAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Description: "Kubernetes namespaces of the cluster the policy applies to",
+							Validators: []validator.List{
+								listvalidator.SizeAtLeast(1),
+								listvalidator.UniqueValues(),
+							},
+						},
+						"runner_scale_sets": schema.ListNestedAttribute{
+							Optional:    true,
+							Description: "Runner scale sets of the cluster the policy applies to",
+							Validators: []validator.List{
+								listvalidator.SizeAtLeast(1),
+							},
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"name": schema.StringAttribute{
+										Required:    true,
+										Description: "Runner scale set name",
+									},
+									"namespace": schema.StringAttribute{
+										Required:    true,
+										Description: "Namespace the runner scale set runs in",
+									},
+								},
+							},
+						},
+					},
+					Validators: []validator.Object{
+						objectvalidator.AtLeastOneOf(
+							path.MatchRelative().AtName("namespaces"),
+							path.MatchRelative().AtName("runner_scale_sets"),
+						),
+					},
+				},
 			},
 		},
 	}
@@ -254,12 +316,35 @@ type repoAttachmentModel struct {
 	Workflows   types.List   `tfsdk:"workflows"`
 }
 
+type clusterScopeModel struct {
+	Name            types.String `tfsdk:"name"`
+	Namespaces      types.List   `tfsdk:"namespaces"`
+	RunnerScaleSets types.List   `tfsdk:"runner_scale_sets"`
+}
+
+type runnerScaleSetModel struct {
+	Name      types.String `tfsdk:"name"`
+	Namespace types.String `tfsdk:"namespace"`
+}
+
+var clusterScopeAttrTypes = map[string]attr.Type{
+	"name":              types.StringType,
+	"namespaces":        types.ListType{ElemType: types.StringType},
+	"runner_scale_sets": types.ListType{ElemType: types.ObjectType{AttrTypes: runnerScaleSetAttrTypes}},
+}
+
 type githubPolicyStoreAttachmentModel struct {
-	ID         types.String `tfsdk:"id"`
-	Owner      types.String `tfsdk:"owner"`
-	PolicyName types.String `tfsdk:"policy_name"`
-	Org        types.Object `tfsdk:"org"`
-	Clusters   types.List   `tfsdk:"clusters"`
+	ID            types.String `tfsdk:"id"`
+	Owner         types.String `tfsdk:"owner"`
+	PolicyName    types.String `tfsdk:"policy_name"`
+	Org           types.Object `tfsdk:"org"`
+	Clusters      types.List   `tfsdk:"clusters"`
+	ClusterScopes types.List   `tfsdk:"cluster_scopes"`
+}
+
+// hasAttachment reports whether the model attaches the policy anywhere.
+func (m githubPolicyStoreAttachmentModel) hasAttachment() bool {
+	return !m.Org.IsNull() || len(m.Clusters.Elements()) > 0 || len(m.ClusterScopes.Elements()) > 0
 }
 
 // ImportState implements resource.ResourceWithImportState.
@@ -298,6 +383,145 @@ func (r *githubPolicyStoreAttachmentResource) ImportState(ctx context.Context, r
 	resp.State = readResp.State
 }
 
+// ModifyPlan checks that the attached clusters, and the namespaces and runner scale
+// sets of cluster_scopes, are known to StepSecurity.
+func (r *githubPolicyStoreAttachmentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Skip ModifyPlan during destroy operations
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan githubPolicyStoreAttachmentModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.Owner.IsUnknown() || plan.Clusters.IsUnknown() || plan.ClusterScopes.IsUnknown() {
+		return
+	}
+	if len(plan.Clusters.Elements()) == 0 && len(plan.ClusterScopes.Elements()) == 0 {
+		return
+	}
+
+	var clusters []types.String
+	var scopes []clusterScopeModel
+	resp.Diagnostics.Append(plan.Clusters.ElementsAs(ctx, &clusters, false)...)
+	resp.Diagnostics.Append(plan.ClusterScopes.ElementsAs(ctx, &scopes, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i, scope := range scopes {
+		if slices.ContainsFunc(clusters, func(cluster types.String) bool { return cluster.Equal(scope.Name) }) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("cluster_scopes").AtListIndex(i).AtName("name"),
+				"Invalid Configuration",
+				fmt.Sprintf("Cluster %q is attached as a whole in clusters and cannot also be attached in cluster_scopes.", scope.Name.ValueString()),
+			)
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	known, err := r.client.ListClusters(ctx, plan.Owner.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("clusters"),
+			"Could not verify clusters",
+			fmt.Sprintf("Could not list the clusters of %s to check the attached cluster names: %s", plan.Owner.ValueString(), err),
+		)
+		return
+	}
+
+	for i, cluster := range clusters {
+		r.findCluster(known, plan.Owner.ValueString(), cluster, path.Root("clusters").AtListIndex(i), &resp.Diagnostics)
+	}
+	for i, scope := range scopes {
+		scopePath := path.Root("cluster_scopes").AtListIndex(i)
+		cluster := r.findCluster(known, plan.Owner.ValueString(), scope.Name, scopePath.AtName("name"), &resp.Diagnostics)
+		if cluster == nil {
+			continue
+		}
+		resp.Diagnostics.Append(validateClusterScope(ctx, *cluster, scope, scopePath)...)
+	}
+}
+
+// findCluster returns the known cluster called name, adding an error at attrPath when
+// there is none. Unknown names are skipped and return nil.
+func (r *githubPolicyStoreAttachmentResource) findCluster(known []stepsecurityapi.Cluster, owner string, name types.String, attrPath path.Path, diags *diag.Diagnostics) *stepsecurityapi.Cluster {
+	if name.IsUnknown() {
+		return nil
+	}
+	for i := range known {
+		if known[i].Name == name.ValueString() {
+			return &known[i]
+		}
+	}
+
+	knownNames := make([]string, 0, len(known))
+	for _, cluster := range known {
+		knownNames = append(knownNames, cluster.Name)
+	}
+	diags.AddAttributeError(
+		attrPath,
+		"Unknown Cluster",
+		fmt.Sprintf("Cluster %q is not known to StepSecurity for %s. Known clusters: %s.", name.ValueString(), owner, joinOrNone(knownNames)),
+	)
+	return nil
+}
+
+// validateClusterScope checks that the namespaces and runner scale sets of scope exist
+// in cluster.
+func validateClusterScope(ctx context.Context, cluster stepsecurityapi.Cluster, scope clusterScopeModel, scopePath path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var namespaces []types.String
+	var scaleSets []runnerScaleSetModel
+	diags.Append(scope.Namespaces.ElementsAs(ctx, &namespaces, false)...)
+	diags.Append(scope.RunnerScaleSets.ElementsAs(ctx, &scaleSets, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	for i, namespace := range namespaces {
+		if namespace.IsUnknown() || slices.Contains(cluster.Namespaces, namespace.ValueString()) {
+			continue
+		}
+		diags.AddAttributeError(
+			scopePath.AtName("namespaces").AtListIndex(i),
+			"Unknown Namespace",
+			fmt.Sprintf("Namespace %q is not known to StepSecurity for cluster %q. Known namespaces: %s.", namespace.ValueString(), cluster.Name, joinOrNone(cluster.Namespaces)),
+		)
+	}
+
+	knownScaleSets := make([]string, 0, len(cluster.RunnerScaleSets))
+	for _, scaleSet := range cluster.RunnerScaleSets {
+		knownScaleSets = append(knownScaleSets, scaleSet.Namespace+"/"+scaleSet.Name)
+	}
+	for i, scaleSet := range scaleSets {
+		if scaleSet.Name.IsUnknown() || scaleSet.Namespace.IsUnknown() {
+			continue
+		}
+		if slices.Contains(cluster.RunnerScaleSets, stepsecurityapi.RunnerScaleSet{Name: scaleSet.Name.ValueString(), Namespace: scaleSet.Namespace.ValueString()}) {
+			continue
+		}
+		diags.AddAttributeError(
+			scopePath.AtName("runner_scale_sets").AtListIndex(i),
+			"Unknown Runner Scale Set",
+			fmt.Sprintf("Runner scale set %q in namespace %q is not known to StepSecurity for cluster %q. Known runner scale sets: %s.", scaleSet.Name.ValueString(), scaleSet.Namespace.ValueString(), cluster.Name, joinOrNone(knownScaleSets)),
+		)
+	}
+	return diags
+}
+
+func joinOrNone(values []string) string {
+	if len(values) == 0 {
+		return "none"
+	}
+	return strings.Join(values, ", ")
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *githubPolicyStoreAttachmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan githubPolicyStoreAttachmentModel
@@ -308,8 +532,8 @@ func (r *githubPolicyStoreAttachmentResource) Create(ctx context.Context, req re
 	}
 
 	// Validate the configuration - ensure at least one attachment type is specified
-	if plan.Org.IsNull() && (plan.Clusters.IsNull() || len(plan.Clusters.Elements()) == 0) {
-		resp.Diagnostics.AddError("Invalid Configuration", "At least one attachment (org or clusters) must be specified")
+	if !plan.hasAttachment() {
+		resp.Diagnostics.AddError("Invalid Configuration", "At least one attachment (org, clusters or cluster_scopes) must be specified")
 		return
 	}
 
@@ -371,8 +595,8 @@ func (r *githubPolicyStoreAttachmentResource) Update(ctx context.Context, req re
 	}
 
 	// Validate the configuration - ensure at least one attachment type is specified
-	if plan.Org.IsNull() && (plan.Clusters.IsNull() || len(plan.Clusters.Elements()) == 0) {
-		resp.Diagnostics.AddError("Invalid Configuration", "At least one attachment (org or clusters) must be specified")
+	if !plan.hasAttachment() {
+		resp.Diagnostics.AddError("Invalid Configuration", "At least one attachment (org, clusters or cluster_scopes) must be specified")
 		return
 	}
 
@@ -488,6 +712,26 @@ func (r *githubPolicyStoreAttachmentResource) createAttachment(ctx context.Conte
 		request.Clusters = clusters
 	}
 
+	// Handle namespace and runner scale set attachments
+	for _, scopeObj := range plan.ClusterScopes.Elements() {
+		scopeAttrs := scopeObj.(types.Object).Attributes()
+
+		scope := stepsecurityapi.ClusterScope{
+			Name: scopeAttrs["name"].(types.String).ValueString(),
+		}
+		for _, namespace := range scopeAttrs["namespaces"].(types.List).Elements() {
+			scope.Namespaces = append(scope.Namespaces, namespace.(types.String).ValueString())
+		}
+		for _, scaleSetObj := range scopeAttrs["runner_scale_sets"].(types.List).Elements() {
+			scaleSetAttrs := scaleSetObj.(types.Object).Attributes()
+			scope.RunnerScaleSets = append(scope.RunnerScaleSets, stepsecurityapi.RunnerScaleSet{
+				Name:      scaleSetAttrs["name"].(types.String).ValueString(),
+				Namespace: scaleSetAttrs["namespace"].(types.String).ValueString(),
+			})
+		}
+		request.ClusterScopes = append(request.ClusterScopes, scope)
+	}
+
 	return r.client.AttachGitHubPolicyStorePolicy(ctx, owner, policyName, request)
 }
 
@@ -505,6 +749,7 @@ func (r *githubPolicyStoreAttachmentResource) updateAttachmentState(policy *step
 			}}},
 		})
 		state.Clusters = types.ListNull(types.StringType)
+		state.ClusterScopes = types.ListNull(types.ObjectType{AttrTypes: clusterScopeAttrTypes})
 		return
 	}
 
@@ -591,4 +836,43 @@ func (r *githubPolicyStoreAttachmentResource) updateAttachmentState(policy *step
 	} else {
 		state.Clusters = types.ListNull(types.StringType)
 	}
+	// Handle namespace and runner scale set attachments
+	state.ClusterScopes = clusterScopesFromAPI(policy.Attachments.ClusterScopes)
+}
+
+// clusterScopesFromAPI converts cluster scopes into the cluster_scopes attribute,
+// keeping unset lists as null to match the configuration.
+func clusterScopesFromAPI(scopes []stepsecurityapi.ClusterScope) types.List {
+	scopeType := types.ObjectType{AttrTypes: clusterScopeAttrTypes}
+	if len(scopes) == 0 {
+		return types.ListNull(scopeType)
+	}
+
+	scopeObjs := make([]attr.Value, 0, len(scopes))
+	for _, scope := range scopes {
+		namespaces := types.ListNull(types.StringType)
+		if len(scope.Namespaces) > 0 {
+			namespaces = types.ListValueMust(types.StringType, stringsToAttrValues(scope.Namespaces))
+		}
+
+		scaleSetType := types.ObjectType{AttrTypes: runnerScaleSetAttrTypes}
+		scaleSets := types.ListNull(scaleSetType)
+		if len(scope.RunnerScaleSets) > 0 {
+			scaleSetObjs := make([]attr.Value, 0, len(scope.RunnerScaleSets))
+			for _, scaleSet := range scope.RunnerScaleSets {
+				scaleSetObjs = append(scaleSetObjs, types.ObjectValueMust(runnerScaleSetAttrTypes, map[string]attr.Value{
+					"name":      types.StringValue(scaleSet.Name),
+					"namespace": types.StringValue(scaleSet.Namespace),
+				}))
+			}
+			scaleSets = types.ListValueMust(scaleSetType, scaleSetObjs)
+		}
+
+		scopeObjs = append(scopeObjs, types.ObjectValueMust(clusterScopeAttrTypes, map[string]attr.Value{
+			"name":              types.StringValue(scope.Name),
+			"namespaces":        namespaces,
+			"runner_scale_sets": scaleSets,
+		}))
+	}
+	return types.ListValueMust(scopeType, scopeObjs)
 }
@@ -14,7 +14,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	res "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAccGithubPolicyStoreAttachmentResource(t *testing.T) {
@@ -152,7 +154,7 @@ func TestGithubPolicyStoreAttachmentResource_Schema(t *testing.T) {
 
 	// Test required attributes
 	expectedAttrs := []string{
-		"id", "owner", "policy_name", "org", "clusters",
+		"id", "owner", "policy_name", "org", "clusters", "cluster_scopes",
 	}
 	for _, attr := range expectedAttrs {
 		if _, exists := resp.Schema.Attributes[attr]; !exists {
@@ -868,3 +870,148 @@ resource "stepsecurity_github_policy_store_attachment" "test" {
 }
 `, owner, policyName)
 }
+
+func TestGithubPolicyStoreAttachmentResource_ModifyPlanClusters(t *testing.T) {
+	t.Parallel()
+
+	known := []stepsecurityapi.Cluster{
+		{
+			Name:            "prod",
+			Namespaces:      []string{"arc", "payments"},
+			RunnerScaleSets: []stepsecurityapi.RunnerScaleSet{{Name: "arc-runners", Namespace: "arc"}},
+		},
+		{Name: "staging"},
+	}
+
+	testCases := []struct {
+		name        string
+		clusters    []string
+		scopes      []stepsecurityapi.ClusterScope
+		listErr     error
+		skipList    bool
+		expectError string
+		expectWarn  bool
+	}{
+		{name: "no_clusters", skipList: true},
+		{name: "known_clusters", clusters: []string{"prod", "staging"}},
+		{name: "unknown_cluster", clusters: []string{"prod", "qa"}, expectError: `Cluster "qa" is not known to StepSecurity for test-org. Known clusters: prod, staging.`},
+		{name: "list_fails", clusters: []string{"qa"}, listErr: fmt.Errorf("status: 404, body: not found"), expectWarn: true},
+		{
+			name: "known_scopes",
+			scopes: []stepsecurityapi.ClusterScope{{
+				Name:            "prod",
+				Namespaces:      []string{"payments"},
+				RunnerScaleSets: []stepsecurityapi.RunnerScaleSet{{Name: "arc-runners", Namespace: "arc"}},
+			}},
+		},
+		{
+			name:        "unknown_scope_cluster",
+			scopes:      []stepsecurityapi.ClusterScope{{Name: "qa", Namespaces: []string{"payments"}}},
+			expectError: `Cluster "qa" is not known`,
+		},
+		{
+			name:        "unknown_namespace",
+			scopes:      []stepsecurityapi.ClusterScope{{Name: "prod", Namespaces: []string{"billing"}}},
+			expectError: `Namespace "billing" is not known to StepSecurity for cluster "prod". Known namespaces: arc, payments.`,
+		},
+		{
+			name: "unknown_runner_scale_set",
+			scopes: []stepsecurityapi.ClusterScope{{
+				Name:            "prod",
+				RunnerScaleSets: []stepsecurityapi.RunnerScaleSet{{Name: "arc-runners", Namespace: "payments"}},
+			}},
+			expectError: `Runner scale set "arc-runners" in namespace "payments" is not known to StepSecurity for cluster "prod". Known runner scale sets: arc/arc-runners.`,
+		},
+		{
+			name:        "cluster_in_both",
+			clusters:    []string{"prod"},
+			scopes:      []stepsecurityapi.ClusterScope{{Name: "prod", Namespaces: []string{"payments"}}},
+			skipList:    true,
+			expectError: `Cluster "prod" is attached as a whole in clusters`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			r := &githubPolicyStoreAttachmentResource{}
+
+			clusters := types.ListNull(types.StringType)
+			if tc.clusters != nil {
+				clusters = types.ListValueMust(types.StringType, stringsToAttrValues(tc.clusters))
+			}
+			plan := testResourcePlan(t, r, githubPolicyStoreAttachmentModel{
+				ID:         types.StringUnknown(),
+				Owner:      types.StringValue("test-org"),
+				PolicyName: types.StringValue("test-policy"),
+				Org: types.ObjectNull(map[string]attr.Type{
+					"apply_to_org": types.BoolType,
+					"repositories": types.ListType{ElemType: types.ObjectType{AttrTypes: map[string]attr.Type{
+						"name":          types.StringType,
+						"apply_to_repo": types.BoolType,
+						"workflows":     types.ListType{ElemType: types.StringType},
+					}}},
+				}),
+				Clusters:      clusters,
+				ClusterScopes: clusterScopesFromAPI(tc.scopes),
+			})
+
+			mockClient := &stepsecurityapi.MockStepSecurityClient{}
+			if !tc.skipList {
+				mockClient.On("ListClusters", mock.Anything, "test-org").Return(known, tc.listErr).Once()
+			}
+			r.client = mockClient
+
+			resp := &resource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: plan, Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}}, resp)
+			mockClient.AssertExpectations(t)
+
+			if tc.expectError != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), tc.expectError)
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+			assert.Equal(t, tc.expectWarn, resp.Diagnostics.WarningsCount() > 0)
+		})
+	}
+}
+
+func TestGithubPolicyStoreAttachmentResource_ClusterScopes(t *testing.T) {
+	t.Parallel()
+
+	scopes := []stepsecurityapi.ClusterScope{{
+		Name:            "prod",
+		Namespaces:      []string{"payments"},
+		RunnerScaleSets: []stepsecurityapi.RunnerScaleSet{{Name: "arc-runners", Namespace: "arc"}},
+	}}
+
+	mockClient := &stepsecurityapi.MockStepSecurityClient{}
+	mockClient.On("AttachGitHubPolicyStorePolicy", mock.Anything, "test-org", "test-policy", &stepsecurityapi.GitHubPolicyAttachRequest{
+		ClusterScopes: scopes,
+	}).Return(nil).Once()
+
+	r := &githubPolicyStoreAttachmentResource{client: mockClient}
+	model := githubPolicyStoreAttachmentModel{
+		Owner:         types.StringValue("test-org"),
+		PolicyName:    types.StringValue("test-policy"),
+		Org:           types.ObjectNull(map[string]attr.Type{}),
+		Clusters:      types.ListNull(types.StringType),
+		ClusterScopes: clusterScopesFromAPI(scopes),
+	}
+	require.NoError(t, r.createAttachment(context.Background(), &model))
+	mockClient.AssertExpectations(t)
+
+	// Reading the attachment back yields the configured cluster_scopes.
+	var state githubPolicyStoreAttachmentModel
+	r.updateAttachmentState(&stepsecurityapi.GitHubPolicyStorePolicy{
+		Owner:       "test-org",
+		PolicyName:  "test-policy",
+		Attachments: &stepsecurityapi.PolicyAttachments{ClusterScopes: scopes},
+	}, &state)
+	assert.True(t, state.ClusterScopes.Equal(model.ClusterScopes))
+	assert.True(t, state.Clusters.IsNull())
+	assert.True(t, state.Org.IsNull())
+}
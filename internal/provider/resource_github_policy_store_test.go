@@ -3,18 +3,24 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strings"
 	"testing"
 
 	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	res "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAccGithubPolicyStoreResource(t *testing.T) {
@@ -30,7 +36,7 @@ func TestAccGithubPolicyStoreResource(t *testing.T) {
 					res.TestCheckResourceAttr("stepsecurity_github_policy_store.test", "policy_name", "test-policy"),
 					res.TestCheckResourceAttr("stepsecurity_github_policy_store.test", "egress_policy", "audit"),
 					res.TestCheckResourceAttr("stepsecurity_github_policy_store.test", "allowed_endpoints.#", "1"),
-					res.TestCheckResourceAttr("stepsecurity_github_policy_store.test", "allowed_endpoints.0", "github.com:443"),
+					res.TestCheckTypeSetElemAttr("stepsecurity_github_policy_store.test", "allowed_endpoints.*", "github.com:443"),
 					res.TestCheckResourceAttr("stepsecurity_github_policy_store.test", "disable_telemetry", "false"),
 					res.TestCheckResourceAttr("stepsecurity_github_policy_store.test", "disable_sudo", "false"),
 					res.TestCheckResourceAttr("stepsecurity_github_policy_store.test", "disable_file_monitoring", "false"),
@@ -69,9 +75,9 @@ func TestAccGithubPolicyStoreResourceWithCustomEndpoints(t *testing.T) {
 					res.TestCheckResourceAttr("stepsecurity_github_policy_store.test", "policy_name", "test-policy-custom"),
 					res.TestCheckResourceAttr("stepsecurity_github_policy_store.test", "egress_policy", "block"),
 					res.TestCheckResourceAttr("stepsecurity_github_policy_store.test", "allowed_endpoints.#", "3"),
-					res.TestCheckResourceAttr("stepsecurity_github_policy_store.test", "allowed_endpoints.0", "github.com:443"),
-					res.TestCheckResourceAttr("stepsecurity_github_policy_store.test", "allowed_endpoints.1", "api.github.com:443"),
-					res.TestCheckResourceAttr("stepsecurity_github_policy_store.test", "allowed_endpoints.2", "registry.npmjs.org:443"),
+					res.TestCheckTypeSetElemAttr("stepsecurity_github_policy_store.test", "allowed_endpoints.*", "github.com:443"),
+					res.TestCheckTypeSetElemAttr("stepsecurity_github_policy_store.test", "allowed_endpoints.*", "api.github.com:443"),
+					res.TestCheckTypeSetElemAttr("stepsecurity_github_policy_store.test", "allowed_endpoints.*", "registry.npmjs.org:443"),
 				),
 			},
 		},
@@ -110,7 +116,7 @@ func TestAccGithubPolicyStoreResourceMinimal(t *testing.T) {
 					res.TestCheckResourceAttr("stepsecurity_github_policy_store.test", "policy_name", "test-policy-minimal"),
 					res.TestCheckResourceAttr("stepsecurity_github_policy_store.test", "egress_policy", "audit"),
 					res.TestCheckResourceAttr("stepsecurity_github_policy_store.test", "allowed_endpoints.#", "1"),
-					res.TestCheckResourceAttr("stepsecurity_github_policy_store.test", "allowed_endpoints.0", "github.com:443"),
+					res.TestCheckTypeSetElemAttr("stepsecurity_github_policy_store.test", "allowed_endpoints.*", "github.com:443"),
 					res.TestCheckResourceAttr("stepsecurity_github_policy_store.test", "disable_telemetry", "false"),
 					res.TestCheckResourceAttr("stepsecurity_github_policy_store.test", "disable_sudo", "false"),
 					res.TestCheckResourceAttr("stepsecurity_github_policy_store.test", "disable_file_monitoring", "false"),
@@ -426,7 +432,7 @@ func TestGithubPolicyStoreResource_ImportState(t *testing.T) {
 							"owner":         tftypes.String,
 							"policy_name":   tftypes.String,
 							"egress_policy": tftypes.String,
-							"allowed_endpoints": tftypes.List{
+							"allowed_endpoints": tftypes.Set{
 								ElementType: tftypes.String,
 							},
 							"disable_telemetry":       tftypes.Bool,
@@ -490,7 +496,7 @@ func TestGithubPolicyStoreResource_UpdateState(t *testing.T) {
 				Owner:        types.StringValue("tf-acc-test"),
 				PolicyName:   types.StringValue("test-policy"),
 				EgressPolicy: types.StringValue("audit"),
-				AllowedEndpoints: types.ListValueMust(
+				AllowedEndpoints: types.SetValueMust(
 					types.StringType,
 					[]attr.Value{types.StringValue("github.com:443")},
 				),
@@ -515,7 +521,7 @@ func TestGithubPolicyStoreResource_UpdateState(t *testing.T) {
 				Owner:        types.StringValue("tf-acc-test"),
 				PolicyName:   types.StringValue("test-policy"),
 				EgressPolicy: types.StringValue("block"),
-				AllowedEndpoints: types.ListValueMust(
+				AllowedEndpoints: types.SetValueMust(
 					types.StringType,
 					[]attr.Value{
 						types.StringValue("github.com:443"),
@@ -577,6 +583,261 @@ func TestGithubPolicyStoreResource_UpdateState(t *testing.T) {
 	}
 }
 
+func testPolicyStoreModel(egressPolicy string, endpoints ...string) githubPolicyStoreModel {
+	var allowedEndpoints []attr.Value
+	for _, endpoint := range endpoints {
+		allowedEndpoints = append(allowedEndpoints, types.StringValue(endpoint))
+	}
+	return githubPolicyStoreModel{
+		ID:                    types.StringValue("test-org:::test-policy"),
+		Owner:                 types.StringValue("test-org"),
+		PolicyName:            types.StringValue("test-policy"),
+		EgressPolicy:          types.StringValue(egressPolicy),
+		AllowedEndpoints:      types.SetValueMust(types.StringType, allowedEndpoints),
+		DisableTelemetry:      types.BoolValue(false),
+		DisableSudo:           types.BoolValue(false),
+		DisableFileMonitoring: types.BoolValue(false),
+		Lockdown:              types.ObjectNull(lockdownAttrTypes()),
+	}
+}
+
+func TestGithubPolicyStoreResource_UpdateUsesServerState(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := &githubPolicyStoreResource{}
+	schemaResp := resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, state.Set(ctx, testPolicyStoreModel("audit", "github.com:443")).HasError())
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(ctx, testPolicyStoreModel("block", "github.com:443", "api.github.com:443")).HasError())
+
+	mockClient := &stepsecurityapi.MockStepSecurityClient{}
+	mockClient.On("UpdateGitHubPolicyStorePolicy", mock.Anything, mock.MatchedBy(func(p *stepsecurityapi.GitHubPolicyStorePolicy) bool {
+		return p.EgressPolicy == "block" && len(p.AllowedEndpoints) == 2
+	})).Return(nil).Once()
+	// The server normalises the endpoint list; state must reflect that.
+	mockClient.On("GetGitHubPolicyStorePolicy", mock.Anything, "test-org", "test-policy").Return(&stepsecurityapi.GitHubPolicyStorePolicy{
+		Owner:            "test-org",
+		PolicyName:       "test-policy",
+		EgressPolicy:     "block",
+		AllowedEndpoints: []string{"api.github.com:443", "github.com:443"},
+	}, nil).Once()
+	r.client = mockClient
+
+	resp := &resource.UpdateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: state}, resp)
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+	mockClient.AssertNotCalled(t, "CreateGitHubPolicyStorePolicy", mock.Anything, mock.Anything)
+	mockClient.AssertExpectations(t)
+
+	var got githubPolicyStoreModel
+	require.False(t, resp.State.Get(ctx, &got).HasError())
+	assert.Equal(t, testPolicyStoreModel("block", "api.github.com:443", "github.com:443"), got)
+}
+
+func TestGithubPolicyStoreResource_RenameRequiresReplace(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := &githubPolicyStoreResource{}
+	schemaResp := resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	testCases := []struct {
+		attribute string
+		rename    func(*githubPolicyStoreModel)
+	}{
+		{attribute: "owner", rename: func(m *githubPolicyStoreModel) { m.Owner = types.StringValue("other-org") }},
+		{attribute: "policy_name", rename: func(m *githubPolicyStoreModel) { m.PolicyName = types.StringValue("renamed-policy") }},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.attribute, func(t *testing.T) {
+			t.Parallel()
+
+			stateModel := testPolicyStoreModel("audit", "github.com:443")
+			planModel := stateModel
+			tc.rename(&planModel)
+
+			state := tfsdk.State{Schema: schemaResp.Schema}
+			require.False(t, state.Set(ctx, stateModel).HasError())
+			plan := tfsdk.Plan{Schema: schemaResp.Schema}
+			require.False(t, plan.Set(ctx, planModel).HasError())
+
+			attrPath := path.Root(tc.attribute)
+			var stateValue, planValue types.String
+			require.False(t, state.GetAttribute(ctx, attrPath, &stateValue).HasError())
+			require.False(t, plan.GetAttribute(ctx, attrPath, &planValue).HasError())
+
+			req := planmodifier.StringRequest{
+				Path:        attrPath,
+				State:       state,
+				StateValue:  stateValue,
+				Plan:        plan,
+				PlanValue:   planValue,
+				ConfigValue: planValue,
+			}
+			resp := &planmodifier.StringResponse{PlanValue: planValue}
+			for _, modifier := range schemaResp.Schema.Attributes[tc.attribute].(schema.StringAttribute).PlanModifiers {
+				modifier.PlanModifyString(ctx, req, resp)
+			}
+
+			// Renaming must replace the policy rather than update one that does not exist yet.
+			assert.True(t, resp.RequiresReplace)
+		})
+	}
+}
+
+func TestGithubPolicyStoreResource_ReadDetectsDrift(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		policy   *stepsecurityapi.GitHubPolicyStorePolicy
+		err      error
+		expected *githubPolicyStoreModel
+	}{
+		{
+			name: "endpoints_and_lockdown_changed",
+			policy: &stepsecurityapi.GitHubPolicyStorePolicy{
+				Owner:            "test-org",
+				PolicyName:       "test-policy",
+				EgressPolicy:     "audit",
+				AllowedEndpoints: []string{"github.com:443", "evil.example.com:443"},
+				Lockdown:         &stepsecurityapi.LockdownConfig{Enabled: true, Detections: []string{"Reverse-Shell"}},
+			},
+			expected: func() *githubPolicyStoreModel {
+				m := testPolicyStoreModel("audit", "github.com:443", "evil.example.com:443")
+				m.Lockdown = types.ObjectValueMust(lockdownAttrTypes(), map[string]attr.Value{
+					"enabled":                   types.BoolValue(true),
+					"privileged_container":      types.BoolValue(false),
+					"runner_worker_memory_read": types.BoolValue(false),
+					"reverse_shell":             types.BoolValue(true),
+				})
+				return &m
+			}(),
+		},
+		{
+			name: "disabled_lockdown_stays_null",
+			policy: &stepsecurityapi.GitHubPolicyStorePolicy{
+				Owner:            "test-org",
+				PolicyName:       "test-policy",
+				EgressPolicy:     "audit",
+				AllowedEndpoints: []string{"github.com:443"},
+				Lockdown:         &stepsecurityapi.LockdownConfig{},
+			},
+			expected: func() *githubPolicyStoreModel {
+				m := testPolicyStoreModel("audit", "github.com:443")
+				return &m
+			}(),
+		},
+		{
+			name: "deleted_out_of_band",
+			err:  fmt.Errorf("failed to get config: %w", &stepsecurityapi.APIError{StatusCode: http.StatusNotFound, Body: "not found"}),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			r := &githubPolicyStoreResource{}
+			schemaResp := resource.SchemaResponse{}
+			r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+			require.False(t, schemaResp.Diagnostics.HasError())
+
+			state := tfsdk.State{Schema: schemaResp.Schema}
+			require.False(t, state.Set(ctx, testPolicyStoreModel("audit", "github.com:443")).HasError())
+
+			mockClient := &stepsecurityapi.MockStepSecurityClient{}
+			mockClient.On("GetGitHubPolicyStorePolicy", mock.Anything, "test-org", "test-policy").Return(tc.policy, tc.err).Once()
+			r.client = mockClient
+
+			resp := &resource.ReadResponse{State: state}
+			r.Read(ctx, resource.ReadRequest{State: state}, resp)
+			require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+
+			if tc.expected == nil {
+				assert.True(t, resp.State.Raw.IsNull())
+				return
+			}
+			var got githubPolicyStoreModel
+			require.False(t, resp.State.Get(ctx, &got).HasError())
+			assert.Equal(t, *tc.expected, got)
+		})
+	}
+}
+
+func TestGithubPolicyStoreResource_ValidateConfig(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name         string
+		egressPolicy string
+		endpoints    types.Set
+		expectWarn   bool
+	}{
+		{
+			name:         "audit_with_default_endpoints",
+			egressPolicy: "audit",
+			endpoints:    types.SetNull(types.StringType),
+		},
+		{
+			name:         "block_with_default_endpoints",
+			egressPolicy: "block",
+			endpoints:    types.SetNull(types.StringType),
+			expectWarn:   true,
+		},
+		{
+			name:         "block_without_github",
+			egressPolicy: "block",
+			endpoints:    types.SetValueMust(types.StringType, []attr.Value{types.StringValue("registry.npmjs.org:443")}),
+			expectWarn:   true,
+		},
+		{
+			name:         "block_with_required_endpoints",
+			egressPolicy: "block",
+			endpoints: types.SetValueMust(types.StringType, []attr.Value{
+				types.StringValue("github.com:443"),
+				types.StringValue("*.github.com:443"),
+			}),
+		},
+		{
+			name:         "block_with_unknown_endpoints",
+			egressPolicy: "block",
+			endpoints:    types.SetUnknown(types.StringType),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			r := &githubPolicyStoreResource{}
+			schemaResp := resource.SchemaResponse{}
+			r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+			require.False(t, schemaResp.Diagnostics.HasError())
+
+			model := testPolicyStoreModel(tc.egressPolicy)
+			model.AllowedEndpoints = tc.endpoints
+			plan := tfsdk.Plan{Schema: schemaResp.Schema}
+			require.False(t, plan.Set(ctx, model).HasError())
+
+			resp := &resource.ValidateConfigResponse{}
+			r.ValidateConfig(ctx, resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}}, resp)
+			require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+			assert.Equal(t, tc.expectWarn, resp.Diagnostics.WarningsCount() > 0, "diags: %v", resp.Diagnostics)
+		})
+	}
+}
+
 func TestGithubPolicyStoreResource_GetPolicy(t *testing.T) {
 	t.Parallel()
 
@@ -591,7 +852,7 @@ func TestGithubPolicyStoreResource_GetPolicy(t *testing.T) {
 				Owner:        types.StringValue("tf-acc-test"),
 				PolicyName:   types.StringValue("test-policy"),
 				EgressPolicy: types.StringValue("audit"),
-				AllowedEndpoints: types.ListValueMust(
+				AllowedEndpoints: types.SetValueMust(
 					types.StringType,
 					[]attr.Value{types.StringValue("github.com:443")},
 				),
@@ -615,7 +876,7 @@ func TestGithubPolicyStoreResource_GetPolicy(t *testing.T) {
 				Owner:        types.StringValue("tf-acc-test"),
 				PolicyName:   types.StringValue("test-policy"),
 				EgressPolicy: types.StringValue("block"),
-				AllowedEndpoints: types.ListValueMust(
+				AllowedEndpoints: types.SetValueMust(
 					types.StringType,
 					[]attr.Value{
 						types.StringValue("github.com:443"),
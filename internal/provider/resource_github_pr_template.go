@@ -3,17 +3,45 @@ package provider
 import (
 	"context"
 	"fmt"
+	"maps"
+	"regexp"
 
 	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+// maxPRBranchPrefixLength leaves room in the branch name for the part StepSecurity
+// generates.
+const maxPRBranchPrefixLength = 100
+
+var (
+	// githubUsernamePattern matches a GitHub username: up to 39 alphanumeric
+	// characters or single hyphens, not starting or ending with a hyphen.
+	githubUsernamePattern = regexp.MustCompile(`^[A-Za-z0-9](?:-?[A-Za-z0-9]){0,38}$`)
+	// githubTeamSlugPattern matches the slug of a GitHub team.
+	githubTeamSlugPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+	// prBranchPrefixPattern matches a branch name prefix git accepts.
+	prBranchPrefixPattern = regexp.MustCompile(`^[A-Za-z0-9_-](?:[A-Za-z0-9_-]|\.[A-Za-z0-9_/-]|/[A-Za-z0-9_-])*\.?/?$`)
+	// commitTrailerIdentityPattern matches the "Name <email>" identity of a commit trailer.
+	commitTrailerIdentityPattern = regexp.MustCompile(`^[^<>\n]*[^<>\s] <[^<>\s@]+@[^<>\s@]+>$`)
+)
+
+func githubUsernameValidator() validator.String {
+	return stringvalidator.RegexMatches(githubUsernamePattern, "must be a GitHub username")
+}
+
 // Ensure the implementation satisfies the expected interfaces.
 var (
 	_ resource.Resource                = &githubPRTemplateResource{}
@@ -61,7 +89,7 @@ func (r *githubPRTemplateResource) Metadata(_ context.Context, req resource.Meta
 // Schema defines the schema for the resource.
 func (r *githubPRTemplateResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "Manages PR template for policy-driven PRs in a GitHub organization.",
+		Description: "Manages PR template for policy-driven PRs in a GitHub organization. Destroying the resource restores StepSecurity's default template, which is available through the `stepsecurity_github_pr_template_default` data source. If StepSecurity cannot reset the template, the resource is removed from the state with a warning and the template stays in place.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
@@ -77,22 +105,106 @@ func (r *githubPRTemplateResource) Schema(_ context.Context, _ resource.SchemaRe
 				},
 				Description: "The owner/organization name for the PR template.",
 			},
-			"title": schema.StringAttribute{
-				Required:    true,
-				Description: "The title template for policy-driven PRs.",
+		},
+	}
+	maps.Copy(resp.Schema.Attributes, prTemplateSchemaAttributes())
+}
+
+// prTemplateSchemaAttributes returns the template attributes shared by the owner and
+// repository PR template resources.
+func prTemplateSchemaAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"title": schema.StringAttribute{
+			Required:    true,
+			Description: fmt.Sprintf("The title template for policy-driven PRs. At most %d characters.", maxPRTitleLength),
+			Validators: []validator.String{
+				stringvalidator.LengthBetween(1, maxPRTitleLength),
+				prTemplatePlaceholdersValidator{},
+			},
+		},
+		"summary": schema.StringAttribute{
+			Required: true,
+			Description: "The summary template for policy-driven PRs. " + stepsecurityapi.PRTemplateSecurityFixesPlaceholder +
+				" is replaced with the security fixes of the PR; other {{...}} tokens are rejected. GitHub Actions expressions such as ${{ github.ref }} are kept as is.",
+			Validators: []validator.String{
+				prTemplatePlaceholdersValidator{requireSecurityFixes: true},
+			},
+		},
+		"commit_message": schema.StringAttribute{
+			Required:    true,
+			Description: fmt.Sprintf("The commit message template for policy-driven PRs. At most %d characters.", maxPRCommitMessageLength),
+			Validators: []validator.String{
+				stringvalidator.LengthBetween(1, maxPRCommitMessageLength),
+				prTemplatePlaceholdersValidator{},
+			},
+		},
+		"rendered_preview": schema.StringAttribute{
+			Computed:    true,
+			Description: "The body of a sample policy-driven PR rendered from summary, to review the template before it is applied.",
+			PlanModifiers: []planmodifier.String{
+				renderedPreviewPlanModifier{},
+			},
+		},
+		"labels": schema.ListAttribute{
+			ElementType: types.StringType,
+			Optional:    true,
+			Description: "List of labels to apply to policy-driven PRs.",
+		},
+		"reviewers": schema.SetAttribute{
+			ElementType: types.StringType,
+			Optional:    true,
+			Description: "GitHub usernames to request reviews of policy-driven PRs from.",
+			Validators: []validator.Set{
+				setvalidator.SizeAtLeast(1),
+				setvalidator.ValueStringsAre(githubUsernameValidator()),
+			},
+		},
+		"team_reviewers": schema.SetAttribute{
+			ElementType: types.StringType,
+			Optional:    true,
+			Description: "Slugs of the owner's teams to request reviews of policy-driven PRs from.",
+			Validators: []validator.Set{
+				setvalidator.SizeAtLeast(1),
+				setvalidator.ValueStringsAre(stringvalidator.RegexMatches(githubTeamSlugPattern, "must be a team slug, e.g. platform-security")),
 			},
-			"summary": schema.StringAttribute{
-				Required:    true,
-				Description: "The summary template for policy-driven PRs.",
+		},
+		"assignees": schema.SetAttribute{
+			ElementType: types.StringType,
+			Optional:    true,
+			Description: "GitHub usernames to assign policy-driven PRs to.",
+			Validators: []validator.Set{
+				setvalidator.SizeAtLeast(1),
+				setvalidator.ValueStringsAre(githubUsernameValidator()),
 			},
-			"commit_message": schema.StringAttribute{
-				Required:    true,
-				Description: "The commit message template for policy-driven PRs.",
+		},
+		"draft": schema.BoolAttribute{
+			Optional:    true,
+			Computed:    true,
+			Default:     booldefault.StaticBool(false),
+			Description: "Whether policy-driven PRs are opened as drafts. Defaults to false.",
+		},
+		"branch_prefix": schema.StringAttribute{
+			Optional:    true,
+			Description: "Prefix of the head branch of policy-driven PRs, e.g. security/. StepSecurity's default naming is used when unset.",
+			Validators: []validator.String{
+				stringvalidator.LengthBetween(1, maxPRBranchPrefixLength),
+				stringvalidator.RegexMatches(prBranchPrefixPattern, "must be a valid git branch name prefix, e.g. security/"),
 			},
-			"labels": schema.ListAttribute{
-				ElementType: types.StringType,
-				Optional:    true,
-				Description: "List of labels to apply to policy-driven PRs.",
+		},
+		"sign_off": schema.BoolAttribute{
+			Optional:    true,
+			Computed:    true,
+			Default:     booldefault.StaticBool(false),
+			Description: "Whether a Signed-off-by trailer is appended to the commit message, for repositories that require the Developer Certificate of Origin. Defaults to false.",
+		},
+		"co_authors": schema.ListAttribute{
+			ElementType: types.StringType,
+			Optional:    true,
+			Description: "Co-authors credited with a Co-authored-by trailer on the commit, formatted as `Name <email>`.",
+			Validators: []validator.List{
+				listvalidator.SizeAtLeast(1),
+				listvalidator.UniqueValues(),
+				listvalidator.ValueStringsAre(stringvalidator.RegexMatches(commitTrailerIdentityPattern, "must be formatted as `Name <email>`")),
 			},
 		},
 	}
@@ -122,12 +234,20 @@ func (r *githubPRTemplateResource) ImportState(ctx context.Context, req resource
 }
 
 type githubPRTemplateModel struct {
-	ID            types.String `tfsdk:"id"`
-	Owner         types.String `tfsdk:"owner"`
-	Title         types.String `tfsdk:"title"`
-	Summary       types.String `tfsdk:"summary"`
-	CommitMessage types.String `tfsdk:"commit_message"`
-	Labels        types.List   `tfsdk:"labels"`
+	ID              types.String `tfsdk:"id"`
+	Owner           types.String `tfsdk:"owner"`
+	Title           types.String `tfsdk:"title"`
+	Summary         types.String `tfsdk:"summary"`
+	CommitMessage   types.String `tfsdk:"commit_message"`
+	Labels          types.List   `tfsdk:"labels"`
+	Reviewers       types.Set    `tfsdk:"reviewers"`
+	TeamReviewers   types.Set    `tfsdk:"team_reviewers"`
+	Assignees       types.Set    `tfsdk:"assignees"`
+	Draft           types.Bool   `tfsdk:"draft"`
+	BranchPrefix    types.String `tfsdk:"branch_prefix"`
+	SignOff         types.Bool   `tfsdk:"sign_off"`
+	CoAuthors       types.List   `tfsdk:"co_authors"`
+	RenderedPreview types.String `tfsdk:"rendered_preview"`
 }
 
 // Create creates the resource and sets the initial Terraform state.
@@ -140,23 +260,13 @@ func (r *githubPRTemplateResource) Create(ctx context.Context, req resource.Crea
 	}
 
 	// Convert Terraform types to Go types for API
-	var labels []string
-	if !plan.Labels.IsNull() && !plan.Labels.IsUnknown() {
-		elements := plan.Labels.Elements()
-		labels = make([]string, len(elements))
-		for i, elem := range elements {
-			labels[i] = elem.(types.String).ValueString()
-		}
+	template, diags := prTemplateFromModel(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	// Create PR template in StepSecurity
-	template := stepsecurityapi.GitHubPRTemplate{
-		Title:         plan.Title.ValueString(),
-		Summary:       plan.Summary.ValueString(),
-		CommitMessage: plan.CommitMessage.ValueString(),
-		Labels:        labels,
-	}
-
 	err := r.client.UpdateGitHubPRTemplate(ctx, plan.Owner.ValueString(), template)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -168,6 +278,7 @@ func (r *githubPRTemplateResource) Create(ctx context.Context, req resource.Crea
 
 	// Set the ID (use owner as the unique identifier)
 	plan.ID = types.StringValue(plan.Owner.ValueString())
+	plan.RenderedPreview = types.StringValue(renderPRTemplatePreview(plan.Summary.ValueString()))
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
@@ -198,25 +309,7 @@ func (r *githubPRTemplateResource) Read(ctx context.Context, req resource.ReadRe
 
 	// Update state with refreshed data
 	state.ID = types.StringValue(state.Owner.ValueString())
-	state.Title = types.StringValue(template.Title)
-	state.Summary = types.StringValue(template.Summary)
-	state.CommitMessage = types.StringValue(template.CommitMessage)
-
-	// Convert labels to Terraform list
-	if len(template.Labels) > 0 {
-		labelElements := make([]types.String, len(template.Labels))
-		for i, label := range template.Labels {
-			labelElements[i] = types.StringValue(label)
-		}
-		labelList, diagsLabels := types.ListValueFrom(ctx, types.StringType, labelElements)
-		resp.Diagnostics.Append(diagsLabels...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		state.Labels = labelList
-	} else {
-		state.Labels = types.ListNull(types.StringType)
-	}
+	updatePRTemplateModel(&state, template)
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, state)
@@ -236,23 +329,13 @@ func (r *githubPRTemplateResource) Update(ctx context.Context, req resource.Upda
 	}
 
 	// Convert Terraform types to Go types for API
-	var labels []string
-	if !plan.Labels.IsNull() && !plan.Labels.IsUnknown() {
-		elements := plan.Labels.Elements()
-		labels = make([]string, len(elements))
-		for i, elem := range elements {
-			labels[i] = elem.(types.String).ValueString()
-		}
+	template, diags := prTemplateFromModel(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	// Update PR template in StepSecurity
-	template := stepsecurityapi.GitHubPRTemplate{
-		Title:         plan.Title.ValueString(),
-		Summary:       plan.Summary.ValueString(),
-		CommitMessage: plan.CommitMessage.ValueString(),
-		Labels:        labels,
-	}
-
 	err := r.client.UpdateGitHubPRTemplate(ctx, plan.Owner.ValueString(), template)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -264,6 +347,7 @@ func (r *githubPRTemplateResource) Update(ctx context.Context, req resource.Upda
 
 	// Set the ID (use owner as the unique identifier)
 	plan.ID = types.StringValue(plan.Owner.ValueString())
+	plan.RenderedPreview = types.StringValue(renderPRTemplatePreview(plan.Summary.ValueString()))
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
@@ -284,6 +368,13 @@ func (r *githubPRTemplateResource) Delete(ctx context.Context, req resource.Dele
 
 	// Delete PR template from StepSecurity (resets to default values)
 	err := r.client.DeleteGitHubPRTemplate(ctx, state.Owner.ValueString())
+	if stepsecurityapi.IsNotFound(err) {
+		resp.Diagnostics.AddWarning(
+			"GitHub PR Template Not Reset",
+			fmt.Sprintf("StepSecurity could not reset the PR template of %s to its default, so the template stays in place after it is removed from the Terraform state: %s", state.Owner.ValueString(), err),
+		)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Delete GitHub PR Template",
@@ -292,3 +383,73 @@ func (r *githubPRTemplateResource) Delete(ctx context.Context, req resource.Dele
 		return
 	}
 }
+
+// prTemplateFromModel converts the resource model to the API template.
+func prTemplateFromModel(ctx context.Context, model githubPRTemplateModel) (stepsecurityapi.GitHubPRTemplate, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	template := stepsecurityapi.GitHubPRTemplate{
+		Title:         model.Title.ValueString(),
+		Summary:       model.Summary.ValueString(),
+		CommitMessage: model.CommitMessage.ValueString(),
+		Draft:         model.Draft.ValueBool(),
+		BranchPrefix:  model.BranchPrefix.ValueString(),
+		SignOff:       model.SignOff.ValueBool(),
+	}
+	for _, field := range []struct {
+		value  stringCollection
+		target *[]string
+	}{
+		{model.Labels, &template.Labels},
+		{model.Reviewers, &template.Reviewers},
+		{model.TeamReviewers, &template.TeamReviewers},
+		{model.Assignees, &template.Assignees},
+		{model.CoAuthors, &template.CoAuthors},
+	} {
+		if !field.value.IsNull() && !field.value.IsUnknown() {
+			diags.Append(field.value.ElementsAs(ctx, field.target, false)...)
+		}
+	}
+
+	return template, diags
+}
+
+// updatePRTemplateModel sets the template attributes of model from the API template.
+// Empty lists are null, matching configurations that leave them unset.
+func updatePRTemplateModel(model *githubPRTemplateModel, template *stepsecurityapi.GitHubPRTemplate) {
+	model.Title = types.StringValue(template.Title)
+	model.Summary = types.StringValue(template.Summary)
+	model.CommitMessage = types.StringValue(template.CommitMessage)
+	model.Labels = optionalStringList(template.Labels)
+	model.Reviewers = optionalStringSet(template.Reviewers)
+	model.TeamReviewers = optionalStringSet(template.TeamReviewers)
+	model.Assignees = optionalStringSet(template.Assignees)
+	model.Draft = types.BoolValue(template.Draft)
+	model.BranchPrefix = optionalStringValue(template.BranchPrefix)
+	model.SignOff = types.BoolValue(template.SignOff)
+	model.CoAuthors = optionalStringList(template.CoAuthors)
+	model.RenderedPreview = types.StringValue(renderPRTemplatePreview(template.Summary))
+}
+
+// stringCollection is a types.List or types.Set of strings.
+type stringCollection interface {
+	IsNull() bool
+	IsUnknown() bool
+	ElementsAs(ctx context.Context, target any, allowUnhandled bool) diag.Diagnostics
+}
+
+// optionalStringList converts values to a list, which is null when there are none.
+func optionalStringList(values []string) types.List {
+	if len(values) == 0 {
+		return types.ListNull(types.StringType)
+	}
+	return types.ListValueMust(types.StringType, stringsToAttrValues(values))
+}
+
+// optionalStringSet converts values to a set, which is null when there are none.
+func optionalStringSet(values []string) types.Set {
+	if len(values) == 0 {
+		return types.SetNull(types.StringType)
+	}
+	return types.SetValueMust(types.StringType, stringsToAttrValues(values))
+}
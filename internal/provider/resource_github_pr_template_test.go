@@ -3,6 +3,8 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -10,6 +12,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	res "github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
 )
@@ -103,7 +107,7 @@ func TestGitHubPRTemplateResource_Schema(t *testing.T) {
 	}
 
 	// Test required attributes
-	expectedAttrs := []string{"id", "owner", "title", "summary", "commit_message", "labels"}
+	expectedAttrs := []string{"id", "owner", "title", "summary", "commit_message", "labels", "rendered_preview"}
 	for _, attr := range expectedAttrs {
 		if _, exists := resp.Schema.Attributes[attr]; !exists {
 			t.Errorf("Expected attribute %s not found in schema", attr)
@@ -151,6 +155,13 @@ func TestGitHubPRTemplateResource_Schema(t *testing.T) {
 			t.Error("Expected id attribute to be computed")
 		}
 	}
+
+	// Test that rendered_preview is computed
+	if previewAttr, exists := resp.Schema.Attributes["rendered_preview"]; exists {
+		if !previewAttr.IsComputed() {
+			t.Error("Expected rendered_preview attribute to be computed")
+		}
+	}
 }
 
 func TestGitHubPRTemplateResource_Configure(t *testing.T) {
@@ -402,6 +413,159 @@ func TestGitHubPRTemplateResource_StateConversion(t *testing.T) {
 	}
 }
 
+func TestPRTemplateFromModel(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	model := githubPRTemplateModel{
+		Owner:         types.StringValue("test-org"),
+		Title:         types.StringValue("ci: apply security best practices"),
+		Summary:       types.StringValue("{{STEPSECURITY_SECURITY_FIXES}}"),
+		CommitMessage: types.StringValue("ci: apply security best practices"),
+		Labels:        types.ListValueMust(types.StringType, stringsToAttrValues([]string{"security"})),
+		Reviewers:     types.SetValueMust(types.StringType, stringsToAttrValues([]string{"octocat"})),
+		TeamReviewers: types.SetValueMust(types.StringType, stringsToAttrValues([]string{"platform-security"})),
+		Assignees:     types.SetNull(types.StringType),
+		Draft:         types.BoolValue(true),
+		BranchPrefix:  types.StringValue("security/"),
+		SignOff:       types.BoolValue(true),
+		CoAuthors:     types.ListValueMust(types.StringType, stringsToAttrValues([]string{"Mona Lisa <mona@example.com>"})),
+	}
+
+	template, diags := prTemplateFromModel(ctx, model)
+	require.False(t, diags.HasError(), "unexpected diags: %v", diags)
+	assert.Equal(t, stepsecurityapi.GitHubPRTemplate{
+		Title:         "ci: apply security best practices",
+		Summary:       "{{STEPSECURITY_SECURITY_FIXES}}",
+		CommitMessage: "ci: apply security best practices",
+		Labels:        []string{"security"},
+		Reviewers:     []string{"octocat"},
+		TeamReviewers: []string{"platform-security"},
+		Draft:         true,
+		BranchPrefix:  "security/",
+		SignOff:       true,
+		CoAuthors:     []string{"Mona Lisa <mona@example.com>"},
+	}, template)
+
+	var got githubPRTemplateModel
+	updatePRTemplateModel(&got, &template)
+	assert.True(t, got.Assignees.IsNull())
+	assert.Equal(t, types.StringValue("security/"), got.BranchPrefix)
+	assert.True(t, got.Reviewers.Equal(model.Reviewers))
+	assert.True(t, got.CoAuthors.Equal(model.CoAuthors))
+	assert.Equal(t, prTemplatePreviewSecurityFixes, got.RenderedPreview.ValueString())
+}
+
+func TestUpdatePRTemplateModel_Unset(t *testing.T) {
+	t.Parallel()
+
+	var model githubPRTemplateModel
+	updatePRTemplateModel(&model, &stepsecurityapi.GitHubPRTemplate{Title: "Security Update", Labels: []string{}})
+
+	assert.True(t, model.Labels.IsNull())
+	assert.True(t, model.Reviewers.IsNull())
+	assert.True(t, model.TeamReviewers.IsNull())
+	assert.True(t, model.CoAuthors.IsNull())
+	assert.True(t, model.BranchPrefix.IsNull())
+	assert.Equal(t, types.BoolValue(false), model.Draft)
+	assert.Equal(t, types.BoolValue(false), model.SignOff)
+}
+
+func TestGitHubPRTemplateResource_Delete(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		deleteErr     error
+		expectedError bool
+		expectedWarn  bool
+	}{
+		{name: "reset"},
+		{
+			name:         "reset_unsupported",
+			deleteErr:    fmt.Errorf("failed to delete PR template: %w", &stepsecurityapi.APIError{StatusCode: http.StatusNotFound, Body: "not found"}),
+			expectedWarn: true,
+		},
+		{
+			name:          "reset_fails",
+			deleteErr:     fmt.Errorf("failed to delete PR template: %w", &stepsecurityapi.APIError{StatusCode: http.StatusInternalServerError, Body: "internal error"}),
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockClient := &stepsecurityapi.MockStepSecurityClient{}
+			mockClient.On("DeleteGitHubPRTemplate", mock.Anything, "test-org").Return(tc.deleteErr).Once()
+			r := &githubPRTemplateResource{client: mockClient}
+
+			model := githubPRTemplateModel{
+				ID:              types.StringValue("test-org"),
+				Owner:           types.StringValue("test-org"),
+				RenderedPreview: types.StringNull(),
+			}
+			updatePRTemplateModel(&model, &stepsecurityapi.GitHubPRTemplate{Title: "Security Update"})
+			resp := &resource.DeleteResponse{}
+			r.Delete(context.Background(), resource.DeleteRequest{State: testResourceState(t, r, model)}, resp)
+
+			mockClient.AssertExpectations(t)
+			assert.Equal(t, tc.expectedError, resp.Diagnostics.HasError(), "diags: %v", resp.Diagnostics)
+			assert.Equal(t, tc.expectedWarn, resp.Diagnostics.WarningsCount() > 0)
+		})
+	}
+}
+
+func TestGitHubPRTemplateResource_Patterns(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		pattern *regexp.Regexp
+		valid   []string
+		invalid []string
+	}{
+		{
+			name:    "username",
+			pattern: githubUsernamePattern,
+			valid:   []string{"octocat", "mona-lisa", "a", "a1-b2-c3"},
+			invalid: []string{"-octocat", "octocat-", "mona--lisa", "mona_lisa", "org/team", strings.Repeat("a", 40)},
+		},
+		{
+			name:    "team_slug",
+			pattern: githubTeamSlugPattern,
+			valid:   []string{"platform-security", "team_1", "a.b"},
+			invalid: []string{"my-org/platform-security", "-team", "Platform Security"},
+		},
+		{
+			name:    "branch_prefix",
+			pattern: prBranchPrefixPattern,
+			valid:   []string{"security/", "stepsecurity-", "bots/security/", "fix.", "v1.2/"},
+			invalid: []string{"/security", ".security", "security//", "a..b", "a/.b", "security fixes/", "a~b"},
+		},
+		{
+			name:    "co_author",
+			pattern: commitTrailerIdentityPattern,
+			valid:   []string{"Mona Lisa <mona@example.com>", "octocat <1234+octocat@users.noreply.github.com>"},
+			invalid: []string{"mona@example.com", "<mona@example.com>", "Mona Lisa <mona>", "Mona Lisa<mona@example.com>", "Mona <mona@example.com> extra"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			for _, value := range tc.valid {
+				assert.True(t, tc.pattern.MatchString(value), "expected %q to be valid", value)
+			}
+			for _, value := range tc.invalid {
+				assert.False(t, tc.pattern.MatchString(value), "expected %q to be invalid", value)
+			}
+		})
+	}
+}
+
 // Test configuration helpers
 func testAccGitHubPRTemplateResourceConfig(owner string) string {
 	return fmt.Sprintf(`
@@ -0,0 +1,364 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &githubRepoNotificationOverrideResource{}
+	_ resource.ResourceWithConfigure   = &githubRepoNotificationOverrideResource{}
+	_ resource.ResourceWithImportState = &githubRepoNotificationOverrideResource{}
+)
+
+// NewGithubRepoNotificationOverrideResource is a helper function to simplify the provider implementation.
+func NewGithubRepoNotificationOverrideResource() resource.Resource {
+	return &githubRepoNotificationOverrideResource{}
+}
+
+// githubRepoNotificationOverrideResource manages the notification override of a
+// repository, which adjusts the owner's notification settings for that repository.
+type githubRepoNotificationOverrideResource struct {
+	client stepsecurityapi.Client
+}
+
+type githubRepoNotificationOverrideModel struct {
+	ID                   types.String `tfsdk:"id"`
+	Owner                types.String `tfsdk:"owner"`
+	Repo                 types.String `tfsdk:"repo"`
+	Events               types.Map    `tfsdk:"events"`
+	DestinationIDs       types.Set    `tfsdk:"destination_ids"`
+	InheritOwnerChannels types.Bool   `tfsdk:"inherit_owner_channels"`
+	EffectiveEvents      types.Set    `tfsdk:"effective_events"`
+	EffectiveChannels    types.Set    `tfsdk:"effective_channels"`
+}
+
+// Metadata returns the resource type name.
+func (r *githubRepoNotificationOverrideResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_github_repo_notification_override"
+}
+
+// Schema defines the schema for the resource.
+func (r *githubRepoNotificationOverrideResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Overrides the notification settings of `stepsecurity_github_org_notification_settings` for a single repository, " +
+			"e.g. to alert on more events for release pipelines. `effective_events` and `effective_channels` show the merged configuration that applies to the repository.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the override, `owner/repo`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"owner": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The GitHub organization or user that owns the repository.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"repo": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The repository name.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"events": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.BoolType,
+				MarkdownDescription: "Turns events on (`true`) or off (`false`) for the repository. Events that are not listed follow the owner's settings. Valid keys are `" +
+					strings.Join(stepsecurityapi.NotificationEvents, "`, `") + "`.",
+				Validators: []validator.Map{
+					mapvalidator.SizeAtLeast(1),
+					mapvalidator.KeysAre(stringvalidator.OneOf(stepsecurityapi.NotificationEvents...)),
+				},
+			},
+			"destination_ids": schema.SetAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "IDs of `stepsecurity_github_notification_destination` resources that receive the repository's events in addition to the owner's channels.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"inherit_owner_channels": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Whether the owner's Slack, Teams and email channels keep receiving the repository's events. Defaults to `true`.",
+			},
+			"effective_events": schema.SetAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The events notified for the repository: the owner's events with `events` applied.",
+			},
+			"effective_channels": schema.SetAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				MarkdownDescription: "The owner's channels, `slack`, `teams` and `email`, that receive the repository's events. " +
+					"Empty when `inherit_owner_channels` is `false`.",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *githubRepoNotificationOverrideResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(stepsecurityapi.Client)
+	if !ok || client == nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected stepsecurityapi.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *githubRepoNotificationOverrideResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan githubRepoNotificationOverrideModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.put(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *githubRepoNotificationOverrideResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state githubRepoNotificationOverrideModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found, diags := r.read(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		tflog.Info(ctx, "Repo notification override no longer exists, removing from state", map[string]any{
+			"owner": state.Owner.ValueString(),
+			"repo":  state.Repo.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *githubRepoNotificationOverrideResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan githubRepoNotificationOverrideModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.put(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *githubRepoNotificationOverrideResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state githubRepoNotificationOverrideModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteRepoNotificationOverride(ctx, state.Owner.ValueString(), state.Repo.ValueString())
+	if err != nil && !stepsecurityapi.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error deleting repo notification override",
+			"Could not delete the notification override of "+state.ID.ValueString()+": "+err.Error(),
+		)
+	}
+}
+
+// ImportState imports the resource using an owner/repo identifier.
+func (r *githubRepoNotificationOverrideResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected owner/repo, got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("owner"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("repo"), parts[1])...)
+}
+
+// put stores the override in model and refreshes model from the API.
+func (r *githubRepoNotificationOverrideResource) put(ctx context.Context, model *githubRepoNotificationOverrideModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	override := stepsecurityapi.RepoNotificationOverride{
+		InheritOwnerChannels: model.InheritOwnerChannels.ValueBool(),
+	}
+	if !model.Events.IsNull() {
+		diags.Append(model.Events.ElementsAs(ctx, &override.Events, false)...)
+	}
+	if !model.DestinationIDs.IsNull() {
+		diags.Append(model.DestinationIDs.ElementsAs(ctx, &override.DestinationIDs, false)...)
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	if err := r.client.UpdateRepoNotificationOverride(ctx, model.Owner.ValueString(), model.Repo.ValueString(), override); err != nil {
+		diags.AddError(
+			"Error updating repo notification override",
+			fmt.Sprintf("Could not update the notification override of %s/%s: %s", model.Owner.ValueString(), model.Repo.ValueString(), err),
+		)
+		return diags
+	}
+
+	found, readDiags := r.read(ctx, model)
+	diags.Append(readDiags...)
+	if !found && !diags.HasError() {
+		diags.AddError(
+			"Error reading repo notification override",
+			fmt.Sprintf("The notification override of %s/%s was not found after it was saved.", model.Owner.ValueString(), model.Repo.ValueString()),
+		)
+	}
+	return diags
+}
+
+// read refreshes model from the override and the owner's notification settings. It
+// returns false when the repository has no override.
+func (r *githubRepoNotificationOverrideResource) read(ctx context.Context, model *githubRepoNotificationOverrideModel) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	owner, repo := model.Owner.ValueString(), model.Repo.ValueString()
+
+	override, err := r.client.GetRepoNotificationOverride(ctx, owner, repo)
+	if err != nil && stepsecurityapi.IsNotFound(err) {
+		return false, diags
+	}
+	if err != nil {
+		diags.AddError(
+			"Error reading repo notification override",
+			fmt.Sprintf("Could not read the notification override of %s/%s: %s", owner, repo, err),
+		)
+		return false, diags
+	}
+
+	settings, err := r.client.GetNotificationSettings(ctx, owner)
+	if err != nil && !stepsecurityapi.IsNotFound(err) {
+		diags.AddError(
+			"Error reading notification settings",
+			fmt.Sprintf("Could not read the notification settings of %s to merge with the override of %s: %s", owner, repo, err),
+		)
+		return false, diags
+	}
+	if settings == nil {
+		settings = &stepsecurityapi.NotificationSettings{}
+	}
+
+	model.ID = types.StringValue(owner + "/" + repo)
+	model.InheritOwnerChannels = types.BoolValue(override.InheritOwnerChannels)
+	if len(override.Events) == 0 {
+		model.Events = types.MapNull(types.BoolType)
+	} else {
+		events := make(map[string]attr.Value, len(override.Events))
+		for event, enabled := range override.Events {
+			events[event] = types.BoolValue(enabled)
+		}
+		model.Events = types.MapValueMust(types.BoolType, events)
+	}
+	if len(override.DestinationIDs) == 0 {
+		model.DestinationIDs = types.SetNull(types.StringType)
+	} else {
+		model.DestinationIDs = types.SetValueMust(types.StringType, stringsToAttrValues(override.DestinationIDs))
+	}
+
+	effectiveEvents, effectiveChannels := effectiveRepoNotifications(settings, override)
+	model.EffectiveEvents = types.SetValueMust(types.StringType, stringsToAttrValues(effectiveEvents))
+	model.EffectiveChannels = types.SetValueMust(types.StringType, stringsToAttrValues(effectiveChannels))
+
+	return true, diags
+}
+
+// effectiveRepoNotifications merges the owner's settings with the override of a
+// repository, returning the events notified for the repository and the owner's
+// channels that receive them.
+func effectiveRepoNotifications(settings *stepsecurityapi.NotificationSettings, override *stepsecurityapi.RepoNotificationOverride) ([]string, []string) {
+	_, ownerEvents := notificationSettingsObjects(settings, githubNotificationChannelsModel{})
+
+	events := []string{}
+	for _, event := range stepsecurityapi.NotificationEvents {
+		enabled := ownerEvents.Attributes()[event].(types.Bool).ValueBool()
+		if value, ok := override.Events[event]; ok {
+			enabled = value
+		}
+		if enabled {
+			events = append(events, event)
+		}
+	}
+
+	channels := []string{}
+	if override.InheritOwnerChannels {
+		slackOAuth := settings.SlackNotificationMethod != nil && *settings.SlackNotificationMethod == "oauth"
+		if settings.SlackWebhookURL != nil && !slackOAuth || settings.SlackChannelID != nil && slackOAuth {
+			channels = append(channels, "slack")
+		}
+		if settings.TeamsWebhookURL != nil {
+			channels = append(channels, "teams")
+		}
+		if settings.Email != nil {
+			channels = append(channels, "email")
+		}
+	}
+	slices.Sort(channels)
+
+	return events, channels
+}
@@ -0,0 +1,257 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
+)
+
+func TestGithubRepoNotificationOverrideResource_Metadata(t *testing.T) {
+	t.Parallel()
+
+	r := &githubRepoNotificationOverrideResource{}
+	resp := &resource.MetadataResponse{}
+	r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "stepsecurity"}, resp)
+
+	assert.Equal(t, "stepsecurity_github_repo_notification_override", resp.TypeName)
+}
+
+func TestGithubRepoNotificationOverrideResource_Schema(t *testing.T) {
+	t.Parallel()
+
+	schema := testResourceSchema(t, &githubRepoNotificationOverrideResource{})
+
+	assert.True(t, schema.Attributes["owner"].IsRequired())
+	assert.True(t, schema.Attributes["repo"].IsRequired())
+	assert.True(t, schema.Attributes["events"].IsOptional())
+	assert.True(t, schema.Attributes["destination_ids"].IsOptional())
+	assert.True(t, schema.Attributes["inherit_owner_channels"].IsComputed())
+	assert.True(t, schema.Attributes["effective_events"].IsComputed())
+	assert.True(t, schema.Attributes["effective_channels"].IsComputed())
+}
+
+func TestEffectiveRepoNotifications(t *testing.T) {
+	t.Parallel()
+
+	enabled, disabled := true, false
+	settings := &stepsecurityapi.NotificationSettings{
+		SlackWebhookURL:           stringPtr("https://hooks.slack.com/services/T000/B000/XXXX"),
+		Email:                     stringPtr("security@example.com"),
+		NotifyForSecretsDetection: &enabled,
+		NotifyWhenDomainBlocked:   &enabled,
+		NotifyOnFileOverwrite:     &disabled,
+	}
+
+	testCases := []struct {
+		name             string
+		settings         *stepsecurityapi.NotificationSettings
+		override         stepsecurityapi.RepoNotificationOverride
+		expectedEvents   []string
+		expectedChannels []string
+	}{
+		{
+			name:             "inherits_owner",
+			settings:         settings,
+			override:         stepsecurityapi.RepoNotificationOverride{InheritOwnerChannels: true},
+			expectedEvents:   []string{"domain_blocked", "secrets_detected"},
+			expectedChannels: []string{"email", "slack"},
+		},
+		{
+			name:     "overrides_events",
+			settings: settings,
+			override: stepsecurityapi.RepoNotificationOverride{
+				Events:               map[string]bool{"domain_blocked": false, "file_overwrite": true},
+				InheritOwnerChannels: true,
+			},
+			expectedEvents:   []string{"file_overwrite", "secrets_detected"},
+			expectedChannels: []string{"email", "slack"},
+		},
+		{
+			name:             "no_owner_channels",
+			settings:         settings,
+			override:         stepsecurityapi.RepoNotificationOverride{},
+			expectedEvents:   []string{"domain_blocked", "secrets_detected"},
+			expectedChannels: []string{},
+		},
+		{
+			name: "slack_oauth_needs_channel",
+			settings: &stepsecurityapi.NotificationSettings{
+				SlackWebhookURL:         stringPtr("https://hooks.slack.com/services/T000/B000/XXXX"),
+				SlackNotificationMethod: stringPtr("oauth"),
+			},
+			override:         stepsecurityapi.RepoNotificationOverride{InheritOwnerChannels: true},
+			expectedEvents:   []string{},
+			expectedChannels: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			events, channels := effectiveRepoNotifications(tc.settings, &tc.override)
+			assert.Equal(t, tc.expectedEvents, events)
+			assert.Equal(t, tc.expectedChannels, channels)
+		})
+	}
+}
+
+func TestGithubRepoNotificationOverrideResource_Create(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	enabled := true
+	override := stepsecurityapi.RepoNotificationOverride{
+		Events:               map[string]bool{"file_overwrite": true},
+		DestinationIDs:       []string{"dest-123"},
+		InheritOwnerChannels: true,
+	}
+	mockClient := &stepsecurityapi.MockStepSecurityClient{}
+	mockClient.On("UpdateRepoNotificationOverride", mock.Anything, "test-org", "release", override).Return(nil)
+	mockClient.On("GetRepoNotificationOverride", mock.Anything, "test-org", "release").Return(&override, nil)
+	mockClient.On("GetNotificationSettings", mock.Anything, "test-org").Return(&stepsecurityapi.NotificationSettings{
+		TeamsWebhookURL:           stringPtr("https://example.webhook.office.com/webhookb2/abc"),
+		NotifyForSecretsDetection: &enabled,
+	}, nil)
+
+	r := &githubRepoNotificationOverrideResource{client: mockClient}
+	plan := testRepoNotificationOverrideModel()
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: testResourceSchema(t, &githubRepoNotificationOverrideResource{})}}
+	r.Create(ctx, resource.CreateRequest{Plan: testResourcePlan(t, &githubRepoNotificationOverrideResource{}, plan)}, resp)
+
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+	mockClient.AssertExpectations(t)
+
+	var state githubRepoNotificationOverrideModel
+	require.False(t, resp.State.Get(ctx, &state).HasError())
+	assert.Equal(t, "test-org/release", state.ID.ValueString())
+	assert.Equal(t, []string{"file_overwrite", "secrets_detected"}, setStrings(t, state.EffectiveEvents))
+	assert.Equal(t, []string{"teams"}, setStrings(t, state.EffectiveChannels))
+}
+
+func TestGithubRepoNotificationOverrideResource_ReadWithoutOwnerSettings(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mockClient := &stepsecurityapi.MockStepSecurityClient{}
+	mockClient.On("GetRepoNotificationOverride", mock.Anything, "test-org", "release").Return(&stepsecurityapi.RepoNotificationOverride{
+		Events: map[string]bool{"file_overwrite": true},
+	}, nil)
+	mockClient.On("GetNotificationSettings", mock.Anything, "test-org").
+		Return((*stepsecurityapi.NotificationSettings)(nil), fmt.Errorf("failed to get notification settings: %w", &stepsecurityapi.APIError{StatusCode: http.StatusNotFound, Body: "not found"}))
+
+	r := &githubRepoNotificationOverrideResource{client: mockClient}
+	state := testResourceState(t, &githubRepoNotificationOverrideResource{}, testRepoNotificationOverrideModel())
+	resp := &resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, resp)
+
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+
+	var got githubRepoNotificationOverrideModel
+	require.False(t, resp.State.Get(ctx, &got).HasError())
+	assert.True(t, got.DestinationIDs.IsNull())
+	assert.False(t, got.InheritOwnerChannels.ValueBool())
+	assert.Equal(t, []string{"file_overwrite"}, setStrings(t, got.EffectiveEvents))
+	assert.Empty(t, setStrings(t, got.EffectiveChannels))
+}
+
+func TestGithubRepoNotificationOverrideResource_ReadRemovesDeleted(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mockClient := &stepsecurityapi.MockStepSecurityClient{}
+	mockClient.On("GetRepoNotificationOverride", mock.Anything, "test-org", "release").
+		Return((*stepsecurityapi.RepoNotificationOverride)(nil), fmt.Errorf("failed to get repo notification override: %w", &stepsecurityapi.APIError{StatusCode: http.StatusNotFound, Body: "not found"}))
+
+	r := &githubRepoNotificationOverrideResource{client: mockClient}
+	state := testResourceState(t, &githubRepoNotificationOverrideResource{}, testRepoNotificationOverrideModel())
+	resp := &resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, resp)
+
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+	assert.True(t, resp.State.Raw.IsNull())
+}
+
+func TestGithubRepoNotificationOverrideResource_Delete(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mockClient := &stepsecurityapi.MockStepSecurityClient{}
+	mockClient.On("DeleteRepoNotificationOverride", mock.Anything, "test-org", "release").Return(nil)
+
+	r := &githubRepoNotificationOverrideResource{client: mockClient}
+	state := testResourceState(t, &githubRepoNotificationOverrideResource{}, testRepoNotificationOverrideModel())
+	resp := &resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, resp)
+
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGithubRepoNotificationOverrideResource_ImportState(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		importID      string
+		expectedError bool
+	}{
+		{name: "valid", importID: "test-org/release"},
+		{name: "missing_repo", importID: "test-org/", expectedError: true},
+		{name: "too_many_parts", importID: "test-org/release/extra", expectedError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			r := &githubRepoNotificationOverrideResource{}
+			schema := testResourceSchema(t, &githubRepoNotificationOverrideResource{})
+			resp := &resource.ImportStateResponse{
+				State: tfsdk.State{Schema: schema, Raw: tftypes.NewValue(schema.Type().TerraformType(ctx), nil)},
+			}
+			r.ImportState(ctx, resource.ImportStateRequest{ID: tc.importID}, resp)
+
+			if tc.expectedError {
+				require.True(t, resp.Diagnostics.HasError())
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+
+			var owner, repo types.String
+			resp.State.GetAttribute(ctx, path.Root("owner"), &owner)
+			resp.State.GetAttribute(ctx, path.Root("repo"), &repo)
+			assert.Equal(t, "test-org", owner.ValueString())
+			assert.Equal(t, "release", repo.ValueString())
+		})
+	}
+}
+
+func testRepoNotificationOverrideModel() githubRepoNotificationOverrideModel {
+	return githubRepoNotificationOverrideModel{
+		ID:    types.StringUnknown(),
+		Owner: types.StringValue("test-org"),
+		Repo:  types.StringValue("release"),
+		Events: types.MapValueMust(types.BoolType, map[string]attr.Value{
+			"file_overwrite": types.BoolValue(true),
+		}),
+		DestinationIDs:       types.SetValueMust(types.StringType, stringsToAttrValues([]string{"dest-123"})),
+		InheritOwnerChannels: types.BoolValue(true),
+		EffectiveEvents:      types.SetUnknown(types.StringType),
+		EffectiveChannels:    types.SetUnknown(types.StringType),
+	}
+}
@@ -0,0 +1,222 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &githubRepoPRTemplateResource{}
+	_ resource.ResourceWithConfigure   = &githubRepoPRTemplateResource{}
+	_ resource.ResourceWithImportState = &githubRepoPRTemplateResource{}
+)
+
+// NewGithubRepoPRTemplateResource is a helper function to simplify the provider implementation.
+func NewGithubRepoPRTemplateResource() resource.Resource {
+	return &githubRepoPRTemplateResource{}
+}
+
+// githubRepoPRTemplateResource manages the PR template of a repository, which replaces
+// the owner's template for that repository.
+type githubRepoPRTemplateResource struct {
+	client stepsecurityapi.Client
+}
+
+type githubRepoPRTemplateModel struct {
+	githubPRTemplateModel
+	Repo types.String `tfsdk:"repo"`
+}
+
+// Metadata returns the resource type name.
+func (r *githubRepoPRTemplateResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_github_repo_pr_template"
+}
+
+// Schema defines the schema for the resource.
+func (r *githubRepoPRTemplateResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the PR template for policy-driven PRs in a single repository, e.g. a monorepo that needs its own wording, labels or reviewers. " +
+			"It replaces the template of stepsecurity_github_pr_template for the repository as a whole; attributes are not merged. " +
+			"Destroying the resource makes the repository use the owner's template again.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "The ID of the PR template, owner/repo.",
+			},
+			"owner": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "The GitHub organization or user that owns the repository.",
+			},
+			"repo": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "The repository name.",
+			},
+		},
+	}
+	maps.Copy(resp.Schema.Attributes, prTemplateSchemaAttributes())
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *githubRepoPRTemplateResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(stepsecurityapi.Client)
+	if !ok || client == nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected stepsecurityapi.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *githubRepoPRTemplateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan githubRepoPRTemplateModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.put(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *githubRepoPRTemplateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state githubRepoPRTemplateModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	template, err := r.client.GetRepoGitHubPRTemplate(ctx, state.Owner.ValueString(), state.Repo.ValueString())
+	if err != nil && stepsecurityapi.IsNotFound(err) {
+		tflog.Info(ctx, "Repo PR template no longer exists, removing from state", map[string]any{
+			"owner": state.Owner.ValueString(),
+			"repo":  state.Repo.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read GitHub Repo PR Template",
+			err.Error(),
+		)
+		return
+	}
+
+	state.ID = types.StringValue(state.Owner.ValueString() + "/" + state.Repo.ValueString())
+	updatePRTemplateModel(&state.githubPRTemplateModel, template)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *githubRepoPRTemplateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan githubRepoPRTemplateModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.put(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *githubRepoPRTemplateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state githubRepoPRTemplateModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteRepoGitHubPRTemplate(ctx, state.Owner.ValueString(), state.Repo.ValueString())
+	if err != nil && !stepsecurityapi.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Unable to Delete GitHub Repo PR Template",
+			err.Error(),
+		)
+	}
+}
+
+// put saves the template of model as the repository's PR template.
+func (r *githubRepoPRTemplateResource) put(ctx context.Context, model *githubRepoPRTemplateModel) diag.Diagnostics {
+	template, diags := prTemplateFromModel(ctx, model.githubPRTemplateModel)
+	if diags.HasError() {
+		return diags
+	}
+
+	err := r.client.UpdateRepoGitHubPRTemplate(ctx, model.Owner.ValueString(), model.Repo.ValueString(), template)
+	if err != nil {
+		diags.AddError(
+			"Unable to Save GitHub Repo PR Template",
+			err.Error(),
+		)
+		return diags
+	}
+
+	model.ID = types.StringValue(model.Owner.ValueString() + "/" + model.Repo.ValueString())
+	model.RenderedPreview = types.StringValue(renderPRTemplatePreview(template.Summary))
+	return diags
+}
+
+// ImportState imports the resource using an owner/repo identifier.
+func (r *githubRepoPRTemplateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected owner/repo, got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("owner"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("repo"), parts[1])...)
+}
@@ -0,0 +1,192 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
+)
+
+func TestGithubRepoPRTemplateResource_Metadata(t *testing.T) {
+	t.Parallel()
+
+	r := &githubRepoPRTemplateResource{}
+	resp := &resource.MetadataResponse{}
+	r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "stepsecurity"}, resp)
+
+	assert.Equal(t, "stepsecurity_github_repo_pr_template", resp.TypeName)
+}
+
+func TestGithubRepoPRTemplateResource_Schema(t *testing.T) {
+	t.Parallel()
+
+	schema := testResourceSchema(t, &githubRepoPRTemplateResource{})
+	orgResp := &resource.SchemaResponse{}
+	(&githubPRTemplateResource{}).Schema(context.Background(), resource.SchemaRequest{}, orgResp)
+
+	// The repository template has every attribute of the owner's template.
+	for name := range orgResp.Schema.Attributes {
+		assert.Contains(t, schema.Attributes, name)
+	}
+	assert.True(t, schema.Attributes["repo"].IsRequired())
+	assert.True(t, schema.Attributes["rendered_preview"].IsComputed())
+}
+
+func TestGithubRepoPRTemplateResource_Create(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mockClient := &stepsecurityapi.MockStepSecurityClient{}
+	mockClient.On("UpdateRepoGitHubPRTemplate", mock.Anything, "test-org", "monorepo", stepsecurityapi.GitHubPRTemplate{
+		Title:         "fix(payments): apply security best practices",
+		Summary:       "{{STEPSECURITY_SECURITY_FIXES}}",
+		CommitMessage: "fix(payments): harden workflows",
+		Labels:        []string{"team:payments"},
+		TeamReviewers: []string{"payments"},
+	}).Return(nil)
+
+	r := &githubRepoPRTemplateResource{client: mockClient}
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: testResourceSchema(t, &githubRepoPRTemplateResource{})}}
+	r.Create(ctx, resource.CreateRequest{Plan: testResourcePlan(t, &githubRepoPRTemplateResource{}, testRepoPRTemplateModel())}, resp)
+
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+	mockClient.AssertExpectations(t)
+
+	var state githubRepoPRTemplateModel
+	require.False(t, resp.State.Get(ctx, &state).HasError())
+	assert.Equal(t, "test-org/monorepo", state.ID.ValueString())
+	assert.Equal(t, prTemplatePreviewSecurityFixes, state.RenderedPreview.ValueString())
+}
+
+func TestGithubRepoPRTemplateResource_Read(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mockClient := &stepsecurityapi.MockStepSecurityClient{}
+	mockClient.On("GetRepoGitHubPRTemplate", mock.Anything, "test-org", "monorepo").Return(&stepsecurityapi.GitHubPRTemplate{
+		Title:         "fix(payments): apply security best practices",
+		Summary:       "{{STEPSECURITY_SECURITY_FIXES}}",
+		CommitMessage: "fix(payments): harden workflows",
+		Labels:        []string{"team:payments"},
+		Draft:         true,
+	}, nil)
+
+	r := &githubRepoPRTemplateResource{client: mockClient}
+	state := testResourceState(t, &githubRepoPRTemplateResource{}, testRepoPRTemplateModel())
+	resp := &resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, resp)
+
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+
+	var got githubRepoPRTemplateModel
+	require.False(t, resp.State.Get(ctx, &got).HasError())
+	assert.Equal(t, "test-org/monorepo", got.ID.ValueString())
+	assert.True(t, got.Draft.ValueBool())
+	assert.True(t, got.TeamReviewers.IsNull())
+}
+
+func TestGithubRepoPRTemplateResource_ReadRemovesDeleted(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mockClient := &stepsecurityapi.MockStepSecurityClient{}
+	mockClient.On("GetRepoGitHubPRTemplate", mock.Anything, "test-org", "monorepo").
+		Return((*stepsecurityapi.GitHubPRTemplate)(nil), fmt.Errorf("failed to get repo PR template: %w", &stepsecurityapi.APIError{StatusCode: http.StatusNotFound, Body: "not found"}))
+
+	r := &githubRepoPRTemplateResource{client: mockClient}
+	state := testResourceState(t, &githubRepoPRTemplateResource{}, testRepoPRTemplateModel())
+	resp := &resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, resp)
+
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+	assert.True(t, resp.State.Raw.IsNull())
+}
+
+func TestGithubRepoPRTemplateResource_Delete(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mockClient := &stepsecurityapi.MockStepSecurityClient{}
+	mockClient.On("DeleteRepoGitHubPRTemplate", mock.Anything, "test-org", "monorepo").Return(nil)
+
+	r := &githubRepoPRTemplateResource{client: mockClient}
+	state := testResourceState(t, &githubRepoPRTemplateResource{}, testRepoPRTemplateModel())
+	resp := &resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, resp)
+
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGithubRepoPRTemplateResource_ImportState(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		importID      string
+		expectedError bool
+	}{
+		{name: "valid", importID: "test-org/monorepo"},
+		{name: "missing_repo", importID: "test-org", expectedError: true},
+		{name: "too_many_parts", importID: "test-org/monorepo/extra", expectedError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			r := &githubRepoPRTemplateResource{}
+			schema := testResourceSchema(t, &githubRepoPRTemplateResource{})
+			resp := &resource.ImportStateResponse{
+				State: tfsdk.State{Schema: schema, Raw: tftypes.NewValue(schema.Type().TerraformType(ctx), nil)},
+			}
+			r.ImportState(ctx, resource.ImportStateRequest{ID: tc.importID}, resp)
+
+			if tc.expectedError {
+				require.True(t, resp.Diagnostics.HasError())
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+
+			var owner, repo types.String
+			resp.State.GetAttribute(ctx, path.Root("owner"), &owner)
+			resp.State.GetAttribute(ctx, path.Root("repo"), &repo)
+			assert.Equal(t, "test-org", owner.ValueString())
+			assert.Equal(t, "monorepo", repo.ValueString())
+		})
+	}
+}
+
+func testRepoPRTemplateModel() githubRepoPRTemplateModel {
+	return githubRepoPRTemplateModel{
+		githubPRTemplateModel: githubPRTemplateModel{
+			ID:              types.StringUnknown(),
+			Owner:           types.StringValue("test-org"),
+			Title:           types.StringValue("fix(payments): apply security best practices"),
+			Summary:         types.StringValue("{{STEPSECURITY_SECURITY_FIXES}}"),
+			CommitMessage:   types.StringValue("fix(payments): harden workflows"),
+			Labels:          types.ListValueMust(types.StringType, stringsToAttrValues([]string{"team:payments"})),
+			Reviewers:       types.SetNull(types.StringType),
+			TeamReviewers:   types.SetValueMust(types.StringType, stringsToAttrValues([]string{"payments"})),
+			Assignees:       types.SetNull(types.StringType),
+			Draft:           types.BoolValue(false),
+			BranchPrefix:    types.StringNull(),
+			SignOff:         types.BoolValue(false),
+			CoAuthors:       types.ListNull(types.StringType),
+			RenderedPreview: types.StringUnknown(),
+		},
+		Repo: types.StringValue("monorepo"),
+	}
+}
@@ -6,6 +6,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -26,9 +29,10 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &githubRunPolicyResource{}
-	_ resource.ResourceWithConfigure   = &githubRunPolicyResource{}
-	_ resource.ResourceWithImportState = &githubRunPolicyResource{}
+	_ resource.Resource                   = &githubRunPolicyResource{}
+	_ resource.ResourceWithConfigure      = &githubRunPolicyResource{}
+	_ resource.ResourceWithImportState    = &githubRunPolicyResource{}
+	_ resource.ResourceWithValidateConfig = &githubRunPolicyResource{}
 )
 
 // NewGithubRunPolicyResource is a helper function to simplify the provider implementation.
@@ -43,17 +47,18 @@ type githubRunPolicyResource struct {
 
 // githubRunPolicyResourceModel maps the resource schema data.
 type githubRunPolicyResourceModel struct {
-	Owner         types.String `tfsdk:"owner"`
-	Name          types.String `tfsdk:"name"`
-	PolicyID      types.String `tfsdk:"policy_id"`
-	AllRepos      types.Bool   `tfsdk:"all_repos"`
-	AllOrgs       types.Bool   `tfsdk:"all_orgs"`
-	Repositories  types.List   `tfsdk:"repositories"`
-	PolicyConfig  types.Object `tfsdk:"policy_config"`
-	CreatedBy     types.String `tfsdk:"created_by"`
-	CreatedAt     types.String `tfsdk:"created_at"`
-	LastUpdatedBy types.String `tfsdk:"last_updated_by"`
-	LastUpdatedAt types.String `tfsdk:"last_updated_at"`
+	Owner            types.String `tfsdk:"owner"`
+	Name             types.String `tfsdk:"name"`
+	PolicyID         types.String `tfsdk:"policy_id"`
+	AllRepos         types.Bool   `tfsdk:"all_repos"`
+	AllOrgs          types.Bool   `tfsdk:"all_orgs"`
+	Repositories     types.List   `tfsdk:"repositories"`
+	RepositoryFilter types.Object `tfsdk:"repository_filter"`
+	PolicyConfig     types.Object `tfsdk:"policy_config"`
+	CreatedBy        types.String `tfsdk:"created_by"`
+	CreatedAt        types.String `tfsdk:"created_at"`
+	LastUpdatedBy    types.String `tfsdk:"last_updated_by"`
+	LastUpdatedAt    types.String `tfsdk:"last_updated_at"`
 }
 
 // policyConfigModel maps the policy configuration data.
@@ -79,6 +84,7 @@ type policyConfigModel struct {
 	RunsOnMode                     types.String `tfsdk:"runs_on_mode"`
 	AllowedRunnerLabels            types.Set    `tfsdk:"allowed_runner_labels"`
 	AllowedRunnerConstraints       types.Map    `tfsdk:"allowed_runner_constraints"`
+	RunsOn                         types.Object `tfsdk:"runs_on"`
 	RequirePolicyStore             types.Bool   `tfsdk:"require_policy_store"`
 	BlockJobContainer              types.Bool   `tfsdk:"block_job_container"`
 	SecretsAnalyzeDefaultBranch    types.Bool   `tfsdk:"secrets_analyze_default_branch"`
@@ -135,6 +141,7 @@ func (r *githubRunPolicyResource) Schema(_ context.Context, _ resource.SchemaReq
 				Optional:            true,
 				MarkdownDescription: "List of specific repositories this policy applies to.",
 			},
+			"repository_filter": repositoryFilterAttribute(),
 			"policy_config": schema.SingleNestedAttribute{
 				Required:            true,
 				MarkdownDescription: "The configuration for this run policy.",
@@ -156,7 +163,10 @@ func (r *githubRunPolicyResource) Schema(_ context.Context, _ resource.SchemaReq
 					"allowed_actions": schema.MapAttribute{
 						ElementType:         types.StringType,
 						Optional:            true,
-						MarkdownDescription: "Map of allowed actions and their permissions (e.g., 'actions/checkout': 'allow').",
+						MarkdownDescription: "Map of allowed actions and their permissions (e.g., 'actions/checkout': 'allow'). Keys must be action references of the form `owner/repo[/path][@ref]`, `owner/*`, `owner/prefix*` or `*`.",
+						Validators: []validator.Map{
+							mapvalidator.KeysAre(actionReferenceValidator{allowWildcards: true}),
+						},
 					},
 					"enable_harden_runner_policy": schema.BoolAttribute{
 						Optional:            true,
@@ -172,7 +182,10 @@ func (r *githubRunPolicyResource) Schema(_ context.Context, _ resource.SchemaReq
 					"harden_runner_custom_actions": schema.SetAttribute{
 						ElementType:         types.StringType,
 						Optional:            true,
-						MarkdownDescription: "Set of custom actions accepted as Harden Runner equivalents (in addition to `step-security/harden-runner`).",
+						MarkdownDescription: "Set of custom actions accepted as Harden Runner equivalents (in addition to `step-security/harden-runner`). Each entry must be an action reference of the form `owner/repo[/path][@ref]`.",
+						Validators: []validator.Set{
+							setvalidator.ValueStringsAre(actionReferenceValidator{}),
+						},
 					},
 					"enable_runs_on_policy": schema.BoolAttribute{
 						Optional:            true,
@@ -212,7 +225,10 @@ func (r *githubRunPolicyResource) Schema(_ context.Context, _ resource.SchemaReq
 					"actions_to_exempt_while_pinning": schema.SetAttribute{
 						ElementType:         types.StringType,
 						Optional:            true,
-						MarkdownDescription: "Set of actions exempt from pinning requirements. Supports exact match (e.g., 'actions/checkout'), name-only match, and owner wildcard (e.g., 'my-org/*').",
+						MarkdownDescription: "Set of actions exempt from pinning requirements. Supports name-only match (e.g., 'actions/checkout', any ref), exact match including the ref (e.g., 'actions/checkout@v4'), and owner wildcard (e.g., 'my-org/*').",
+						Validators: []validator.Set{
+							setvalidator.ValueStringsAre(actionReferenceValidator{allowWildcards: true}),
+						},
 					},
 					"is_dry_run": schema.BoolAttribute{
 						Optional:            true,
@@ -256,6 +272,19 @@ func (r *githubRunPolicyResource) Schema(_ context.Context, _ resource.SchemaReq
 						Optional:            true,
 						MarkdownDescription: "Structured runs-on.com constraints permitted when `runs_on_mode` is `allowed`, keyed by dimension (e.g. `family`, `cpu`, `image`). Each key maps to the set of allowed values for that dimension: a `runs-on` token of the form `key=value` is allowed when the key is unconfigured, or when its value is in the set. Keys are lowercased server-side (use lowercase keys to avoid plan drift) and each key must have at least one value. Expression values are matched by their exact text (whitespace-insensitive), so the `runs-on` routing key itself can be pinned to the conventional expression.",
 					},
+					"runs_on": schema.SingleNestedAttribute{
+						Optional:            true,
+						MarkdownDescription: "Structured runner-label governance for the runs-on policy. An alternative to `runs_on_mode`, `disallowed_runner_labels`, `allowed_runner_labels` and `allowed_runner_constraints`, which cannot be set together with it. Requires `enable_runs_on_policy = true`.",
+						Attributes:          runsOnBlockAttributes(),
+						Validators: []validator.Object{
+							objectvalidator.ConflictsWith(
+								path.MatchRelative().AtParent().AtName("runs_on_mode"),
+								path.MatchRelative().AtParent().AtName("disallowed_runner_labels"),
+								path.MatchRelative().AtParent().AtName("allowed_runner_labels"),
+								path.MatchRelative().AtParent().AtName("allowed_runner_constraints"),
+							),
+						},
+					},
 					"require_policy_store": schema.BoolAttribute{
 						Optional:            true,
 						Computed:            true,
@@ -366,6 +395,7 @@ func (r *githubRunPolicyResource) Create(ctx context.Context, req resource.Creat
 		}
 		createRequest.Repositories = repos
 	}
+	createRequest.RepositoryFilter = repositoryFilterToAPI(ctx, plan.RepositoryFilter, &resp.Diagnostics)
 
 	// Handle allowed actions map
 	if !policyConfig.AllowedActions.IsNull() {
@@ -443,6 +473,12 @@ func (r *githubRunPolicyResource) Create(ctx context.Context, req resource.Creat
 		createRequest.PolicyConfig.AllowedRunnerConstraints = allowedConstraints
 	}
 
+	// The structured runs_on block replaces the flat runs-on attributes
+	applyRunsOnBlockToAPI(ctx, policyConfig.RunsOn, &createRequest.PolicyConfig, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Handle pinned actions exemptions set
 	if !policyConfig.PinnedActionsExemptions.IsNull() {
 		var pinnedExemptions []string
@@ -621,6 +657,7 @@ func (r *githubRunPolicyResource) Update(ctx context.Context, req resource.Updat
 		}
 		updateRequest.Repositories = repos
 	}
+	updateRequest.RepositoryFilter = repositoryFilterToAPI(ctx, plan.RepositoryFilter, &resp.Diagnostics)
 
 	// Handle allowed actions map
 	if !policyConfig.AllowedActions.IsNull() {
@@ -698,6 +735,12 @@ func (r *githubRunPolicyResource) Update(ctx context.Context, req resource.Updat
 		updateRequest.PolicyConfig.AllowedRunnerConstraints = allowedConstraints
 	}
 
+	// The structured runs_on block replaces the flat runs-on attributes
+	applyRunsOnBlockToAPI(ctx, policyConfig.RunsOn, &updateRequest.PolicyConfig, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Handle pinned actions exemptions set
 	if !policyConfig.PinnedActionsExemptions.IsNull() {
 		var pinnedExemptions []string
@@ -796,6 +839,65 @@ func (r *githubRunPolicyResource) ImportState(ctx context.Context, req resource.
 	resp.State = readResp.State
 }
 
+// ValidateConfig validates repository_filter against the repository selection and the
+// structured runs_on block against the rest of the policy configuration.
+func (r *githubRunPolicyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var repositoryFilter types.Object
+	var allRepos, allOrgs types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("repository_filter"), &repositoryFilter)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("all_repos"), &allRepos)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("all_orgs"), &allOrgs)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(validateRepositoryFilter(ctx, repositoryFilter, allRepos, allOrgs)...)
+
+	var policyConfigValue types.Object
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("policy_config"), &policyConfigValue)...)
+	if resp.Diagnostics.HasError() || !isKnownAndSet(policyConfigValue) {
+		return
+	}
+
+	var policyConfig policyConfigModel
+	resp.Diagnostics.Append(policyConfigValue.As(ctx, &policyConfig, basetypes.ObjectAsOptions{})...)
+	if resp.Diagnostics.HasError() || !isKnownAndSet(policyConfig.RunsOn) {
+		return
+	}
+
+	var runsOn runsOnBlockModel
+	resp.Diagnostics.Append(policyConfig.RunsOn.As(ctx, &runsOn, basetypes.ObjectAsOptions{})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	runsOnPath := path.Root("policy_config").AtName("runs_on")
+	if !policyConfig.EnableRunsOnPolicy.IsUnknown() && !policyConfig.EnableRunsOnPolicy.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			runsOnPath,
+			"Invalid runs-on policy configuration",
+			"runs_on is only evaluated when enable_runs_on_policy is true.",
+		)
+	}
+	if runsOn.Mode.IsUnknown() {
+		return
+	}
+	resp.Diagnostics.Append(runsOn.validate(runsOnPath, policyConfig.EnableStandardRunnerLabels)...)
+}
+
+// applyRunsOnBlockToAPI overrides the runs-on wire fields when the runs_on block is set.
+func applyRunsOnBlockToAPI(ctx context.Context, runsOnValue types.Object, config *stepsecurityapi.RunPolicyConfig, diags *diag.Diagnostics) {
+	if runsOnValue.IsNull() || runsOnValue.IsUnknown() {
+		return
+	}
+
+	var runsOn runsOnBlockModel
+	diags.Append(runsOnValue.As(ctx, &runsOn, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return
+	}
+	runsOn.toAPI(ctx, config, diags)
+}
+
 // updateModelFromAPI updates the Terraform model with data from the API response.
 func (r *githubRunPolicyResource) updateModelFromAPI(ctx context.Context, model *githubRunPolicyResourceModel, policy *stepsecurityapi.RunPolicy, diags *diag.Diagnostics) {
 	var existingPolicyConfig policyConfigModel
@@ -833,6 +935,8 @@ func (r *githubRunPolicyResource) updateModelFromAPI(ctx context.Context, model
 		return emptySet, true
 	}
 
+	policy = restoreExpandedRepositoryFilter(ctx, r.client, model.Owner.ValueString(), model.RepositoryFilter, policy, diags)
+
 	// when applied across org..preserve owner set in state/plan
 	if !strings.Contains(policy.Owner, "#[all]") {
 		model.Owner = types.StringValue(policy.Owner)
@@ -858,6 +962,7 @@ func (r *githubRunPolicyResource) updateModelFromAPI(ctx context.Context, model
 	} else {
 		model.Repositories = types.ListNull(types.StringType)
 	}
+	model.RepositoryFilter = repositoryFilterFromAPI(policy.RepositoryFilter, diags)
 
 	// Handle policy configuration
 	policyConfigAttrs := map[string]attr.Value{
@@ -975,6 +1080,18 @@ func (r *githubRunPolicyResource) updateModelFromAPI(ctx context.Context, model
 		policyConfigAttrs["allowed_runner_constraints"] = types.MapNull(types.SetType{ElemType: types.StringType})
 	}
 
+	// When the configuration uses the structured runs_on block, report the runs-on
+	// wire fields through it and leave the flat attributes at their defaults.
+	if hasExistingPolicyConfig && !existingPolicyConfig.RunsOn.IsNull() {
+		policyConfigAttrs["runs_on"] = runsOnBlockFromAPI(ctx, policy.PolicyConfig, diags)
+		policyConfigAttrs["runs_on_mode"] = types.StringValue("")
+		policyConfigAttrs["disallowed_runner_labels"] = types.SetNull(types.StringType)
+		policyConfigAttrs["allowed_runner_labels"] = types.SetNull(types.StringType)
+		policyConfigAttrs["allowed_runner_constraints"] = types.MapNull(types.SetType{ElemType: types.StringType})
+	} else {
+		policyConfigAttrs["runs_on"] = types.ObjectNull(runsOnBlockAttrTypes)
+	}
+
 	// Handle pinned actions exemptions set
 	if policy.PolicyConfig.PinnedActionsExemptions != nil {
 		pinnedExemptionsList := make([]attr.Value, len(policy.PolicyConfig.PinnedActionsExemptions))
@@ -1024,6 +1141,7 @@ func (r *githubRunPolicyResource) updateModelFromAPI(ctx context.Context, model
 		"runs_on_mode":                      types.StringType,
 		"allowed_runner_labels":             types.SetType{ElemType: types.StringType},
 		"allowed_runner_constraints":        types.MapType{ElemType: types.SetType{ElemType: types.StringType}},
+		"runs_on":                           types.ObjectType{AttrTypes: runsOnBlockAttrTypes},
 		"require_policy_store":              types.BoolType,
 		"block_job_container":               types.BoolType,
 		"secrets_analyze_default_branch":    types.BoolType,
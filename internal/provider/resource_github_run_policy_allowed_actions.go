@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
+)
+
+// NewGithubRunPolicyAllowedActionsResource is a helper function to simplify the provider implementation.
+func NewGithubRunPolicyAllowedActionsResource() resource.Resource {
+	return &githubTypedRunPolicyResource{kind: githubRunPolicyAllowedActionsKind}
+}
+
+// githubRunPolicyAllowedActionsKind exposes the allowed actions policy. Pinning is a
+// sub-feature of the same backend policy but is managed by
+// stepsecurity_github_run_policy_pinned_actions, so this type only matches policies
+// that do not require pinning.
+var githubRunPolicyAllowedActionsKind = githubRunPolicyKind{
+	typeName: "_github_run_policy_allowed_actions",
+	label:    "allowed actions",
+	description: "Manages a GitHub Actions allowed actions run policy in StepSecurity. " +
+		"Only the actions listed in `allowed_actions` may run in workflows covered by the policy. " +
+		"Use `stepsecurity_github_run_policy_pinned_actions` to additionally require pinning to commit SHAs.",
+	attributes: map[string]schema.Attribute{
+		"allowed_actions": schema.MapAttribute{
+			ElementType:         types.StringType,
+			Required:            true,
+			MarkdownDescription: "Map of allowed actions and their permissions (e.g., 'actions/checkout': 'allow'). Keys must be action references of the form `owner/repo[/path][@ref]`, `owner/*`, `owner/prefix*` or `*`.",
+			Validators: []validator.Map{
+				mapvalidator.SizeAtLeast(1),
+				mapvalidator.KeysAre(actionReferenceValidator{allowWildcards: true}),
+			},
+		},
+	},
+	newModel: func() githubTypedRunPolicy {
+		return &githubRunPolicyAllowedActionsModel{}
+	},
+	matches: func(config stepsecurityapi.RunPolicyConfig) bool {
+		return config.EnableActionPolicy && !config.RequirePinnedActions && len(runPolicyEnabledControls(config)) == 1
+	},
+}
+
+// githubRunPolicyAllowedActionsModel maps the allowed actions policy schema data.
+type githubRunPolicyAllowedActionsModel struct {
+	githubTypedRunPolicyModel
+	AllowedActions types.Map `tfsdk:"allowed_actions"`
+}
+
+func (m *githubRunPolicyAllowedActionsModel) validate(_ context.Context) diag.Diagnostics {
+	return nil
+}
+
+func (m *githubRunPolicyAllowedActionsModel) toAPI(ctx context.Context, config *stepsecurityapi.RunPolicyConfig, diags *diag.Diagnostics) {
+	config.EnableActionPolicy = true
+	config.AllowedActions = allowedActionsToAPI(ctx, m.AllowedActions, diags)
+}
+
+func (m *githubRunPolicyAllowedActionsModel) fromAPI(_ context.Context, config stepsecurityapi.RunPolicyConfig, diags *diag.Diagnostics) {
+	m.AllowedActions = allowedActionsFromAPI(config.AllowedActions, diags)
+}
+
+// allowedActionsToAPI converts the allowed actions map into the API shape, keeping a
+// null map as nil.
+func allowedActionsToAPI(ctx context.Context, value types.Map, diags *diag.Diagnostics) map[string]string {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+	var allowedActions map[string]string
+	diags.Append(value.ElementsAs(ctx, &allowedActions, false)...)
+	return allowedActions
+}
+
+// allowedActionsFromAPI converts the API allowed actions map into a Terraform map,
+// keeping a nil map as null.
+func allowedActionsFromAPI(allowedActions map[string]string, diags *diag.Diagnostics) types.Map {
+	if allowedActions == nil {
+		return types.MapNull(types.StringType)
+	}
+	elems := make(map[string]attr.Value, len(allowedActions))
+	for action, permission := range allowedActions {
+		elems[action] = types.StringValue(permission)
+	}
+	mapValue, mapDiags := types.MapValue(types.StringType, elems)
+	diags.Append(mapDiags...)
+	return mapValue
+}
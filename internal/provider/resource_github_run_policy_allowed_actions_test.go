@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
+)
+
+func TestGithubRunPolicyAllowedActions_BuildRequest(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	model := &githubRunPolicyAllowedActionsModel{
+		githubTypedRunPolicyModel: testTypedRunPolicyCommon("Allowed Actions"),
+		AllowedActions: types.MapValueMust(types.StringType, map[string]attr.Value{
+			"actions/checkout":            types.StringValue("allow"),
+			"step-security/harden-runner": types.StringValue("allow"),
+		}),
+	}
+
+	var diags diag.Diagnostics
+	req := buildGithubTypedRunPolicyRequest(ctx, model, &diags)
+	require.False(t, diags.HasError(), "unexpected diags: %v", diags)
+
+	assert.True(t, req.PolicyConfig.EnableActionPolicy)
+	assert.False(t, req.PolicyConfig.RequirePinnedActions)
+	assert.Equal(t, map[string]string{
+		"actions/checkout":            "allow",
+		"step-security/harden-runner": "allow",
+	}, req.PolicyConfig.AllowedActions)
+}
+
+func TestGithubRunPolicyAllowedActions_Matches(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, githubRunPolicyAllowedActionsKind.matches(stepsecurityapi.RunPolicyConfig{EnableActionPolicy: true}))
+	// Pinning policies belong to stepsecurity_github_run_policy_pinned_actions.
+	assert.False(t, githubRunPolicyAllowedActionsKind.matches(stepsecurityapi.RunPolicyConfig{EnableActionPolicy: true, RequirePinnedActions: true}))
+	assert.False(t, githubRunPolicyAllowedActionsKind.matches(stepsecurityapi.RunPolicyConfig{EnableSecretsPolicy: true}))
+	// Policies enabling other controls as well belong to stepsecurity_github_run_policy.
+	assert.False(t, githubRunPolicyAllowedActionsKind.matches(stepsecurityapi.RunPolicyConfig{EnableActionPolicy: true, EnableSecretsPolicy: true, EnableRunsOnPolicy: true}))
+}
+
+func TestGithubRunPolicyAllowedActions_ApplyToModel(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	model := &githubRunPolicyAllowedActionsModel{}
+
+	var diags diag.Diagnostics
+	model.fromAPI(ctx, stepsecurityapi.RunPolicyConfig{
+		EnableActionPolicy: true,
+		AllowedActions:     map[string]string{"actions/checkout": "allow"},
+	}, &diags)
+	require.False(t, diags.HasError())
+
+	var allowed map[string]string
+	require.False(t, model.AllowedActions.ElementsAs(ctx, &allowed, false).HasError())
+	assert.Equal(t, map[string]string{"actions/checkout": "allow"}, allowed)
+}
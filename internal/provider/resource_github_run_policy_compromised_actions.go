@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+
+	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
+)
+
+// NewGithubRunPolicyCompromisedActionsResource is a helper function to simplify the provider implementation.
+func NewGithubRunPolicyCompromisedActionsResource() resource.Resource {
+	return &githubTypedRunPolicyResource{kind: githubRunPolicyCompromisedActionsKind}
+}
+
+// githubRunPolicyCompromisedActionsKind exposes the compromised actions policy. It has
+// no settings beyond the shared scope and dry-run attributes.
+var githubRunPolicyCompromisedActionsKind = githubRunPolicyKind{
+	typeName: "_github_run_policy_compromised_actions",
+	label:    "compromised actions",
+	description: "Manages a GitHub Actions compromised actions run policy in StepSecurity. " +
+		"Workflow runs that use an action StepSecurity knows to be compromised are blocked.",
+	newModel: func() githubTypedRunPolicy {
+		return &githubRunPolicyCompromisedActionsModel{}
+	},
+	matches: func(config stepsecurityapi.RunPolicyConfig) bool {
+		return config.EnableCompromisedActionsPolicy && len(runPolicyEnabledControls(config)) == 1
+	},
+}
+
+// githubRunPolicyCompromisedActionsModel maps the compromised actions policy schema data.
+type githubRunPolicyCompromisedActionsModel struct {
+	githubTypedRunPolicyModel
+}
+
+func (m *githubRunPolicyCompromisedActionsModel) validate(_ context.Context) diag.Diagnostics {
+	return nil
+}
+
+func (m *githubRunPolicyCompromisedActionsModel) toAPI(_ context.Context, config *stepsecurityapi.RunPolicyConfig, _ *diag.Diagnostics) {
+	config.EnableCompromisedActionsPolicy = true
+}
+
+func (m *githubRunPolicyCompromisedActionsModel) fromAPI(_ context.Context, _ stepsecurityapi.RunPolicyConfig, _ *diag.Diagnostics) {
+}
@@ -0,0 +1,21 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
+)
+
+func TestGithubRunPolicyCompromisedActions_Schema(t *testing.T) {
+	t.Parallel()
+
+	schemaResp := testTypedRunPolicySchema(t, NewGithubRunPolicyCompromisedActionsResource())
+
+	// The compromised actions policy has no settings beyond the shared attributes.
+	assert.Len(t, schemaResp.Schema.Attributes, len(githubTypedRunPolicyCommonAttributes()))
+	assert.True(t, githubRunPolicyCompromisedActionsKind.matches(stepsecurityapi.RunPolicyConfig{EnableCompromisedActionsPolicy: true}))
+	assert.False(t, githubRunPolicyCompromisedActionsKind.matches(stepsecurityapi.RunPolicyConfig{EnableSecretsPolicy: true}))
+	assert.False(t, githubRunPolicyCompromisedActionsKind.matches(stepsecurityapi.RunPolicyConfig{EnableCompromisedActionsPolicy: true, EnableHardenRunnerPolicy: true}))
+}
@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
+)
+
+// NewGithubRunPolicyPinnedActionsResource is a helper function to simplify the provider implementation.
+func NewGithubRunPolicyPinnedActionsResource() resource.Resource {
+	return &githubTypedRunPolicyResource{kind: githubRunPolicyPinnedActionsKind}
+}
+
+// githubRunPolicyPinnedActionsKind exposes pinned actions enforcement. The backend
+// models it as a sub-feature of the allowed actions policy, so the request always
+// enables the action policy alongside require_pinned_actions.
+var githubRunPolicyPinnedActionsKind = githubRunPolicyKind{
+	typeName: "_github_run_policy_pinned_actions",
+	label:    "pinned actions",
+	description: "Manages a GitHub Actions pinned actions run policy in StepSecurity. " +
+		"Workflows covered by the policy may only use actions pinned to full-length commit SHAs, " +
+		"except for the actions listed in `actions_to_exempt_while_pinning`.",
+	attributes: map[string]schema.Attribute{
+		"actions_to_exempt_while_pinning": schema.SetAttribute{
+			ElementType:         types.StringType,
+			Optional:            true,
+			MarkdownDescription: "Set of actions exempt from pinning requirements. Supports name-only match (e.g., 'actions/checkout', any ref), exact match including the ref (e.g., 'actions/checkout@v4'), and owner wildcard (e.g., 'my-org/*').",
+			Validators: []validator.Set{
+				setvalidator.SizeAtLeast(1),
+				setvalidator.ValueStringsAre(actionReferenceValidator{allowWildcards: true}),
+			},
+		},
+		"allowed_actions": schema.MapAttribute{
+			ElementType:         types.StringType,
+			Optional:            true,
+			MarkdownDescription: "Optional map of allowed actions and their permissions (e.g., 'actions/checkout': 'allow') enforced together with pinning. Keys must be action references of the form `owner/repo[/path][@ref]`, `owner/*`, `owner/prefix*` or `*`.",
+			Validators: []validator.Map{
+				mapvalidator.SizeAtLeast(1),
+				mapvalidator.KeysAre(actionReferenceValidator{allowWildcards: true}),
+			},
+		},
+	},
+	newModel: func() githubTypedRunPolicy {
+		return &githubRunPolicyPinnedActionsModel{}
+	},
+	matches: func(config stepsecurityapi.RunPolicyConfig) bool {
+		return config.EnableActionPolicy && config.RequirePinnedActions && len(runPolicyEnabledControls(config)) == 1
+	},
+}
+
+// githubRunPolicyPinnedActionsModel maps the pinned actions policy schema data.
+type githubRunPolicyPinnedActionsModel struct {
+	githubTypedRunPolicyModel
+	PinnedActionsExemptions types.Set `tfsdk:"actions_to_exempt_while_pinning"`
+	AllowedActions          types.Map `tfsdk:"allowed_actions"`
+}
+
+func (m *githubRunPolicyPinnedActionsModel) validate(_ context.Context) diag.Diagnostics {
+	return nil
+}
+
+func (m *githubRunPolicyPinnedActionsModel) toAPI(ctx context.Context, config *stepsecurityapi.RunPolicyConfig, diags *diag.Diagnostics) {
+	config.EnableActionPolicy = true
+	config.RequirePinnedActions = true
+	config.PinnedActionsExemptions = stringSetToAPI(ctx, m.PinnedActionsExemptions, diags)
+	config.AllowedActions = allowedActionsToAPI(ctx, m.AllowedActions, diags)
+}
+
+func (m *githubRunPolicyPinnedActionsModel) fromAPI(_ context.Context, config stepsecurityapi.RunPolicyConfig, diags *diag.Diagnostics) {
+	m.PinnedActionsExemptions = stringSetFromAPI(config.PinnedActionsExemptions, diags)
+	m.AllowedActions = allowedActionsFromAPI(config.AllowedActions, diags)
+}
@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
+)
+
+func TestGithubRunPolicyPinnedActions_BuildRequest(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	model := &githubRunPolicyPinnedActionsModel{
+		githubTypedRunPolicyModel: testTypedRunPolicyCommon("Pinned"),
+		PinnedActionsExemptions:   types.SetValueMust(types.StringType, testStringAttrValues([]string{"actions/*", "my-org/*"})),
+		AllowedActions:            types.MapNull(types.StringType),
+	}
+
+	var diags diag.Diagnostics
+	req := buildGithubTypedRunPolicyRequest(ctx, model, &diags)
+	require.False(t, diags.HasError(), "unexpected diags: %v", diags)
+
+	// Pinning is a sub-feature of the action policy, so both flags are sent.
+	assert.True(t, req.PolicyConfig.EnableActionPolicy)
+	assert.True(t, req.PolicyConfig.RequirePinnedActions)
+	assert.ElementsMatch(t, []string{"actions/*", "my-org/*"}, req.PolicyConfig.PinnedActionsExemptions)
+	assert.Nil(t, req.PolicyConfig.AllowedActions)
+}
+
+func TestGithubRunPolicyPinnedActions_ApplyToModel(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	model := &githubRunPolicyPinnedActionsModel{}
+
+	var diags diag.Diagnostics
+	model.fromAPI(ctx, stepsecurityapi.RunPolicyConfig{
+		EnableActionPolicy:   true,
+		RequirePinnedActions: true,
+	}, &diags)
+	require.False(t, diags.HasError())
+
+	assert.True(t, model.PinnedActionsExemptions.IsNull())
+	assert.True(t, model.AllowedActions.IsNull())
+	assert.True(t, githubRunPolicyPinnedActionsKind.matches(stepsecurityapi.RunPolicyConfig{EnableActionPolicy: true, RequirePinnedActions: true}))
+	assert.False(t, githubRunPolicyPinnedActionsKind.matches(stepsecurityapi.RunPolicyConfig{EnableActionPolicy: true}))
+	assert.False(t, githubRunPolicyPinnedActionsKind.matches(stepsecurityapi.RunPolicyConfig{EnableActionPolicy: true, RequirePinnedActions: true, EnableCompromisedActionsPolicy: true}))
+}
@@ -0,0 +1,379 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
+)
+
+const (
+	runsOnModeDisallowed = "disallowed"
+	runsOnModeAllowed    = "allowed"
+)
+
+// NewGithubRunPolicyRunsOnResource is a helper function to simplify the provider implementation.
+func NewGithubRunPolicyRunsOnResource() resource.Resource {
+	return &githubTypedRunPolicyResource{kind: githubRunPolicyRunsOnKind}
+}
+
+// githubRunPolicyRunsOnKind exposes the runs-on (runner label) policy.
+var githubRunPolicyRunsOnKind = githubRunPolicyKind{
+	typeName: "_github_run_policy_runs_on",
+	label:    "runs-on",
+	description: "Manages a GitHub Actions runs-on run policy in StepSecurity. " +
+		"In `disallowed` mode jobs whose `runs-on` matches a disallowed label are blocked; in `allowed` mode " +
+		"only jobs whose `runs-on` matches the allowed labels or constraints may run.",
+	attributes: map[string]schema.Attribute{
+		"runs_on_mode": schema.StringAttribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString(runsOnModeDisallowed),
+			MarkdownDescription: "Controls how runner labels are evaluated. `disallowed` (the default) blocks jobs whose `runs-on` matches `disallowed_runner_labels`. `allowed` only permits jobs whose `runs-on` matches `allowed_runner_labels` / `allowed_runner_constraints`.",
+			Validators: []validator.String{
+				stringvalidator.OneOf(runsOnModeDisallowed, runsOnModeAllowed),
+			},
+		},
+		"disallowed_runner_labels": schema.SetAttribute{
+			ElementType:         types.StringType,
+			Optional:            true,
+			MarkdownDescription: "Set of disallowed runner labels. Only valid in `disallowed` mode.",
+			Validators: []validator.Set{
+				setvalidator.SizeAtLeast(1),
+			},
+		},
+		"enable_standard_runner_labels": schema.BoolAttribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+			MarkdownDescription: "When true, the GitHub-hosted standard runner label set (ubuntu-latest, windows-latest, macos-*, arm variants, ...; kept up to date automatically) is added to `disallowed_runner_labels` at evaluation time. Only valid in `disallowed` mode.",
+		},
+		"allowed_runner_labels": schema.SetAttribute{
+			ElementType:         types.StringType,
+			Optional:            true,
+			MarkdownDescription: "Set of plain runner labels permitted in `allowed` mode (e.g. `ubuntu-latest`). A job is allowed when its `runs-on` label matches an entry verbatim.",
+			Validators: []validator.Set{
+				setvalidator.SizeAtLeast(1),
+			},
+		},
+		"allowed_runner_constraints": schema.MapAttribute{
+			ElementType:         types.SetType{ElemType: types.StringType},
+			Optional:            true,
+			MarkdownDescription: "Structured runs-on.com constraints permitted in `allowed` mode, keyed by lowercase dimension (e.g. `family`, `cpu`, `image`). Each key maps to the set of allowed values for that dimension: a `runs-on` token of the form `key=value` is allowed when the key is unconfigured, or when its value is in the set.",
+			Validators: []validator.Map{
+				mapvalidator.SizeAtLeast(1),
+			},
+		},
+	},
+	newModel: func() githubTypedRunPolicy {
+		return &githubRunPolicyRunsOnModel{}
+	},
+	matches: func(config stepsecurityapi.RunPolicyConfig) bool {
+		return config.EnableRunsOnPolicy && len(runPolicyEnabledControls(config)) == 1
+	},
+}
+
+// githubRunPolicyRunsOnModel maps the runs-on policy schema data.
+type githubRunPolicyRunsOnModel struct {
+	githubTypedRunPolicyModel
+	RunsOnMode                 types.String `tfsdk:"runs_on_mode"`
+	DisallowedRunnerLabels     types.Set    `tfsdk:"disallowed_runner_labels"`
+	EnableStandardRunnerLabels types.Bool   `tfsdk:"enable_standard_runner_labels"`
+	AllowedRunnerLabels        types.Set    `tfsdk:"allowed_runner_labels"`
+	AllowedRunnerConstraints   types.Map    `tfsdk:"allowed_runner_constraints"`
+}
+
+// validate enforces that each mode only carries its own label fields and that the
+// policy has something to evaluate. Unknown values are skipped until apply.
+func (m *githubRunPolicyRunsOnModel) validate(_ context.Context) diag.Diagnostics {
+	if m.RunsOnMode.IsUnknown() {
+		return nil
+	}
+
+	return validateRunsOnSettings(m.RunsOnMode.ValueString(), runsOnSettings{
+		Mode:                       runsOnSetting{Path: path.Root("runs_on_mode")},
+		DisallowedLabels:           runsOnSetting{Path: path.Root("disallowed_runner_labels"), Value: m.DisallowedRunnerLabels},
+		EnableStandardRunnerLabels: runsOnSetting{Path: path.Root("enable_standard_runner_labels"), Value: m.EnableStandardRunnerLabels},
+		AllowedLabels:              runsOnSetting{Path: path.Root("allowed_runner_labels"), Value: m.AllowedRunnerLabels},
+		AllowedConstraints:         runsOnSetting{Path: path.Root("allowed_runner_constraints"), Value: m.AllowedRunnerConstraints},
+	})
+}
+
+// runsOnSetting is a runs-on policy value together with the attribute it is configured in.
+type runsOnSetting struct {
+	Path  path.Path
+	Value attr.Value
+}
+
+// runsOnSettings are the runs-on policy values, which stepsecurity_github_run_policy_runs_on
+// and the runs_on block of stepsecurity_github_run_policy configure under different names.
+type runsOnSettings struct {
+	Mode                       runsOnSetting
+	DisallowedLabels           runsOnSetting
+	EnableStandardRunnerLabels runsOnSetting
+	AllowedLabels              runsOnSetting
+	AllowedConstraints         runsOnSetting
+}
+
+// validateRunsOnSettings enforces that each mode only carries its own settings, which
+// the backend would otherwise silently ignore, and that the mode has something to
+// evaluate. An empty mode is treated as disallowed, like the backend does.
+func validateRunsOnSettings(mode string, s runsOnSettings) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	onlyUsedIn := func(setting runsOnSetting, mode string) {
+		diags.AddAttributeError(
+			setting.Path,
+			"Invalid runs-on policy configuration",
+			fmt.Sprintf("%s is only used when %s is %q.", setting.Path, s.Mode.Path, mode),
+		)
+	}
+	standardLabels, _ := s.EnableStandardRunnerLabels.Value.(types.Bool)
+
+	switch mode {
+	case "", runsOnModeDisallowed:
+		if isKnownAndSet(s.AllowedLabels.Value) {
+			onlyUsedIn(s.AllowedLabels, runsOnModeAllowed)
+		}
+		if isKnownAndSet(s.AllowedConstraints.Value) {
+			onlyUsedIn(s.AllowedConstraints, runsOnModeAllowed)
+		}
+		if s.DisallowedLabels.Value.IsNull() && !standardLabels.IsUnknown() && !standardLabels.ValueBool() {
+			diags.AddAttributeError(
+				s.DisallowedLabels.Path,
+				"Invalid runs-on policy configuration",
+				fmt.Sprintf("In disallowed mode set %s, %s, or both; otherwise the policy blocks nothing.", s.DisallowedLabels.Path, s.EnableStandardRunnerLabels.Path),
+			)
+		}
+	case runsOnModeAllowed:
+		if isKnownAndSet(s.DisallowedLabels.Value) {
+			onlyUsedIn(s.DisallowedLabels, runsOnModeDisallowed)
+		}
+		if !standardLabels.IsUnknown() && standardLabels.ValueBool() {
+			onlyUsedIn(s.EnableStandardRunnerLabels, runsOnModeDisallowed)
+		}
+		if s.AllowedLabels.Value.IsNull() && s.AllowedConstraints.Value.IsNull() {
+			diags.AddAttributeError(
+				s.AllowedLabels.Path,
+				"Invalid runs-on policy configuration",
+				fmt.Sprintf("In allowed mode set %s, %s, or both; otherwise every job is blocked.", s.AllowedLabels.Path, s.AllowedConstraints.Path),
+			)
+		}
+	}
+
+	constraints, _ := s.AllowedConstraints.Value.(types.Map)
+	diags.Append(validateRunnerConstraints(s.AllowedConstraints.Path, constraints)...)
+	return diags
+}
+
+func (m *githubRunPolicyRunsOnModel) toAPI(ctx context.Context, config *stepsecurityapi.RunPolicyConfig, diags *diag.Diagnostics) {
+	config.EnableRunsOnPolicy = true
+	config.RunsOnMode = m.RunsOnMode.ValueString()
+	config.DisallowedRunnerLabels = runnerLabelSetToAPI(ctx, m.DisallowedRunnerLabels, diags)
+	config.EnableStandardRunnerLabels = m.EnableStandardRunnerLabels.ValueBool()
+	config.AllowedRunnerLabels = runnerLabelSetToAPI(ctx, m.AllowedRunnerLabels, diags)
+
+	if !m.AllowedRunnerConstraints.IsNull() && !m.AllowedRunnerConstraints.IsUnknown() {
+		constraints := make(map[string][]string)
+		diags.Append(m.AllowedRunnerConstraints.ElementsAs(ctx, &constraints, false)...)
+		config.AllowedRunnerConstraints = constraints
+	}
+}
+
+func (m *githubRunPolicyRunsOnModel) fromAPI(_ context.Context, config stepsecurityapi.RunPolicyConfig, diags *diag.Diagnostics) {
+	// The backend treats an empty mode as disallowed.
+	mode := config.RunsOnMode
+	if mode == "" {
+		mode = runsOnModeDisallowed
+	}
+	m.RunsOnMode = types.StringValue(mode)
+	m.DisallowedRunnerLabels = runnerLabelSetFromAPI(config.DisallowedRunnerLabels, diags)
+	m.EnableStandardRunnerLabels = types.BoolValue(config.EnableStandardRunnerLabels)
+	m.AllowedRunnerLabels = runnerLabelSetFromAPI(config.AllowedRunnerLabels, diags)
+
+	if config.AllowedRunnerConstraints == nil {
+		m.AllowedRunnerConstraints = types.MapNull(types.SetType{ElemType: types.StringType})
+		return
+	}
+	constraints := make(map[string]attr.Value, len(config.AllowedRunnerConstraints))
+	for key, values := range config.AllowedRunnerConstraints {
+		constraints[key] = stringSetFromAPI(values, diags)
+	}
+	mapValue, mapDiags := types.MapValue(types.SetType{ElemType: types.StringType}, constraints)
+	diags.Append(mapDiags...)
+	m.AllowedRunnerConstraints = mapValue
+}
+
+// isKnownAndSet reports whether a configuration value is known and not null. Unknown
+// values may still resolve to null, so conflict checks must wait for them.
+func isKnownAndSet(value attr.Value) bool {
+	return !value.IsNull() && !value.IsUnknown()
+}
+
+// validateRunnerConstraints mirrors the backend rules for allowed runner constraints:
+// keys are lowercased server-side, so an uppercase key would surface as plan drift, and
+// every key needs at least one value.
+func validateRunnerConstraints(attrPath path.Path, constraints types.Map) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if constraints.IsNull() || constraints.IsUnknown() {
+		return diags
+	}
+
+	for key, value := range constraints.Elements() {
+		if key != strings.ToLower(key) {
+			diags.AddAttributeError(
+				attrPath.AtMapKey(key),
+				"Invalid runner constraint key",
+				fmt.Sprintf("Constraint key %q must be lowercase; the backend lowercases keys, which would cause plan drift. Use %q.", key, strings.ToLower(key)),
+			)
+		}
+		set, ok := value.(types.Set)
+		if !ok || set.IsUnknown() {
+			continue
+		}
+		if set.IsNull() || len(set.Elements()) == 0 {
+			diags.AddAttributeError(
+				attrPath.AtMapKey(key),
+				"Invalid runner constraint",
+				fmt.Sprintf("Constraint key %q must have at least one allowed value.", key),
+			)
+		}
+	}
+
+	return diags
+}
+
+// runsOnBlockModel maps the structured runs_on attribute of stepsecurity_github_run_policy.
+// It is an alternative to the flat runs_on_mode / *_runner_labels / allowed_runner_constraints
+// attributes and maps onto the same wire fields.
+type runsOnBlockModel struct {
+	Mode               types.String `tfsdk:"mode"`
+	DisallowedLabels   types.Set    `tfsdk:"disallowed_labels"`
+	AllowedLabels      types.Set    `tfsdk:"allowed_labels"`
+	AllowedConstraints types.Map    `tfsdk:"allowed_constraints"`
+}
+
+var runsOnBlockAttrTypes = map[string]attr.Type{
+	"mode":                types.StringType,
+	"disallowed_labels":   types.SetType{ElemType: types.StringType},
+	"allowed_labels":      types.SetType{ElemType: types.StringType},
+	"allowed_constraints": types.MapType{ElemType: types.SetType{ElemType: types.StringType}},
+}
+
+// runsOnBlockAttributes returns the nested attributes of the runs_on block.
+func runsOnBlockAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"mode": schema.StringAttribute{
+			Required:            true,
+			MarkdownDescription: "How runner labels are evaluated. `disallowed` blocks jobs whose `runs-on` matches `disallowed_labels` (plus the standard labels when `enable_standard_runner_labels` is true). `allowed` only permits jobs whose `runs-on` matches `allowed_labels` or `allowed_constraints`.",
+			Validators: []validator.String{
+				stringvalidator.OneOf(runsOnModeDisallowed, runsOnModeAllowed),
+			},
+		},
+		"disallowed_labels": schema.SetAttribute{
+			ElementType:         types.StringType,
+			Optional:            true,
+			MarkdownDescription: "Runner labels blocked in `disallowed` mode.",
+			Validators: []validator.Set{
+				setvalidator.SizeAtLeast(1),
+			},
+		},
+		"allowed_labels": schema.SetAttribute{
+			ElementType:         types.StringType,
+			Optional:            true,
+			MarkdownDescription: "Plain runner labels permitted in `allowed` mode (e.g. `ubuntu-latest`). A label may not also appear in `disallowed_labels`.",
+			Validators: []validator.Set{
+				setvalidator.SizeAtLeast(1),
+			},
+		},
+		"allowed_constraints": schema.MapAttribute{
+			ElementType:         types.SetType{ElemType: types.StringType},
+			Optional:            true,
+			MarkdownDescription: "runs-on.com constraint entries permitted in `allowed` mode. Each key is a lowercase label group (e.g. `family`, `cpu`, `image`) mapped to the set of values allowed for it; a `runs-on` token `key=value` is allowed when the key is unconfigured or its value is in the set.",
+			Validators: []validator.Map{
+				mapvalidator.SizeAtLeast(1),
+			},
+		},
+	}
+}
+
+// validate checks the block for conflicts the backend would silently accept: labels that
+// are both allowed and disallowed, settings of the other mode, and a mode with nothing
+// to evaluate. enable_standard_runner_labels is a sibling of the block.
+func (m runsOnBlockModel) validate(blockPath path.Path, enableStandardRunnerLabels types.Bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if isKnownAndSet(m.AllowedLabels) && isKnownAndSet(m.DisallowedLabels) {
+		disallowed := make(map[string]struct{}, len(m.DisallowedLabels.Elements()))
+		for _, v := range m.DisallowedLabels.Elements() {
+			if s, ok := v.(types.String); ok && !s.IsUnknown() {
+				disallowed[strings.ToLower(s.ValueString())] = struct{}{}
+			}
+		}
+		for _, v := range m.AllowedLabels.Elements() {
+			s, ok := v.(types.String)
+			if !ok || s.IsUnknown() {
+				continue
+			}
+			if _, conflict := disallowed[strings.ToLower(s.ValueString())]; conflict {
+				diags.AddAttributeError(
+					blockPath.AtName("allowed_labels"),
+					"Conflicting runner labels",
+					fmt.Sprintf("Runner label %q is listed in both allowed_labels and disallowed_labels.", s.ValueString()),
+				)
+			}
+		}
+	}
+
+	diags.Append(validateRunsOnSettings(m.Mode.ValueString(), runsOnSettings{
+		Mode:                       runsOnSetting{Path: blockPath.AtName("mode")},
+		DisallowedLabels:           runsOnSetting{Path: blockPath.AtName("disallowed_labels"), Value: m.DisallowedLabels},
+		EnableStandardRunnerLabels: runsOnSetting{Path: blockPath.ParentPath().AtName("enable_standard_runner_labels"), Value: enableStandardRunnerLabels},
+		AllowedLabels:              runsOnSetting{Path: blockPath.AtName("allowed_labels"), Value: m.AllowedLabels},
+		AllowedConstraints:         runsOnSetting{Path: blockPath.AtName("allowed_constraints"), Value: m.AllowedConstraints},
+	})...)
+	return diags
+}
+
+// toAPI writes the block onto the wire fields of config.
+func (m runsOnBlockModel) toAPI(ctx context.Context, config *stepsecurityapi.RunPolicyConfig, diags *diag.Diagnostics) {
+	config.RunsOnMode = m.Mode.ValueString()
+	config.DisallowedRunnerLabels = runnerLabelSetToAPI(ctx, m.DisallowedLabels, diags)
+	config.AllowedRunnerLabels = runnerLabelSetToAPI(ctx, m.AllowedLabels, diags)
+	config.AllowedRunnerConstraints = nil
+	if isKnownAndSet(m.AllowedConstraints) {
+		constraints := make(map[string][]string)
+		diags.Append(m.AllowedConstraints.ElementsAs(ctx, &constraints, false)...)
+		config.AllowedRunnerConstraints = constraints
+	}
+}
+
+// runsOnBlockFromAPI builds the runs_on block value from the wire fields.
+func runsOnBlockFromAPI(ctx context.Context, config stepsecurityapi.RunPolicyConfig, diags *diag.Diagnostics) types.Object {
+	m := &githubRunPolicyRunsOnModel{}
+	m.fromAPI(ctx, config, diags)
+
+	obj, objDiags := types.ObjectValue(runsOnBlockAttrTypes, map[string]attr.Value{
+		"mode":                m.RunsOnMode,
+		"disallowed_labels":   m.DisallowedRunnerLabels,
+		"allowed_labels":      m.AllowedRunnerLabels,
+		"allowed_constraints": m.AllowedRunnerConstraints,
+	})
+	diags.Append(objDiags...)
+	return obj
+}
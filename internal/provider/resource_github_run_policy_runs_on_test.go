@@ -0,0 +1,339 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
+)
+
+func testRunsOnModel(mode string) *githubRunPolicyRunsOnModel {
+	return &githubRunPolicyRunsOnModel{
+		githubTypedRunPolicyModel:  testTypedRunPolicyCommon("Runs On"),
+		RunsOnMode:                 types.StringValue(mode),
+		DisallowedRunnerLabels:     types.SetNull(types.StringType),
+		EnableStandardRunnerLabels: types.BoolValue(false),
+		AllowedRunnerLabels:        types.SetNull(types.StringType),
+		AllowedRunnerConstraints:   types.MapNull(types.SetType{ElemType: types.StringType}),
+	}
+}
+
+func TestGithubRunPolicyRunsOn_BuildRequestAllowedMode(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	model := testRunsOnModel(runsOnModeAllowed)
+	model.AllowedRunnerLabels = types.SetValueMust(types.StringType, testStringAttrValues([]string{"ubuntu-latest"}))
+	model.AllowedRunnerConstraints = types.MapValueMust(types.SetType{ElemType: types.StringType}, map[string]attr.Value{
+		"family": types.SetValueMust(types.StringType, testStringAttrValues([]string{"c7a", "m7a"})),
+	})
+
+	var diags diag.Diagnostics
+	req := buildGithubTypedRunPolicyRequest(ctx, model, &diags)
+	require.False(t, diags.HasError(), "unexpected diags: %v", diags)
+
+	assert.True(t, req.PolicyConfig.EnableRunsOnPolicy)
+	assert.Equal(t, runsOnModeAllowed, req.PolicyConfig.RunsOnMode)
+	assert.Equal(t, map[string]struct{}{"ubuntu-latest": {}}, req.PolicyConfig.AllowedRunnerLabels)
+	assert.ElementsMatch(t, []string{"c7a", "m7a"}, req.PolicyConfig.AllowedRunnerConstraints["family"])
+	assert.Nil(t, req.PolicyConfig.DisallowedRunnerLabels)
+}
+
+func TestGithubRunPolicyRunsOn_ApplyTreatsEmptyModeAsDisallowed(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	model := &githubRunPolicyRunsOnModel{}
+
+	var diags diag.Diagnostics
+	model.fromAPI(ctx, stepsecurityapi.RunPolicyConfig{
+		EnableRunsOnPolicy:     true,
+		DisallowedRunnerLabels: map[string]struct{}{"self-hosted": {}},
+	}, &diags)
+	require.False(t, diags.HasError())
+
+	assert.Equal(t, runsOnModeDisallowed, model.RunsOnMode.ValueString())
+	assert.Equal(t, []string{"self-hosted"}, setStrings(t, model.DisallowedRunnerLabels))
+	assert.True(t, model.AllowedRunnerLabels.IsNull())
+	assert.True(t, model.AllowedRunnerConstraints.IsNull())
+}
+
+func TestGithubRunPolicyRunsOn_Validate(t *testing.T) {
+	t.Parallel()
+
+	labels := types.SetValueMust(types.StringType, testStringAttrValues([]string{"self-hosted"}))
+
+	testCases := []struct {
+		name        string
+		model       func() *githubRunPolicyRunsOnModel
+		errorDetail string
+	}{
+		{
+			name: "disallowed mode with labels",
+			model: func() *githubRunPolicyRunsOnModel {
+				m := testRunsOnModel(runsOnModeDisallowed)
+				m.DisallowedRunnerLabels = labels
+				return m
+			},
+		},
+		{
+			name: "disallowed mode with standard labels only",
+			model: func() *githubRunPolicyRunsOnModel {
+				m := testRunsOnModel(runsOnModeDisallowed)
+				m.EnableStandardRunnerLabels = types.BoolValue(true)
+				return m
+			},
+		},
+		{
+			name: "disallowed mode without labels",
+			model: func() *githubRunPolicyRunsOnModel {
+				return testRunsOnModel(runsOnModeDisallowed)
+			},
+			errorDetail: "the policy blocks nothing",
+		},
+		{
+			name: "disallowed mode with allowed labels",
+			model: func() *githubRunPolicyRunsOnModel {
+				m := testRunsOnModel(runsOnModeDisallowed)
+				m.DisallowedRunnerLabels = labels
+				m.AllowedRunnerLabels = labels
+				return m
+			},
+			errorDetail: `allowed_runner_labels is only used when runs_on_mode is "allowed"`,
+		},
+		{
+			name: "allowed mode with disallowed labels",
+			model: func() *githubRunPolicyRunsOnModel {
+				m := testRunsOnModel(runsOnModeAllowed)
+				m.AllowedRunnerLabels = labels
+				m.DisallowedRunnerLabels = labels
+				return m
+			},
+			errorDetail: `disallowed_runner_labels is only used when runs_on_mode is "disallowed"`,
+		},
+		{
+			name: "allowed mode without allow list",
+			model: func() *githubRunPolicyRunsOnModel {
+				return testRunsOnModel(runsOnModeAllowed)
+			},
+			errorDetail: "every job is blocked",
+		},
+		{
+			name: "uppercase constraint key",
+			model: func() *githubRunPolicyRunsOnModel {
+				m := testRunsOnModel(runsOnModeAllowed)
+				m.AllowedRunnerConstraints = types.MapValueMust(types.SetType{ElemType: types.StringType}, map[string]attr.Value{
+					"Family": types.SetValueMust(types.StringType, testStringAttrValues([]string{"c7a"})),
+				})
+				return m
+			},
+			errorDetail: `Constraint key "Family" must be lowercase`,
+		},
+		{
+			name: "empty constraint values",
+			model: func() *githubRunPolicyRunsOnModel {
+				m := testRunsOnModel(runsOnModeAllowed)
+				m.AllowedRunnerConstraints = types.MapValueMust(types.SetType{ElemType: types.StringType}, map[string]attr.Value{
+					"cpu": types.SetValueMust(types.StringType, []attr.Value{}),
+				})
+				return m
+			},
+			errorDetail: `Constraint key "cpu" must have at least one allowed value`,
+		},
+		{
+			name: "unknown allowed labels are deferred",
+			model: func() *githubRunPolicyRunsOnModel {
+				m := testRunsOnModel(runsOnModeDisallowed)
+				m.DisallowedRunnerLabels = labels
+				m.AllowedRunnerLabels = types.SetUnknown(types.StringType)
+				return m
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			diags := tc.model().validate(context.Background())
+			if tc.errorDetail == "" {
+				assert.False(t, diags.HasError(), "unexpected diags: %v", diags)
+				return
+			}
+			require.True(t, diags.HasError())
+			assert.Contains(t, diags.Errors()[0].Detail(), tc.errorDetail)
+		})
+	}
+}
+
+func TestRunsOnBlock_Validate(t *testing.T) {
+	t.Parallel()
+
+	nullBlock := runsOnBlockModel{
+		Mode:               types.StringValue(runsOnModeAllowed),
+		DisallowedLabels:   types.SetNull(types.StringType),
+		AllowedLabels:      types.SetNull(types.StringType),
+		AllowedConstraints: types.MapNull(types.SetType{ElemType: types.StringType}),
+	}
+
+	testCases := []struct {
+		name          string
+		block         func() runsOnBlockModel
+		standardLabel bool
+		errorDetail   string
+	}{
+		{
+			name: "allowed mode with labels",
+			block: func() runsOnBlockModel {
+				b := nullBlock
+				b.AllowedLabels = types.SetValueMust(types.StringType, testStringAttrValues([]string{"ubuntu-latest"}))
+				return b
+			},
+		},
+		{
+			name: "allowed mode with deny list",
+			block: func() runsOnBlockModel {
+				b := nullBlock
+				b.AllowedLabels = types.SetValueMust(types.StringType, testStringAttrValues([]string{"ubuntu-latest"}))
+				b.DisallowedLabels = types.SetValueMust(types.StringType, testStringAttrValues([]string{"self-hosted"}))
+				return b
+			},
+			errorDetail: `policy_config.runs_on.disallowed_labels is only used when policy_config.runs_on.mode is "disallowed"`,
+		},
+		{
+			name: "allowed mode with standard labels",
+			block: func() runsOnBlockModel {
+				b := nullBlock
+				b.AllowedLabels = types.SetValueMust(types.StringType, testStringAttrValues([]string{"ubuntu-latest"}))
+				return b
+			},
+			standardLabel: true,
+			errorDetail:   `policy_config.enable_standard_runner_labels is only used when policy_config.runs_on.mode is "disallowed"`,
+		},
+		{
+			name: "disallowed mode with allow list",
+			block: func() runsOnBlockModel {
+				b := nullBlock
+				b.Mode = types.StringValue(runsOnModeDisallowed)
+				b.DisallowedLabels = types.SetValueMust(types.StringType, testStringAttrValues([]string{"self-hosted"}))
+				b.AllowedLabels = types.SetValueMust(types.StringType, testStringAttrValues([]string{"ubuntu-latest"}))
+				return b
+			},
+			errorDetail: `policy_config.runs_on.allowed_labels is only used when policy_config.runs_on.mode is "allowed"`,
+		},
+		{
+			name: "disallowed mode with constraints",
+			block: func() runsOnBlockModel {
+				b := nullBlock
+				b.Mode = types.StringValue(runsOnModeDisallowed)
+				b.DisallowedLabels = types.SetValueMust(types.StringType, testStringAttrValues([]string{"self-hosted"}))
+				b.AllowedConstraints = types.MapValueMust(types.SetType{ElemType: types.StringType}, map[string]attr.Value{
+					"family": types.SetValueMust(types.StringType, testStringAttrValues([]string{"c7a"})),
+				})
+				return b
+			},
+			errorDetail: `policy_config.runs_on.allowed_constraints is only used when policy_config.runs_on.mode is "allowed"`,
+		},
+		{
+			name: "label both allowed and disallowed",
+			block: func() runsOnBlockModel {
+				b := nullBlock
+				b.AllowedLabels = types.SetValueMust(types.StringType, testStringAttrValues([]string{"Self-Hosted"}))
+				b.DisallowedLabels = types.SetValueMust(types.StringType, testStringAttrValues([]string{"self-hosted"}))
+				return b
+			},
+			errorDetail: `Runner label "Self-Hosted" is listed in both`,
+		},
+		{
+			name:        "allowed mode without allow list",
+			block:       func() runsOnBlockModel { return nullBlock },
+			errorDetail: "every job is blocked",
+		},
+		{
+			name: "disallowed mode without labels",
+			block: func() runsOnBlockModel {
+				b := nullBlock
+				b.Mode = types.StringValue(runsOnModeDisallowed)
+				return b
+			},
+			errorDetail: "the policy blocks nothing",
+		},
+		{
+			name: "disallowed mode with standard labels only",
+			block: func() runsOnBlockModel {
+				b := nullBlock
+				b.Mode = types.StringValue(runsOnModeDisallowed)
+				return b
+			},
+			standardLabel: true,
+		},
+		{
+			name: "uppercase constraint key",
+			block: func() runsOnBlockModel {
+				b := nullBlock
+				b.AllowedConstraints = types.MapValueMust(types.SetType{ElemType: types.StringType}, map[string]attr.Value{
+					"Family": types.SetValueMust(types.StringType, testStringAttrValues([]string{"c7a"})),
+				})
+				return b
+			},
+			errorDetail: `Constraint key "Family" must be lowercase`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			blockPath := path.Root("policy_config").AtName("runs_on")
+			diags := tc.block().validate(blockPath, types.BoolValue(tc.standardLabel))
+			if tc.errorDetail == "" {
+				assert.False(t, diags.HasError(), "unexpected diags: %v", diags)
+				return
+			}
+			require.True(t, diags.HasError())
+			assert.Contains(t, diags.Errors()[0].Detail(), tc.errorDetail)
+		})
+	}
+}
+
+func TestRunsOnBlock_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	block := runsOnBlockModel{
+		Mode:             types.StringValue(runsOnModeAllowed),
+		DisallowedLabels: types.SetValueMust(types.StringType, testStringAttrValues([]string{"self-hosted"})),
+		AllowedLabels:    types.SetValueMust(types.StringType, testStringAttrValues([]string{"ubuntu-latest"})),
+		AllowedConstraints: types.MapValueMust(types.SetType{ElemType: types.StringType}, map[string]attr.Value{
+			"family": types.SetValueMust(types.StringType, testStringAttrValues([]string{"c7a"})),
+		}),
+	}
+
+	var diags diag.Diagnostics
+	config := stepsecurityapi.RunPolicyConfig{EnableRunsOnPolicy: true}
+	block.toAPI(ctx, &config, &diags)
+	require.False(t, diags.HasError(), "unexpected diags: %v", diags)
+
+	assert.Equal(t, runsOnModeAllowed, config.RunsOnMode)
+	assert.Equal(t, map[string]struct{}{"self-hosted": {}}, config.DisallowedRunnerLabels)
+	assert.Equal(t, map[string]struct{}{"ubuntu-latest": {}}, config.AllowedRunnerLabels)
+	assert.Equal(t, map[string][]string{"family": {"c7a"}}, config.AllowedRunnerConstraints)
+
+	obj := runsOnBlockFromAPI(ctx, config, &diags)
+	require.False(t, diags.HasError(), "unexpected diags: %v", diags)
+
+	var got runsOnBlockModel
+	require.False(t, obj.As(ctx, &got, basetypes.ObjectAsOptions{}).HasError())
+	assert.Equal(t, runsOnModeAllowed, got.Mode.ValueString())
+	assert.Equal(t, []string{"self-hosted"}, setStrings(t, got.DisallowedLabels))
+	assert.Equal(t, []string{"ubuntu-latest"}, setStrings(t, got.AllowedLabels))
+	assert.False(t, got.AllowedConstraints.IsNull())
+}
@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
+)
+
+// NewGithubRunPolicySecretsResource is a helper function to simplify the provider implementation.
+func NewGithubRunPolicySecretsResource() resource.Resource {
+	return &githubTypedRunPolicyResource{kind: githubRunPolicySecretsKind}
+}
+
+// githubRunPolicySecretsKind exposes the secret exfiltration policy.
+var githubRunPolicySecretsKind = githubRunPolicyKind{
+	typeName: "_github_run_policy_secrets",
+	label:    "secrets",
+	description: "Manages a GitHub Actions secrets run policy in StepSecurity. " +
+		"Workflow runs that could exfiltrate secrets are blocked unless triggered by an exempted user.",
+	attributes: map[string]schema.Attribute{
+		"exempted_users": schema.SetAttribute{
+			ElementType:         types.StringType,
+			Optional:            true,
+			MarkdownDescription: "Set of exempted users (can be bots/usernames). These users will not be subject to the secrets policy checks.",
+			Validators: []validator.Set{
+				setvalidator.SizeAtLeast(1),
+			},
+		},
+		"bulk_secrets_only_mode": schema.BoolAttribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+			MarkdownDescription: "When enabled, enforcement is restricted to high-risk bulk secret-exposure attempts rather than all secret references. See the StepSecurity run-policies documentation for details.",
+		},
+		"secrets_analyze_default_branch": schema.BoolAttribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+			MarkdownDescription: "When true, runs on the repository default branch are also evaluated (by default only non-default-branch runs are). Honors `bulk_secrets_only_mode` and `exempted_users`.",
+		},
+	},
+	newModel: func() githubTypedRunPolicy {
+		return &githubRunPolicySecretsModel{}
+	},
+	matches: func(config stepsecurityapi.RunPolicyConfig) bool {
+		return config.EnableSecretsPolicy && len(runPolicyEnabledControls(config)) == 1
+	},
+}
+
+// githubRunPolicySecretsModel maps the secrets policy schema data.
+type githubRunPolicySecretsModel struct {
+	githubTypedRunPolicyModel
+	ExemptedUsers               types.Set  `tfsdk:"exempted_users"`
+	BulkSecretsOnlyMode         types.Bool `tfsdk:"bulk_secrets_only_mode"`
+	SecretsAnalyzeDefaultBranch types.Bool `tfsdk:"secrets_analyze_default_branch"`
+}
+
+func (m *githubRunPolicySecretsModel) validate(_ context.Context) diag.Diagnostics {
+	return nil
+}
+
+func (m *githubRunPolicySecretsModel) toAPI(ctx context.Context, config *stepsecurityapi.RunPolicyConfig, diags *diag.Diagnostics) {
+	config.EnableSecretsPolicy = true
+	config.ExemptedUsers = stringSetToAPI(ctx, m.ExemptedUsers, diags)
+	config.BulkSecretsOnlyMode = m.BulkSecretsOnlyMode.ValueBool()
+	config.SecretsAnalyzeDefaultBranch = m.SecretsAnalyzeDefaultBranch.ValueBool()
+}
+
+func (m *githubRunPolicySecretsModel) fromAPI(_ context.Context, config stepsecurityapi.RunPolicyConfig, diags *diag.Diagnostics) {
+	m.ExemptedUsers = stringSetFromAPI(config.ExemptedUsers, diags)
+	m.BulkSecretsOnlyMode = types.BoolValue(config.BulkSecretsOnlyMode)
+	m.SecretsAnalyzeDefaultBranch = types.BoolValue(config.SecretsAnalyzeDefaultBranch)
+}
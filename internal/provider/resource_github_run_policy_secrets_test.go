@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
+)
+
+func TestGithubRunPolicySecrets_BuildRequest(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	model := &githubRunPolicySecretsModel{
+		githubTypedRunPolicyModel:   testTypedRunPolicyCommon("Secrets"),
+		ExemptedUsers:               types.SetValueMust(types.StringType, testStringAttrValues([]string{"dependabot[bot]"})),
+		BulkSecretsOnlyMode:         types.BoolValue(true),
+		SecretsAnalyzeDefaultBranch: types.BoolValue(true),
+	}
+
+	var diags diag.Diagnostics
+	req := buildGithubTypedRunPolicyRequest(ctx, model, &diags)
+	require.False(t, diags.HasError(), "unexpected diags: %v", diags)
+
+	assert.True(t, req.PolicyConfig.EnableSecretsPolicy)
+	assert.Equal(t, []string{"dependabot[bot]"}, req.PolicyConfig.ExemptedUsers)
+	assert.True(t, req.PolicyConfig.BulkSecretsOnlyMode)
+	assert.True(t, req.PolicyConfig.SecretsAnalyzeDefaultBranch)
+	assert.False(t, req.PolicyConfig.EnableActionPolicy)
+}
+
+func TestGithubRunPolicySecrets_ApplyToModel(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	model := &githubRunPolicySecretsModel{}
+
+	var diags diag.Diagnostics
+	model.fromAPI(ctx, stepsecurityapi.RunPolicyConfig{
+		EnableSecretsPolicy: true,
+		ExemptedUsers:       []string{"renovate[bot]"},
+		BulkSecretsOnlyMode: true,
+	}, &diags)
+	require.False(t, diags.HasError())
+
+	assert.Equal(t, []string{"renovate[bot]"}, setStrings(t, model.ExemptedUsers))
+	assert.True(t, model.BulkSecretsOnlyMode.ValueBool())
+	assert.False(t, model.SecretsAnalyzeDefaultBranch.ValueBool())
+}
@@ -298,12 +298,13 @@ func TestGithubRunPolicyResource_UpdateSendsEmptyHardenRunnerSets(t *testing.T)
 	now := time.Date(2024, 2, 3, 4, 5, 6, 0, time.UTC)
 
 	state := githubRunPolicyResourceModel{
-		Owner:        types.StringValue("test-org"),
-		Name:         types.StringValue("Test Policy"),
-		PolicyID:     types.StringValue("policy-123"),
-		AllRepos:     types.BoolValue(true),
-		AllOrgs:      types.BoolValue(false),
-		Repositories: types.ListNull(types.StringType),
+		Owner:            types.StringValue("test-org"),
+		Name:             types.StringValue("Test Policy"),
+		PolicyID:         types.StringValue("policy-123"),
+		AllRepos:         types.BoolValue(true),
+		AllOrgs:          types.BoolValue(false),
+		Repositories:     types.ListNull(types.StringType),
+		RepositoryFilter: types.ObjectNull(repositoryFilterAttrTypes),
 		PolicyConfig: testRunPolicyConfigObjectValue(policyConfigModel{
 			Owner:                          types.StringValue("test-org"),
 			Name:                           types.StringValue("Test Policy"),
@@ -326,12 +327,13 @@ func TestGithubRunPolicyResource_UpdateSendsEmptyHardenRunnerSets(t *testing.T)
 	}
 
 	plan := githubRunPolicyResourceModel{
-		Owner:        types.StringValue("test-org"),
-		Name:         types.StringValue("Updated Policy"),
-		PolicyID:     types.StringValue("policy-123"),
-		AllRepos:     types.BoolValue(true),
-		AllOrgs:      types.BoolValue(false),
-		Repositories: types.ListNull(types.StringType),
+		Owner:            types.StringValue("test-org"),
+		Name:             types.StringValue("Updated Policy"),
+		PolicyID:         types.StringValue("policy-123"),
+		AllRepos:         types.BoolValue(true),
+		AllOrgs:          types.BoolValue(false),
+		Repositories:     types.ListNull(types.StringType),
+		RepositoryFilter: types.ObjectNull(repositoryFilterAttrTypes),
 		PolicyConfig: testRunPolicyConfigObjectValue(policyConfigModel{
 			Owner:                          types.StringValue("test-org"),
 			Name:                           types.StringValue("Updated Policy"),
@@ -425,12 +427,13 @@ func TestGithubRunPolicyResource_UpdatePreservesUnmanagedHardenRunnerFields(t *t
 	now := time.Date(2024, 2, 3, 4, 5, 6, 0, time.UTC)
 
 	state := githubRunPolicyResourceModel{
-		Owner:        types.StringValue("test-org"),
-		Name:         types.StringValue("Test Policy"),
-		PolicyID:     types.StringValue("policy-123"),
-		AllRepos:     types.BoolValue(true),
-		AllOrgs:      types.BoolValue(false),
-		Repositories: types.ListNull(types.StringType),
+		Owner:            types.StringValue("test-org"),
+		Name:             types.StringValue("Test Policy"),
+		PolicyID:         types.StringValue("policy-123"),
+		AllRepos:         types.BoolValue(true),
+		AllOrgs:          types.BoolValue(false),
+		Repositories:     types.ListNull(types.StringType),
+		RepositoryFilter: types.ObjectNull(repositoryFilterAttrTypes),
 		PolicyConfig: testRunPolicyConfigObjectValue(policyConfigModel{
 			Owner:                          types.StringValue("test-org"),
 			Name:                           types.StringValue("Test Policy"),
@@ -453,12 +456,13 @@ func TestGithubRunPolicyResource_UpdatePreservesUnmanagedHardenRunnerFields(t *t
 	}
 
 	plan := githubRunPolicyResourceModel{
-		Owner:        types.StringValue("test-org"),
-		Name:         types.StringValue("Updated Policy"),
-		PolicyID:     types.StringValue("policy-123"),
-		AllRepos:     types.BoolValue(true),
-		AllOrgs:      types.BoolValue(false),
-		Repositories: types.ListNull(types.StringType),
+		Owner:            types.StringValue("test-org"),
+		Name:             types.StringValue("Updated Policy"),
+		PolicyID:         types.StringValue("policy-123"),
+		AllRepos:         types.BoolValue(true),
+		AllOrgs:          types.BoolValue(false),
+		Repositories:     types.ListNull(types.StringType),
+		RepositoryFilter: types.ObjectNull(repositoryFilterAttrTypes),
 		PolicyConfig: testRunPolicyConfigObjectValue(policyConfigModel{
 			Owner:                          types.StringValue("test-org"),
 			Name:                           types.StringValue("Updated Policy"),
@@ -481,12 +485,13 @@ func TestGithubRunPolicyResource_UpdatePreservesUnmanagedHardenRunnerFields(t *t
 	}
 
 	config := githubRunPolicyResourceModel{
-		Owner:        types.StringValue("test-org"),
-		Name:         types.StringValue("Updated Policy"),
-		PolicyID:     types.StringNull(),
-		AllRepos:     types.BoolValue(true),
-		AllOrgs:      types.BoolNull(),
-		Repositories: types.ListNull(types.StringType),
+		Owner:            types.StringValue("test-org"),
+		Name:             types.StringValue("Updated Policy"),
+		PolicyID:         types.StringNull(),
+		AllRepos:         types.BoolValue(true),
+		AllOrgs:          types.BoolNull(),
+		Repositories:     types.ListNull(types.StringType),
+		RepositoryFilter: types.ObjectNull(repositoryFilterAttrTypes),
 		PolicyConfig: testRunPolicyConfigObjectValue(policyConfigModel{
 			Owner:                          types.StringValue("test-org"),
 			Name:                           types.StringValue("Updated Policy"),
@@ -567,12 +572,13 @@ func TestGithubRunPolicyResource_EmptyLabelsMatchAllJobs(t *testing.T) {
 	now := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
 
 	plan := githubRunPolicyResourceModel{
-		Owner:        types.StringValue("test-org"),
-		Name:         types.StringValue("All Jobs Policy"),
-		PolicyID:     types.StringNull(),
-		AllRepos:     types.BoolValue(true),
-		AllOrgs:      types.BoolValue(false),
-		Repositories: types.ListNull(types.StringType),
+		Owner:            types.StringValue("test-org"),
+		Name:             types.StringValue("All Jobs Policy"),
+		PolicyID:         types.StringNull(),
+		AllRepos:         types.BoolValue(true),
+		AllOrgs:          types.BoolValue(false),
+		Repositories:     types.ListNull(types.StringType),
+		RepositoryFilter: types.ObjectNull(repositoryFilterAttrTypes),
 		PolicyConfig: testRunPolicyConfigObjectValue(policyConfigModel{
 			Owner:                          types.StringValue("test-org"),
 			Name:                           types.StringValue("All Jobs Policy"),
@@ -712,6 +718,224 @@ func TestGithubRunPolicyResource_ImportAllJobsPolicyLandsAsNull(t *testing.T) {
 	assert.True(t, policyConfig.HardenRunnerCustomActions.IsNull())
 }
 
+func testRunsOnBlockPlan(enableRunsOnPolicy bool, runsOn types.Object) githubRunPolicyResourceModel {
+	return githubRunPolicyResourceModel{
+		Owner:            types.StringValue("test-org"),
+		Name:             types.StringValue("Runner Labels"),
+		PolicyID:         types.StringNull(),
+		AllRepos:         types.BoolValue(true),
+		AllOrgs:          types.BoolValue(false),
+		Repositories:     types.ListNull(types.StringType),
+		RepositoryFilter: types.ObjectNull(repositoryFilterAttrTypes),
+		PolicyConfig: testRunPolicyConfigObjectValue(policyConfigModel{
+			Owner:                          types.StringValue("test-org"),
+			Name:                           types.StringValue("Runner Labels"),
+			EnableActionPolicy:             types.BoolValue(false),
+			AllowedActions:                 types.MapNull(types.StringType),
+			EnableHardenRunnerPolicy:       types.BoolValue(false),
+			HardenRunnerTargetLabels:       types.SetNull(types.StringType),
+			HardenRunnerCustomActions:      types.SetNull(types.StringType),
+			EnableRunsOnPolicy:             types.BoolValue(enableRunsOnPolicy),
+			EnableStandardRunnerLabels:     types.BoolValue(false),
+			DisallowedRunnerLabels:         types.SetNull(types.StringType),
+			EnableSecretsPolicy:            types.BoolValue(false),
+			EnableCompromisedActionsPolicy: types.BoolValue(false),
+			IsDryRun:                       types.BoolValue(false),
+			ExemptedUsers:                  types.SetNull(types.StringType),
+			RunsOnMode:                     types.StringValue(""),
+			RunsOn:                         runsOn,
+		}),
+		CreatedBy:     types.StringNull(),
+		CreatedAt:     types.StringNull(),
+		LastUpdatedBy: types.StringNull(),
+		LastUpdatedAt: types.StringNull(),
+	}
+}
+
+func testRunsOnBlockValue(mode string, allowed, disallowed []string) types.Object {
+	toSet := func(values []string) types.Set {
+		if values == nil {
+			return types.SetNull(types.StringType)
+		}
+		return types.SetValueMust(types.StringType, testStringAttrValues(values))
+	}
+	return types.ObjectValueMust(runsOnBlockAttrTypes, map[string]attr.Value{
+		"mode":                types.StringValue(mode),
+		"allowed_labels":      toSet(allowed),
+		"disallowed_labels":   toSet(disallowed),
+		"allowed_constraints": types.MapNull(types.SetType{ElemType: types.StringType}),
+	})
+}
+
+func TestGithubRunPolicyResource_ValidateConfigRunsOnBlock(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		enabled     bool
+		runsOn      types.Object
+		errorDetail string
+	}{
+		{
+			name:    "valid block",
+			enabled: true,
+			runsOn:  testRunsOnBlockValue(runsOnModeAllowed, []string{"ubuntu-latest"}, nil),
+		},
+		{
+			name:        "deny list in allowed mode",
+			enabled:     true,
+			runsOn:      testRunsOnBlockValue(runsOnModeAllowed, []string{"ubuntu-latest"}, []string{"self-hosted"}),
+			errorDetail: `policy_config.runs_on.disallowed_labels is only used when policy_config.runs_on.mode is "disallowed"`,
+		},
+		{
+			name:    "no block",
+			enabled: false,
+			runsOn:  types.ObjectNull(runsOnBlockAttrTypes),
+		},
+		{
+			name:        "runs-on policy disabled",
+			enabled:     false,
+			runsOn:      testRunsOnBlockValue(runsOnModeAllowed, []string{"ubuntu-latest"}, nil),
+			errorDetail: "only evaluated when enable_runs_on_policy is true",
+		},
+		{
+			name:        "conflicting labels",
+			enabled:     true,
+			runsOn:      testRunsOnBlockValue(runsOnModeAllowed, []string{"self-hosted"}, []string{"self-hosted"}),
+			errorDetail: "listed in both allowed_labels and disallowed_labels",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := &githubRunPolicyResource{}
+			resp := &fwresource.ValidateConfigResponse{}
+			r.ValidateConfig(context.Background(), fwresource.ValidateConfigRequest{
+				Config: testGithubRunPolicyConfig(t, testRunsOnBlockPlan(tc.enabled, tc.runsOn)),
+			}, resp)
+
+			if tc.errorDetail == "" {
+				assert.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+				return
+			}
+			require.True(t, resp.Diagnostics.HasError())
+			assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), tc.errorDetail)
+		})
+	}
+}
+
+func TestGithubRunPolicyResource_CreateWithRunsOnBlock(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	plan := testRunsOnBlockPlan(true, testRunsOnBlockValue(runsOnModeAllowed, []string{"ubuntu-latest"}, []string{"self-hosted"}))
+
+	mockClient := &stepsecurityapi.MockStepSecurityClient{}
+	mockClient.
+		On("CreateRunPolicy", mock.Anything, "test-org", mock.MatchedBy(func(req stepsecurityapi.CreateRunPolicyRequest) bool {
+			return req.PolicyConfig.RunsOnMode == runsOnModeAllowed &&
+				reflect.DeepEqual(req.PolicyConfig.AllowedRunnerLabels, map[string]struct{}{"ubuntu-latest": {}}) &&
+				reflect.DeepEqual(req.PolicyConfig.DisallowedRunnerLabels, map[string]struct{}{"self-hosted": {}})
+		})).
+		Return(&stepsecurityapi.RunPolicy{
+			Owner:         "test-org",
+			PolicyID:      "policy-runs-on",
+			Name:          "Runner Labels",
+			CreatedBy:     "test-user",
+			CreatedAt:     now,
+			LastUpdatedBy: "test-user",
+			LastUpdatedAt: now,
+			AllRepos:      true,
+			PolicyConfig: stepsecurityapi.RunPolicyConfig{
+				Owner:                  "test-org",
+				Name:                   "Runner Labels",
+				EnableRunsOnPolicy:     true,
+				RunsOnMode:             runsOnModeAllowed,
+				AllowedRunnerLabels:    map[string]struct{}{"ubuntu-latest": {}},
+				DisallowedRunnerLabels: map[string]struct{}{"self-hosted": {}},
+			},
+		}, nil).
+		Once()
+
+	r := &githubRunPolicyResource{client: mockClient}
+	resp := &fwresource.CreateResponse{
+		State: tfsdk.State{Schema: testGithubRunPolicyResourceSchema(t)},
+	}
+	r.Create(ctx, fwresource.CreateRequest{Plan: testGithubRunPolicyPlan(t, plan)}, resp)
+
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+	mockClient.AssertExpectations(t)
+
+	var state githubRunPolicyResourceModel
+	require.False(t, resp.State.Get(ctx, &state).HasError())
+
+	var policyConfig policyConfigModel
+	require.False(t, state.PolicyConfig.As(ctx, &policyConfig, basetypes.ObjectAsOptions{}).HasError())
+
+	// The block owns the runner-label fields, so the flat attributes stay unset in state.
+	assert.True(t, policyConfig.DisallowedRunnerLabels.IsNull())
+	assert.True(t, policyConfig.AllowedRunnerLabels.IsNull())
+
+	var runsOn runsOnBlockModel
+	require.False(t, policyConfig.RunsOn.As(ctx, &runsOn, basetypes.ObjectAsOptions{}).HasError())
+	assert.Equal(t, runsOnModeAllowed, runsOn.Mode.ValueString())
+	assert.Equal(t, []string{"ubuntu-latest"}, setStrings(t, runsOn.AllowedLabels))
+	assert.Equal(t, []string{"self-hosted"}, setStrings(t, runsOn.DisallowedLabels))
+}
+
+func TestGithubRunPolicyResource_CreateWithRepositoryFilter(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	plan := testRunsOnBlockPlan(true, testRunsOnBlockValue(runsOnModeDisallowed, nil, []string{"self-hosted"}))
+	plan.RepositoryFilter = testRepositoryFilterValue([]string{"svc-*"}, nil, []string{"payments"}, nil)
+
+	expectedFilter := &stepsecurityapi.RunPolicyRepositoryFilter{
+		IncludePatterns: []string{"svc-*"},
+		Topics:          []string{"payments"},
+	}
+
+	mockClient := &stepsecurityapi.MockStepSecurityClient{}
+	mockClient.
+		On("CreateRunPolicy", mock.Anything, "test-org", mock.MatchedBy(func(req stepsecurityapi.CreateRunPolicyRequest) bool {
+			return req.AllRepos && req.Repositories == nil && reflect.DeepEqual(req.RepositoryFilter, expectedFilter)
+		})).
+		Return(&stepsecurityapi.RunPolicy{
+			Owner:            "test-org",
+			PolicyID:         "policy-filtered",
+			Name:             "Runner Labels",
+			CreatedAt:        now,
+			LastUpdatedAt:    now,
+			AllRepos:         true,
+			RepositoryFilter: expectedFilter,
+			PolicyConfig: stepsecurityapi.RunPolicyConfig{
+				Owner:                  "test-org",
+				Name:                   "Runner Labels",
+				EnableRunsOnPolicy:     true,
+				RunsOnMode:             runsOnModeDisallowed,
+				DisallowedRunnerLabels: map[string]struct{}{"self-hosted": {}},
+			},
+		}, nil).
+		Once()
+
+	r := &githubRunPolicyResource{client: mockClient}
+	resp := &fwresource.CreateResponse{
+		State: tfsdk.State{Schema: testGithubRunPolicyResourceSchema(t)},
+	}
+	r.Create(ctx, fwresource.CreateRequest{Plan: testGithubRunPolicyPlan(t, plan)}, resp)
+
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+	mockClient.AssertExpectations(t)
+
+	var state githubRunPolicyResourceModel
+	require.False(t, resp.State.Get(ctx, &state).HasError())
+	assert.True(t, state.RepositoryFilter.Equal(plan.RepositoryFilter))
+}
+
 func testGithubRunPolicyResourceSchema(t *testing.T) resourceschema.Schema {
 	t.Helper()
 
@@ -766,6 +990,11 @@ func testRunPolicyConfigObjectValue(policyConfig policyConfigModel) types.Object
 		allowedRunnerConstraints = types.MapNull(types.SetType{ElemType: types.StringType})
 	}
 
+	runsOn := policyConfig.RunsOn
+	if reflect.DeepEqual(runsOn, types.Object{}) {
+		runsOn = types.ObjectNull(runsOnBlockAttrTypes)
+	}
+
 	return types.ObjectValueMust(map[string]attr.Type{
 		"owner":                             types.StringType,
 		"name":                              types.StringType,
@@ -788,6 +1017,7 @@ func testRunPolicyConfigObjectValue(policyConfig policyConfigModel) types.Object
 		"runs_on_mode":                      types.StringType,
 		"allowed_runner_labels":             types.SetType{ElemType: types.StringType},
 		"allowed_runner_constraints":        types.MapType{ElemType: types.SetType{ElemType: types.StringType}},
+		"runs_on":                           types.ObjectType{AttrTypes: runsOnBlockAttrTypes},
 		"require_policy_store":              types.BoolType,
 		"block_job_container":               types.BoolType,
 		"secrets_analyze_default_branch":    types.BoolType,
@@ -813,6 +1043,7 @@ func testRunPolicyConfigObjectValue(policyConfig policyConfigModel) types.Object
 		"runs_on_mode":                      policyConfig.RunsOnMode,
 		"allowed_runner_labels":             allowedRunnerLabels,
 		"allowed_runner_constraints":        allowedRunnerConstraints,
+		"runs_on":                           runsOn,
 		"require_policy_store":              policyConfig.RequirePolicyStore,
 		"block_job_container":               policyConfig.BlockJobContainer,
 		"secrets_analyze_default_branch":    policyConfig.SecretsAnalyzeDefaultBranch,
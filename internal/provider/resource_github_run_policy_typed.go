@@ -0,0 +1,513 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &githubTypedRunPolicyResource{}
+	_ resource.ResourceWithConfigure      = &githubTypedRunPolicyResource{}
+	_ resource.ResourceWithImportState    = &githubTypedRunPolicyResource{}
+	_ resource.ResourceWithValidateConfig = &githubTypedRunPolicyResource{}
+)
+
+// githubTypedRunPolicyResource is the shared implementation behind the per-policy-type
+// run policy resources (stepsecurity_github_run_policy_allowed_actions, _runs_on, ...).
+// Every type is stored as a regular run policy through the same client calls as
+// stepsecurity_github_run_policy; the kind only decides which slice of RunPolicyConfig
+// the resource exposes, validates and owns.
+type githubTypedRunPolicyResource struct {
+	kind   githubRunPolicyKind
+	client stepsecurityapi.Client
+}
+
+// githubRunPolicyKind describes one policy type.
+type githubRunPolicyKind struct {
+	// typeName is appended to the provider type name, e.g. "_github_run_policy_secrets".
+	typeName string
+	// label names the policy type in diagnostics, e.g. "secrets".
+	label       string
+	description string
+	// attributes are the type-specific schema attributes, merged with the shared ones.
+	attributes map[string]schema.Attribute
+	// newModel returns a pointer to an empty type-specific model.
+	newModel func() githubTypedRunPolicy
+	// matches reports whether a policy returned by the API is of this type and enables
+	// no other control.
+	matches func(config stepsecurityapi.RunPolicyConfig) bool
+}
+
+// runPolicyEnabledControls names the controls enabled in a policy config. The action
+// policy is one control, named "pinned actions" when it requires pinned actions.
+func runPolicyEnabledControls(config stepsecurityapi.RunPolicyConfig) []string {
+	var controls []string
+	if config.EnableActionPolicy {
+		if config.RequirePinnedActions {
+			controls = append(controls, "pinned actions")
+		} else {
+			controls = append(controls, "allowed actions")
+		}
+	}
+	if config.EnableHardenRunnerPolicy {
+		controls = append(controls, "harden runner")
+	}
+	if config.EnableRunsOnPolicy {
+		controls = append(controls, "runs-on")
+	}
+	if config.EnableSecretsPolicy {
+		controls = append(controls, "secrets")
+	}
+	if config.EnableCompromisedActionsPolicy {
+		controls = append(controls, "compromised actions")
+	}
+	return controls
+}
+
+// githubTypedRunPolicy is implemented by the type-specific models.
+type githubTypedRunPolicy interface {
+	// common returns the attributes shared by every policy type.
+	common() *githubTypedRunPolicyModel
+	// validate runs cross-field validation on a configuration or plan.
+	validate(ctx context.Context) diag.Diagnostics
+	// toAPI writes the type-specific fields into the request policy config.
+	toAPI(ctx context.Context, config *stepsecurityapi.RunPolicyConfig, diags *diag.Diagnostics)
+	// fromAPI reads the type-specific fields from an API policy config.
+	fromAPI(ctx context.Context, config stepsecurityapi.RunPolicyConfig, diags *diag.Diagnostics)
+}
+
+// githubTypedRunPolicyModel maps the schema attributes shared by every policy type.
+// Type-specific models embed it by value.
+type githubTypedRunPolicyModel struct {
+	Owner             types.String `tfsdk:"owner"`
+	Name              types.String `tfsdk:"name"`
+	PolicyID          types.String `tfsdk:"policy_id"`
+	AllRepos          types.Bool   `tfsdk:"all_repos"`
+	AllOrgs           types.Bool   `tfsdk:"all_orgs"`
+	Repositories      types.List   `tfsdk:"repositories"`
+	RepositoryFilter  types.Object `tfsdk:"repository_filter"`
+	IsDryRun          types.Bool   `tfsdk:"is_dry_run"`
+	PrCommentTemplate types.String `tfsdk:"pr_comment_template"`
+	CreatedBy         types.String `tfsdk:"created_by"`
+	CreatedAt         types.String `tfsdk:"created_at"`
+	LastUpdatedBy     types.String `tfsdk:"last_updated_by"`
+	LastUpdatedAt     types.String `tfsdk:"last_updated_at"`
+}
+
+func (m *githubTypedRunPolicyModel) common() *githubTypedRunPolicyModel {
+	return m
+}
+
+// githubTypedRunPolicyCommonAttributes returns the schema attributes shared by every policy type.
+func githubTypedRunPolicyCommonAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"owner": schema.StringAttribute{
+			Required:            true,
+			MarkdownDescription: "The GitHub organization or user that owns this policy.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			},
+		},
+		"name": schema.StringAttribute{
+			Required:            true,
+			MarkdownDescription: "The name of the run policy.",
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			},
+		},
+		"policy_id": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The unique identifier for this policy generated by StepSecurity.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"all_repos": schema.BoolAttribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+			MarkdownDescription: "Whether this policy applies to all repositories in the organization.",
+		},
+		"all_orgs": schema.BoolAttribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+			MarkdownDescription: "Whether this policy applies to all organizations.",
+		},
+		"repositories": schema.ListAttribute{
+			ElementType:         types.StringType,
+			Optional:            true,
+			MarkdownDescription: "List of specific repositories this policy applies to.",
+		},
+		"repository_filter": repositoryFilterAttribute(),
+		"is_dry_run": schema.BoolAttribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+			MarkdownDescription: "Whether this policy is in dry-run mode. Violations are reported but runs are not blocked.",
+		},
+		"pr_comment_template": schema.StringAttribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString(""),
+			MarkdownDescription: "Optional custom template for the pull request comment posted when this policy blocks a run. Supports placeholder substitution; leave empty to use the default StepSecurity comment.",
+		},
+		"created_by": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The user who created this policy.",
+		},
+		"created_at": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The timestamp when this policy was created.",
+		},
+		"last_updated_by": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The user who last updated this policy.",
+		},
+		"last_updated_at": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The timestamp when this policy was last updated.",
+		},
+	}
+}
+
+// Metadata returns the resource type name.
+func (r *githubTypedRunPolicyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + r.kind.typeName
+}
+
+// Schema defines the schema for the resource.
+func (r *githubTypedRunPolicyResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := githubTypedRunPolicyCommonAttributes()
+	for name, attribute := range r.kind.attributes {
+		attributes[name] = attribute
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: r.kind.description,
+		Attributes:          attributes,
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *githubTypedRunPolicyResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(stepsecurityapi.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected stepsecurityapi.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// ValidateConfig validates the repository selection and runs the type-specific
+// cross-field validation.
+func (r *githubTypedRunPolicyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	model := r.kind.newModel()
+	resp.Diagnostics.Append(req.Config.Get(ctx, model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	common := model.common()
+	resp.Diagnostics.Append(validateRepositoryFilter(ctx, common.RepositoryFilter, common.AllRepos, common.AllOrgs)...)
+	resp.Diagnostics.Append(model.validate(ctx)...)
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *githubTypedRunPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	plan := r.kind.newModel()
+	resp.Diagnostics.Append(req.Plan.Get(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createRequest := stepsecurityapi.CreateRunPolicyRequest(buildGithubTypedRunPolicyRequest(ctx, plan, &resp.Diagnostics))
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Creating run policy", map[string]interface{}{
+		"owner": plan.common().Owner.ValueString(),
+		"name":  plan.common().Name.ValueString(),
+		"type":  r.kind.label,
+	})
+
+	created, err := r.client.CreateRunPolicy(ctx, plan.common().Owner.ValueString(), createRequest)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating "+r.kind.label+" run policy",
+			"Could not create run policy, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	r.applyGithubTypedRunPolicyToModel(ctx, created, plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *githubTypedRunPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	state := r.kind.newModel()
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policy, err := r.client.GetRunPolicy(ctx, state.common().Owner.ValueString(), state.common().PolicyID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading "+r.kind.label+" run policy",
+			"Could not read run policy ID "+state.common().PolicyID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	r.applyGithubTypedRunPolicyToModel(ctx, policy, state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *githubTypedRunPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	plan := r.kind.newModel()
+	resp.Diagnostics.Append(req.Plan.Get(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateRequest := stepsecurityapi.UpdateRunPolicyRequest(buildGithubTypedRunPolicyRequest(ctx, plan, &resp.Diagnostics))
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updated, err := r.client.UpdateRunPolicy(ctx, plan.common().Owner.ValueString(), plan.common().PolicyID.ValueString(), updateRequest)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating "+r.kind.label+" run policy",
+			"Could not update run policy, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	r.applyGithubTypedRunPolicyToModel(ctx, updated, plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *githubTypedRunPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	state := r.kind.newModel()
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteRunPolicy(ctx, state.common().Owner.ValueString(), state.common().PolicyID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting "+r.kind.label+" run policy",
+			"Could not delete run policy, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports the resource using an owner/policy_id identifier and lets Read
+// populate the rest.
+func (r *githubTypedRunPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	splitted := strings.Split(req.ID, "/")
+	if len(splitted) != 2 || splitted[0] == "" || splitted[1] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected owner/policy_id, got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("owner"), splitted[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("policy_id"), splitted[1])...)
+}
+
+// buildGithubTypedRunPolicyRequest converts a typed model into a run policy request.
+// The embedded policy config owner and name always follow the top-level attributes,
+// and only the fields owned by the policy type are set; everything else stays at its
+// zero value so the policy never carries another type's enforcement.
+func buildGithubTypedRunPolicyRequest(ctx context.Context, model githubTypedRunPolicy, diags *diag.Diagnostics) stepsecurityapi.UpdateRunPolicyRequest {
+	common := model.common()
+
+	request := stepsecurityapi.UpdateRunPolicyRequest{
+		Name:     common.Name.ValueString(),
+		AllRepos: common.AllRepos.ValueBool(),
+		AllOrgs:  common.AllOrgs.ValueBool(),
+		PolicyConfig: stepsecurityapi.RunPolicyConfig{
+			Owner:             common.Owner.ValueString(),
+			Name:              common.Name.ValueString(),
+			IsDryRun:          common.IsDryRun.ValueBool(),
+			PrCommentTemplate: common.PrCommentTemplate.ValueString(),
+		},
+	}
+
+	if !common.Repositories.IsNull() && !common.Repositories.IsUnknown() {
+		var repos []string
+		diags.Append(common.Repositories.ElementsAs(ctx, &repos, false)...)
+		request.Repositories = repos
+	}
+	request.RepositoryFilter = repositoryFilterToAPI(ctx, common.RepositoryFilter, diags)
+
+	model.toAPI(ctx, &request.PolicyConfig, diags)
+	return request
+}
+
+// applyGithubTypedRunPolicyToModel applies an API run policy response into a typed model.
+// It rejects policies of another type, and policies that enable several controls,
+// because this resource would silently drop their other enforcement on the next update.
+func (r *githubTypedRunPolicyResource) applyGithubTypedRunPolicyToModel(ctx context.Context, policy *stepsecurityapi.RunPolicy, model githubTypedRunPolicy, diags *diag.Diagnostics) {
+	if controls := runPolicyEnabledControls(policy.PolicyConfig); len(controls) > 1 {
+		diags.AddError(
+			"Unsupported run policy type",
+			fmt.Sprintf(
+				"Run policy %q enables several controls (%s), so stepsecurity%s cannot manage it without dropping the others. Use stepsecurity_github_run_policy instead.",
+				policy.PolicyID, strings.Join(controls, ", "), r.kind.typeName,
+			),
+		)
+		return
+	}
+	if !r.kind.matches(policy.PolicyConfig) {
+		diags.AddError(
+			"Unsupported run policy type",
+			fmt.Sprintf(
+				"Run policy %q is not a %s policy, so stepsecurity%s cannot manage it. Use stepsecurity_github_run_policy or the resource matching its policy type instead.",
+				policy.PolicyID, r.kind.label, r.kind.typeName,
+			),
+		)
+		return
+	}
+
+	common := model.common()
+	policy = restoreExpandedRepositoryFilter(ctx, r.client, common.Owner.ValueString(), common.RepositoryFilter, policy, diags)
+
+	// when applied across org..preserve owner set in state/plan
+	if !strings.Contains(policy.Owner, "#[all]") {
+		common.Owner = types.StringValue(policy.Owner)
+	}
+	common.Name = types.StringValue(policy.Name)
+	common.PolicyID = types.StringValue(policy.PolicyID)
+	common.AllRepos = types.BoolValue(policy.AllRepos)
+	common.AllOrgs = types.BoolValue(policy.AllOrgs)
+	common.IsDryRun = types.BoolValue(policy.PolicyConfig.IsDryRun)
+	common.PrCommentTemplate = types.StringValue(policy.PolicyConfig.PrCommentTemplate)
+	common.CreatedBy = types.StringValue(policy.CreatedBy)
+	common.CreatedAt = types.StringValue(policy.CreatedAt.Format(time.RFC3339))
+	common.LastUpdatedBy = types.StringValue(policy.LastUpdatedBy)
+	common.LastUpdatedAt = types.StringValue(policy.LastUpdatedAt.Format(time.RFC3339))
+
+	if policy.Repositories != nil {
+		repoList := make([]attr.Value, len(policy.Repositories))
+		for i, repo := range policy.Repositories {
+			repoList[i] = types.StringValue(repo)
+		}
+		listValue, listDiags := types.ListValue(types.StringType, repoList)
+		diags.Append(listDiags...)
+		common.Repositories = listValue
+	} else {
+		common.Repositories = types.ListNull(types.StringType)
+	}
+	common.RepositoryFilter = repositoryFilterFromAPI(policy.RepositoryFilter, diags)
+
+	model.fromAPI(ctx, policy.PolicyConfig, diags)
+}
+
+// runnerLabelSetToAPI converts a label set into the map[string]struct{} shape expected by
+// the API. A null set stays nil so the field is omitted from the request.
+func runnerLabelSetToAPI(ctx context.Context, set types.Set, diags *diag.Diagnostics) map[string]struct{} {
+	if set.IsNull() || set.IsUnknown() {
+		return nil
+	}
+	var labels []string
+	diags.Append(set.ElementsAs(ctx, &labels, false)...)
+
+	result := make(map[string]struct{}, len(labels))
+	for _, label := range labels {
+		result[label] = struct{}{}
+	}
+	return result
+}
+
+// runnerLabelSetFromAPI converts the API label map into a Terraform set, keeping a nil
+// map as null.
+func runnerLabelSetFromAPI(labels map[string]struct{}, diags *diag.Diagnostics) types.Set {
+	if labels == nil {
+		return types.SetNull(types.StringType)
+	}
+	elems := make([]attr.Value, 0, len(labels))
+	for label := range labels {
+		elems = append(elems, types.StringValue(label))
+	}
+	setValue, setDiags := types.SetValue(types.StringType, elems)
+	diags.Append(setDiags...)
+	return setValue
+}
+
+// stringSetToAPI converts a set into a string slice, keeping a null set as nil.
+func stringSetToAPI(ctx context.Context, set types.Set, diags *diag.Diagnostics) []string {
+	if set.IsNull() || set.IsUnknown() {
+		return nil
+	}
+	var values []string
+	diags.Append(set.ElementsAs(ctx, &values, false)...)
+	return values
+}
+
+// stringSetFromAPI converts a string slice into a Terraform set, keeping nil as null and
+// an empty slice as an empty set.
+func stringSetFromAPI(values []string, diags *diag.Diagnostics) types.Set {
+	if values == nil {
+		return types.SetNull(types.StringType)
+	}
+	elems := make([]attr.Value, len(values))
+	for i, v := range values {
+		elems[i] = types.StringValue(v)
+	}
+	setValue, setDiags := types.SetValue(types.StringType, elems)
+	diags.Append(setDiags...)
+	return setValue
+}
@@ -0,0 +1,268 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
+)
+
+func testTypedRunPolicySchema(t *testing.T, r fwresource.Resource) fwresource.SchemaResponse {
+	t.Helper()
+
+	resp := fwresource.SchemaResponse{}
+	r.Schema(context.Background(), fwresource.SchemaRequest{}, &resp)
+	require.False(t, resp.Diagnostics.HasError(), "Schema() errors: %v", resp.Diagnostics)
+	return resp
+}
+
+func testTypedRunPolicyCommon(name string) githubTypedRunPolicyModel {
+	return githubTypedRunPolicyModel{
+		Owner:             types.StringValue("test-org"),
+		Name:              types.StringValue(name),
+		PolicyID:          types.StringUnknown(),
+		AllRepos:          types.BoolValue(true),
+		AllOrgs:           types.BoolValue(false),
+		Repositories:      types.ListNull(types.StringType),
+		RepositoryFilter:  types.ObjectNull(repositoryFilterAttrTypes),
+		IsDryRun:          types.BoolValue(false),
+		PrCommentTemplate: types.StringValue(""),
+		CreatedBy:         types.StringUnknown(),
+		CreatedAt:         types.StringUnknown(),
+		LastUpdatedBy:     types.StringUnknown(),
+		LastUpdatedAt:     types.StringUnknown(),
+	}
+}
+
+func TestGithubTypedRunPolicyResources_Metadata(t *testing.T) {
+	t.Parallel()
+
+	expected := map[string]func() fwresource.Resource{
+		"stepsecurity_github_run_policy_allowed_actions":     NewGithubRunPolicyAllowedActionsResource,
+		"stepsecurity_github_run_policy_pinned_actions":      NewGithubRunPolicyPinnedActionsResource,
+		"stepsecurity_github_run_policy_runs_on":             NewGithubRunPolicyRunsOnResource,
+		"stepsecurity_github_run_policy_secrets":             NewGithubRunPolicySecretsResource,
+		"stepsecurity_github_run_policy_compromised_actions": NewGithubRunPolicyCompromisedActionsResource,
+	}
+
+	for typeName, factory := range expected {
+		resp := &fwresource.MetadataResponse{}
+		factory().Metadata(context.Background(), fwresource.MetadataRequest{ProviderTypeName: "stepsecurity"}, resp)
+		assert.Equal(t, typeName, resp.TypeName)
+
+		schemaResp := testTypedRunPolicySchema(t, factory())
+		for _, name := range []string{"owner", "name", "policy_id", "all_repos", "all_orgs", "repositories", "is_dry_run", "pr_comment_template", "created_by", "created_at", "last_updated_by", "last_updated_at"} {
+			assert.Contains(t, schemaResp.Schema.Attributes, name, "%s is missing shared attribute %q", typeName, name)
+		}
+		// Typed resources never expose the combined policy_config block.
+		assert.NotContains(t, schemaResp.Schema.Attributes, "policy_config")
+	}
+}
+
+func TestGithubTypedRunPolicy_BuildRequestCommonFields(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	model := &githubRunPolicyCompromisedActionsModel{githubTypedRunPolicyModel: testTypedRunPolicyCommon("Compromised")}
+	model.AllRepos = types.BoolValue(false)
+	model.Repositories = types.ListValueMust(types.StringType, testStringAttrValues([]string{"repo-a", "repo-b"}))
+	model.IsDryRun = types.BoolValue(true)
+	model.PrCommentTemplate = types.StringValue("blocked by {{policy_name}}")
+
+	var diags diag.Diagnostics
+	req := buildGithubTypedRunPolicyRequest(ctx, model, &diags)
+	require.False(t, diags.HasError(), "unexpected diags: %v", diags)
+
+	assert.Equal(t, "Compromised", req.Name)
+	assert.False(t, req.AllRepos)
+	assert.Equal(t, []string{"repo-a", "repo-b"}, req.Repositories)
+	assert.Equal(t, "test-org", req.PolicyConfig.Owner)
+	assert.Equal(t, "Compromised", req.PolicyConfig.Name)
+	assert.True(t, req.PolicyConfig.IsDryRun)
+	assert.Equal(t, "blocked by {{policy_name}}", req.PolicyConfig.PrCommentTemplate)
+	assert.True(t, req.PolicyConfig.EnableCompromisedActionsPolicy)
+	assert.False(t, req.PolicyConfig.EnableActionPolicy)
+	assert.False(t, req.PolicyConfig.EnableSecretsPolicy)
+	assert.False(t, req.PolicyConfig.EnableRunsOnPolicy)
+}
+
+func TestGithubTypedRunPolicy_CreateUsesSharedClientCall(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	r := NewGithubRunPolicyCompromisedActionsResource().(*githubTypedRunPolicyResource)
+	schemaResp := testTypedRunPolicySchema(t, r)
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(ctx, &githubRunPolicyCompromisedActionsModel{githubTypedRunPolicyModel: testTypedRunPolicyCommon("Compromised")}).HasError())
+
+	mockClient := &stepsecurityapi.MockStepSecurityClient{}
+	mockClient.
+		On("CreateRunPolicy", mock.Anything, "test-org", mock.MatchedBy(func(req stepsecurityapi.CreateRunPolicyRequest) bool {
+			return req.Name == "Compromised" && req.AllRepos && req.PolicyConfig.EnableCompromisedActionsPolicy
+		})).
+		Return(&stepsecurityapi.RunPolicy{
+			Owner:         "test-org",
+			PolicyID:      "policy-1",
+			Name:          "Compromised",
+			CreatedBy:     "alice",
+			CreatedAt:     now,
+			LastUpdatedBy: "alice",
+			LastUpdatedAt: now,
+			AllRepos:      true,
+			PolicyConfig: stepsecurityapi.RunPolicyConfig{
+				Owner:                          "test-org",
+				Name:                           "Compromised",
+				EnableCompromisedActionsPolicy: true,
+			},
+		}, nil).
+		Once()
+	r.client = mockClient
+
+	resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, resp)
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+	mockClient.AssertExpectations(t)
+
+	var state githubRunPolicyCompromisedActionsModel
+	require.False(t, resp.State.Get(ctx, &state).HasError())
+	assert.Equal(t, "policy-1", state.PolicyID.ValueString())
+	assert.Equal(t, "alice", state.CreatedBy.ValueString())
+	assert.Equal(t, now.Format(time.RFC3339), state.CreatedAt.ValueString())
+	assert.True(t, state.Repositories.IsNull())
+}
+
+func TestGithubTypedRunPolicy_ReadRejectsOtherPolicyType(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := NewGithubRunPolicySecretsResource().(*githubTypedRunPolicyResource)
+
+	var diags diag.Diagnostics
+	model := &githubRunPolicySecretsModel{}
+	r.applyGithubTypedRunPolicyToModel(ctx, &stepsecurityapi.RunPolicy{
+		PolicyID: "policy-9",
+		PolicyConfig: stepsecurityapi.RunPolicyConfig{
+			EnableActionPolicy: true,
+		},
+	}, model, &diags)
+
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags.Errors()[0].Detail(), "is not a secrets policy")
+	assert.Contains(t, diags.Errors()[0].Detail(), "stepsecurity_github_run_policy_secrets")
+}
+
+func TestGithubTypedRunPolicy_ReadRejectsMixedPolicy(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		resource *githubTypedRunPolicyResource
+		model    githubTypedRunPolicy
+		config   stepsecurityapi.RunPolicyConfig
+	}{
+		{
+			name:     "secrets_with_actions_and_runs_on",
+			resource: NewGithubRunPolicySecretsResource().(*githubTypedRunPolicyResource),
+			model:    &githubRunPolicySecretsModel{},
+			config:   stepsecurityapi.RunPolicyConfig{EnableActionPolicy: true, EnableSecretsPolicy: true, EnableRunsOnPolicy: true},
+		},
+		{
+			name:     "runs_on_with_harden_runner",
+			resource: NewGithubRunPolicyRunsOnResource().(*githubTypedRunPolicyResource),
+			model:    &githubRunPolicyRunsOnModel{},
+			config:   stepsecurityapi.RunPolicyConfig{EnableRunsOnPolicy: true, EnableHardenRunnerPolicy: true},
+		},
+		{
+			name:     "pinned_actions_with_compromised_actions",
+			resource: NewGithubRunPolicyPinnedActionsResource().(*githubTypedRunPolicyResource),
+			model:    &githubRunPolicyPinnedActionsModel{},
+			config:   stepsecurityapi.RunPolicyConfig{EnableActionPolicy: true, RequirePinnedActions: true, EnableCompromisedActionsPolicy: true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var diags diag.Diagnostics
+			tc.resource.applyGithubTypedRunPolicyToModel(context.Background(), &stepsecurityapi.RunPolicy{
+				PolicyID:     "policy-9",
+				PolicyConfig: tc.config,
+			}, tc.model, &diags)
+
+			require.True(t, diags.HasError())
+			assert.Contains(t, diags.Errors()[0].Detail(), "enables several controls")
+			assert.Contains(t, diags.Errors()[0].Detail(), "Use stepsecurity_github_run_policy instead")
+		})
+	}
+}
+
+func TestGithubTypedRunPolicy_ApplyPreservesAllOrgsOwner(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := NewGithubRunPolicyCompromisedActionsResource().(*githubTypedRunPolicyResource)
+
+	model := &githubRunPolicyCompromisedActionsModel{githubTypedRunPolicyModel: testTypedRunPolicyCommon("Compromised")}
+	var diags diag.Diagnostics
+	r.applyGithubTypedRunPolicyToModel(ctx, &stepsecurityapi.RunPolicy{
+		Owner:    "#[all]",
+		PolicyID: "policy-2",
+		Name:     "Compromised",
+		AllOrgs:  true,
+		PolicyConfig: stepsecurityapi.RunPolicyConfig{
+			EnableCompromisedActionsPolicy: true,
+		},
+	}, model, &diags)
+
+	require.False(t, diags.HasError())
+	assert.Equal(t, "test-org", model.Owner.ValueString())
+	assert.True(t, model.AllOrgs.ValueBool())
+}
+
+func TestGithubTypedRunPolicy_ImportState(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := NewGithubRunPolicySecretsResource().(*githubTypedRunPolicyResource)
+	schemaResp := testTypedRunPolicySchema(t, r)
+
+	for _, tc := range []struct {
+		id        string
+		expectErr bool
+	}{
+		{id: "test-org/policy-1"},
+		{id: "test-org", expectErr: true},
+		{id: "test-org/", expectErr: true},
+		{id: "a/b/c", expectErr: true},
+	} {
+		resp := &fwresource.ImportStateResponse{
+			State: tfsdk.State{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			},
+		}
+		r.ImportState(ctx, fwresource.ImportStateRequest{ID: tc.id}, resp)
+		assert.Equal(t, tc.expectErr, resp.Diagnostics.HasError(), "import ID %q", tc.id)
+		if tc.expectErr {
+			continue
+		}
+
+		var policyID types.String
+		require.False(t, resp.State.GetAttribute(ctx, path.Root("policy_id"), &policyID).HasError())
+		assert.Equal(t, "policy-1", policyID.ValueString())
+	}
+}
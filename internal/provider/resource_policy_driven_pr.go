@@ -7,6 +7,8 @@ import (
 
 	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -14,6 +16,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -135,7 +138,10 @@ func (r *policyDrivenPRResource) Schema(_ context.Context, _ resource.SchemaRequ
 						ElementType: types.StringType,
 						Optional:    true,
 						Computed:    true,
-						Description: "List of actions to exempt while pinning actions to SHA. When exempted, the action will not be pinned to SHA.",
+						Description: "List of actions to exempt while pinning actions to SHA. When exempted, the action will not be pinned to SHA. Each entry must be an action reference of the form `owner/repo[/path][@ref]`, `owner/*`, `owner/prefix*` or `*`, a local action (`./path`) or a Docker action (`docker://image`).",
+						Validators: []validator.List{
+							listvalidator.ValueStringsAre(actionReferenceValidator{allowWildcards: true, allowLocal: true}),
+						},
 						Default: listdefault.StaticValue(
 							types.ListValueMust(
 								types.StringType,
@@ -159,7 +165,10 @@ func (r *policyDrivenPRResource) Schema(_ context.Context, _ resource.SchemaRequ
 						ElementType: types.StringType,
 						Optional:    true,
 						Computed:    true,
-						Description: "List of actions to replace with Step Security actions. When provided, the actions will be replaced with Step Security actions.",
+						Description: "List of actions to replace with Step Security actions. When provided, the actions will be replaced with Step Security actions. Each entry must be an action reference of the form `owner/repo[/path][@ref]`, `owner/*`, `owner/prefix*` or `*`, a local action (`./path`) or a Docker action (`docker://image`).",
+						Validators: []validator.List{
+							listvalidator.ValueStringsAre(actionReferenceValidator{allowWildcards: true, allowLocal: true}),
+						},
 						Default: listdefault.StaticValue(
 							types.ListValueMust(
 								types.StringType,
@@ -170,7 +179,11 @@ func (r *policyDrivenPRResource) Schema(_ context.Context, _ resource.SchemaRequ
 					"custom_actions_to_replace": schema.MapAttribute{
 						ElementType: types.StringType,
 						Optional:    true,
-						Description: "Map of actions to replace with custom replacements. Keys are the original action names, values are the replacement action names chosen by the customer.",
+						Description: "Map of actions to replace with custom replacements. Keys are the original action names, values are the replacement action names chosen by the customer. Keys and values must be action references of the form `owner/repo[/path][@ref]`, local actions (`./path`) or Docker actions (`docker://image`).",
+						Validators: []validator.Map{
+							mapvalidator.KeysAre(actionReferenceValidator{allowLocal: true}),
+							mapvalidator.ValueStringsAre(actionReferenceValidator{allowLocal: true}),
+						},
 					},
 					"replace_action_on_major_tag_match": schema.BoolAttribute{
 						Optional:    true,
@@ -182,7 +195,10 @@ func (r *policyDrivenPRResource) Schema(_ context.Context, _ resource.SchemaRequ
 						ElementType: types.StringType,
 						Optional:    true,
 						Computed:    true,
-						Description: "List of actions to exempt from replacement. When set, ALL maintained actions are replaced EXCEPT those listed. Mutually exclusive with actions_to_replace_with_step_security_actions.",
+						Description: "List of actions to exempt from replacement. When set, ALL maintained actions are replaced EXCEPT those listed. Mutually exclusive with actions_to_replace_with_step_security_actions. Each entry must be an action reference of the form `owner/repo[/path][@ref]`, `owner/*`, `owner/prefix*` or `*`, a local action (`./path`) or a Docker action (`docker://image`).",
+						Validators: []validator.List{
+							listvalidator.ValueStringsAre(actionReferenceValidator{allowWildcards: true, allowLocal: true}),
+						},
 						Default: listdefault.StaticValue(
 							types.ListValueMust(
 								types.StringType,
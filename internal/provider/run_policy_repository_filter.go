@@ -0,0 +1,256 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	stdpath "path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+
+	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
+)
+
+// repositoryTopicPattern follows GitHub's topic rules: lowercase letters, digits and
+// hyphens, starting with a letter or digit, at most 50 characters.
+var repositoryTopicPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,49}$`)
+
+// repositoryFilterModel maps the repository_filter attribute of run policies.
+type repositoryFilterModel struct {
+	IncludePatterns types.Set `tfsdk:"include_patterns"`
+	ExcludePatterns types.Set `tfsdk:"exclude_patterns"`
+	Topics          types.Set `tfsdk:"topics"`
+	Visibility      types.Set `tfsdk:"visibility"`
+}
+
+var repositoryFilterAttrTypes = map[string]attr.Type{
+	"include_patterns": types.SetType{ElemType: types.StringType},
+	"exclude_patterns": types.SetType{ElemType: types.StringType},
+	"topics":           types.SetType{ElemType: types.StringType},
+	"visibility":       types.SetType{ElemType: types.StringType},
+}
+
+// repositoryFilterAttribute returns the repository_filter schema attribute shared by
+// stepsecurity_github_run_policy and the per-policy-type run policy resources.
+func repositoryFilterAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Optional:            true,
+		MarkdownDescription: "Narrows `all_repos = true` to the repositories matching the filter. The filter is resolved by StepSecurity whenever the policy is evaluated, so repositories created later that match (e.g. a new `svc-payments` for `include_patterns = [\"svc-*\"]`) are covered without re-applying. A repository is selected when it matches at least one include pattern (every repository when unset), has at least one of `topics`, has one of `visibility`, and matches no exclude pattern. Requires `all_repos = true` and cannot be combined with `repositories`. When the StepSecurity API does not support repository filters, the provider saves the repositories the filter selects as an explicit list instead; repositories created later then show up as drift and are added on the next apply.",
+		Attributes: map[string]schema.Attribute{
+			"include_patterns": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Glob patterns matched against repository names (e.g. `svc-*`, `*-api`). Supports `*`, `?` and `[...]` character classes; matching is case-insensitive.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(repositoryPatternValidator{}),
+				},
+			},
+			"exclude_patterns": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Glob patterns for repositories to leave out, even when they match `include_patterns`, `topics` or `visibility`.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(repositoryPatternValidator{}),
+				},
+			},
+			"topics": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Repository topics; a repository must have at least one of them.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(repositoryTopicPattern, "must be a GitHub topic: lowercase letters, digits and hyphens, starting with a letter or digit, at most 50 characters"),
+					),
+				},
+			},
+			"visibility": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Repository visibilities to select: `public`, `private` and/or `internal`.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf("public", "private", "internal")),
+				},
+			},
+		},
+		Validators: []validator.Object{
+			objectvalidator.ConflictsWith(path.MatchRoot("repositories")),
+		},
+	}
+}
+
+// validateRepositoryFilter checks that a configured repository_filter has at least one
+// criterion and is used together with all_repos, which it narrows.
+func validateRepositoryFilter(ctx context.Context, filter types.Object, allRepos, allOrgs types.Bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if filter.IsNull() || filter.IsUnknown() {
+		return diags
+	}
+
+	var model repositoryFilterModel
+	diags.Append(filter.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return diags
+	}
+	if model.IncludePatterns.IsNull() && model.ExcludePatterns.IsNull() && model.Topics.IsNull() && model.Visibility.IsNull() {
+		diags.AddAttributeError(
+			path.Root("repository_filter"),
+			"Invalid repository selection",
+			"repository_filter must set at least one of include_patterns, exclude_patterns, topics or visibility.",
+		)
+	}
+
+	if !allRepos.IsUnknown() && !allRepos.ValueBool() {
+		diags.AddAttributeError(
+			path.Root("repository_filter"),
+			"Invalid repository selection",
+			"repository_filter narrows the repositories selected by all_repos; set all_repos = true or list the repositories explicitly instead.",
+		)
+	}
+	if !allOrgs.IsUnknown() && allOrgs.ValueBool() {
+		diags.AddAttributeError(
+			path.Root("repository_filter"),
+			"Invalid repository selection",
+			"repository_filter cannot be combined with all_orgs = true.",
+		)
+	}
+	return diags
+}
+
+// repositoryFilterToAPI converts the repository_filter attribute into its API shape.
+// A null filter stays nil, which clears any filter stored on the policy.
+func repositoryFilterToAPI(ctx context.Context, filter types.Object, diags *diag.Diagnostics) *stepsecurityapi.RunPolicyRepositoryFilter {
+	if filter.IsNull() || filter.IsUnknown() {
+		return nil
+	}
+
+	var model repositoryFilterModel
+	diags.Append(filter.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return nil
+	}
+
+	return &stepsecurityapi.RunPolicyRepositoryFilter{
+		IncludePatterns: stringSetToAPI(ctx, model.IncludePatterns, diags),
+		ExcludePatterns: stringSetToAPI(ctx, model.ExcludePatterns, diags),
+		Topics:          stringSetToAPI(ctx, model.Topics, diags),
+		Visibility:      stringSetToAPI(ctx, model.Visibility, diags),
+	}
+}
+
+// repositoryFilterFromAPI converts an API repository filter into the repository_filter
+// attribute, keeping a missing filter as null.
+func repositoryFilterFromAPI(filter *stepsecurityapi.RunPolicyRepositoryFilter, diags *diag.Diagnostics) types.Object {
+	if filter == nil {
+		return types.ObjectNull(repositoryFilterAttrTypes)
+	}
+
+	fromAPI := func(values []string) types.Set {
+		// The API omits empty criteria, and the schema rejects empty sets.
+		if len(values) == 0 {
+			return types.SetNull(types.StringType)
+		}
+		return stringSetFromAPI(values, diags)
+	}
+
+	obj, objDiags := types.ObjectValue(repositoryFilterAttrTypes, map[string]attr.Value{
+		"include_patterns": fromAPI(filter.IncludePatterns),
+		"exclude_patterns": fromAPI(filter.ExcludePatterns),
+		"topics":           fromAPI(filter.Topics),
+		"visibility":       fromAPI(filter.Visibility),
+	})
+	diags.Append(objDiags...)
+	return obj
+}
+
+// restoreExpandedRepositoryFilter recognises a run policy saved by an API without
+// repository filter support, where filter was stored as the explicit list of repositories
+// it selected. While that list is still what filter selects, the policy is returned with
+// the filter in place of the list so the configuration shows no drift. Once matching
+// repositories are added or removed, the policy is returned as stored and the next apply
+// refreshes the list.
+func restoreExpandedRepositoryFilter(ctx context.Context, client stepsecurityapi.Client, owner string, filter types.Object, policy *stepsecurityapi.RunPolicy, diags *diag.Diagnostics) *stepsecurityapi.RunPolicy {
+	if filter.IsNull() || filter.IsUnknown() || policy.RepositoryFilter != nil || policy.AllRepos || policy.AllOrgs {
+		return policy
+	}
+
+	apiFilter := repositoryFilterToAPI(ctx, filter, diags)
+	if diags.HasError() {
+		return policy
+	}
+	resolved, err := client.ResolveRepositoryFilter(ctx, owner, apiFilter)
+	if err != nil {
+		diags.AddWarning(
+			"Unable to resolve repository_filter",
+			fmt.Sprintf("Run policy %s stores repository_filter as an explicit repository list, which could not be compared with the repositories the filter selects now: %s", policy.PolicyID, err),
+		)
+		return policy
+	}
+
+	stored := slices.Clone(policy.Repositories)
+	slices.Sort(stored)
+	if !slices.Equal(stored, resolved) {
+		return policy
+	}
+
+	restored := *policy
+	restored.AllRepos = true
+	restored.Repositories = nil
+	restored.RepositoryFilter = apiFilter
+	return &restored
+}
+
+var _ validator.String = repositoryPatternValidator{}
+
+// repositoryPatternValidator validates a repository name glob pattern.
+type repositoryPatternValidator struct{}
+
+func (v repositoryPatternValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+func (v repositoryPatternValidator) MarkdownDescription(_ context.Context) string {
+	return "value must be a glob pattern over repository names, e.g. `svc-*`"
+}
+
+func (v repositoryPatternValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	pattern := req.ConfigValue.ValueString()
+	var problem string
+	switch {
+	case pattern == "":
+		problem = "must not be empty"
+	case strings.ContainsAny(pattern, " \t\r\n"):
+		problem = "must not contain whitespace"
+	case strings.Contains(pattern, "/"):
+		problem = "must not contain \"/\"; patterns match repository names within the policy owner"
+	default:
+		if _, err := stdpath.Match(pattern, ""); err != nil {
+			problem = "is not a valid glob pattern"
+		}
+	}
+	if problem != "" {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Repository Pattern",
+			fmt.Sprintf("Repository pattern %q %s.", pattern, problem),
+		)
+	}
+}
@@ -0,0 +1,219 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	stepsecurityapi "github.com/step-security/terraform-provider-stepsecurity/internal/stepsecurity-api"
+)
+
+func testRepositoryFilterValue(include, exclude, topics, visibility []string) types.Object {
+	toSet := func(values []string) types.Set {
+		if values == nil {
+			return types.SetNull(types.StringType)
+		}
+		return types.SetValueMust(types.StringType, testStringAttrValues(values))
+	}
+	return types.ObjectValueMust(repositoryFilterAttrTypes, map[string]attr.Value{
+		"include_patterns": toSet(include),
+		"exclude_patterns": toSet(exclude),
+		"topics":           toSet(topics),
+		"visibility":       toSet(visibility),
+	})
+}
+
+func TestRepositoryPatternValidator(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		pattern     string
+		errContains string
+	}{
+		{pattern: "svc-*"},
+		{pattern: "*-api"},
+		{pattern: "repo-?"},
+		{pattern: "[a-c]*"},
+		{pattern: "", errContains: "must not be empty"},
+		{pattern: "svc *", errContains: "must not contain whitespace"},
+		{pattern: "my-org/svc-*", errContains: `must not contain "/"`},
+		{pattern: "svc-[", errContains: "not a valid glob pattern"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.pattern, func(t *testing.T) {
+			t.Parallel()
+
+			resp := &validator.StringResponse{}
+			repositoryPatternValidator{}.ValidateString(context.Background(), validator.StringRequest{
+				Path:        path.Root("repository_filter").AtName("include_patterns"),
+				ConfigValue: types.StringValue(tc.pattern),
+			}, resp)
+
+			if tc.errContains == "" {
+				assert.False(t, resp.Diagnostics.HasError(), "unexpected diags: %v", resp.Diagnostics)
+				return
+			}
+			require.True(t, resp.Diagnostics.HasError())
+			assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), tc.errContains)
+		})
+	}
+}
+
+func TestValidateRepositoryFilter(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		filter      types.Object
+		allRepos    types.Bool
+		allOrgs     types.Bool
+		errContains string
+	}{
+		{
+			name:     "null filter",
+			filter:   types.ObjectNull(repositoryFilterAttrTypes),
+			allRepos: types.BoolNull(),
+			allOrgs:  types.BoolNull(),
+		},
+		{
+			name:     "filter with all_repos",
+			filter:   testRepositoryFilterValue([]string{"svc-*"}, nil, nil, nil),
+			allRepos: types.BoolValue(true),
+			allOrgs:  types.BoolNull(),
+		},
+		{
+			name:     "unknown all_repos is deferred",
+			filter:   testRepositoryFilterValue(nil, nil, []string{"payments"}, nil),
+			allRepos: types.BoolUnknown(),
+			allOrgs:  types.BoolNull(),
+		},
+		{
+			name:        "filter without all_repos",
+			filter:      testRepositoryFilterValue([]string{"svc-*"}, nil, nil, nil),
+			allRepos:    types.BoolNull(),
+			allOrgs:     types.BoolNull(),
+			errContains: "set all_repos = true",
+		},
+		{
+			name:        "filter with all_orgs",
+			filter:      testRepositoryFilterValue([]string{"svc-*"}, nil, nil, nil),
+			allRepos:    types.BoolValue(true),
+			allOrgs:     types.BoolValue(true),
+			errContains: "cannot be combined with all_orgs",
+		},
+		{
+			name:        "empty filter",
+			filter:      testRepositoryFilterValue(nil, nil, nil, nil),
+			allRepos:    types.BoolValue(true),
+			allOrgs:     types.BoolNull(),
+			errContains: "at least one of include_patterns",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			diags := validateRepositoryFilter(context.Background(), tc.filter, tc.allRepos, tc.allOrgs)
+			if tc.errContains == "" {
+				assert.False(t, diags.HasError(), "unexpected diags: %v", diags)
+				return
+			}
+			require.True(t, diags.HasError())
+			assert.Contains(t, diags.Errors()[0].Detail(), tc.errContains)
+		})
+	}
+}
+
+func TestRepositoryFilter_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	var diags diag.Diagnostics
+
+	assert.Nil(t, repositoryFilterToAPI(ctx, types.ObjectNull(repositoryFilterAttrTypes), &diags))
+	assert.True(t, repositoryFilterFromAPI(nil, &diags).IsNull())
+
+	filter := repositoryFilterToAPI(ctx, testRepositoryFilterValue([]string{"svc-*"}, []string{"svc-legacy"}, nil, []string{"private"}), &diags)
+	require.False(t, diags.HasError(), "unexpected diags: %v", diags)
+	assert.Equal(t, &stepsecurityapi.RunPolicyRepositoryFilter{
+		IncludePatterns: []string{"svc-*"},
+		ExcludePatterns: []string{"svc-legacy"},
+		Visibility:      []string{"private"},
+	}, filter)
+
+	obj := repositoryFilterFromAPI(filter, &diags)
+	require.False(t, diags.HasError(), "unexpected diags: %v", diags)
+	assert.True(t, obj.Equal(testRepositoryFilterValue([]string{"svc-*"}, []string{"svc-legacy"}, nil, []string{"private"})))
+}
+
+func TestRestoreExpandedRepositoryFilter(t *testing.T) {
+	t.Parallel()
+
+	filter := testRepositoryFilterValue([]string{"svc-*"}, nil, nil, nil)
+	apiFilter := &stepsecurityapi.RunPolicyRepositoryFilter{IncludePatterns: []string{"svc-*"}}
+
+	testCases := []struct {
+		name         string
+		filter       types.Object
+		policy       stepsecurityapi.RunPolicy
+		resolved     []string
+		expectFilter bool
+	}{
+		{
+			name:         "expanded_list_current",
+			filter:       filter,
+			policy:       stepsecurityapi.RunPolicy{PolicyID: "policy-1", Repositories: []string{"svc-payments", "svc-orders"}},
+			resolved:     []string{"svc-orders", "svc-payments"},
+			expectFilter: true,
+		},
+		{
+			name:     "expanded_list_stale",
+			filter:   filter,
+			policy:   stepsecurityapi.RunPolicy{PolicyID: "policy-1", Repositories: []string{"svc-payments"}},
+			resolved: []string{"svc-orders", "svc-payments"},
+		},
+		{
+			name:   "filter_stored_by_api",
+			filter: filter,
+			policy: stepsecurityapi.RunPolicy{PolicyID: "policy-1", AllRepos: true, RepositoryFilter: apiFilter},
+		},
+		{
+			name:   "no_filter_configured",
+			filter: types.ObjectNull(repositoryFilterAttrTypes),
+			policy: stepsecurityapi.RunPolicy{PolicyID: "policy-1", Repositories: []string{"svc-payments"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockClient := &stepsecurityapi.MockStepSecurityClient{}
+			if tc.resolved != nil {
+				mockClient.On("ResolveRepositoryFilter", context.Background(), "test-org", apiFilter).Return(tc.resolved, nil)
+			}
+
+			var diags diag.Diagnostics
+			got := restoreExpandedRepositoryFilter(context.Background(), mockClient, "test-org", tc.filter, &tc.policy, &diags)
+			require.False(t, diags.HasError(), "unexpected diags: %v", diags)
+			mockClient.AssertExpectations(t)
+
+			if tc.expectFilter {
+				assert.True(t, got.AllRepos)
+				assert.Nil(t, got.Repositories)
+				assert.Equal(t, apiFilter, got.RepositoryFilter)
+				return
+			}
+			assert.Equal(t, &tc.policy, got)
+		})
+	}
+}
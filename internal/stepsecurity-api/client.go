@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -23,6 +24,14 @@ type Client interface {
 	GetNotificationSettings(ctx context.Context, owner string) (*NotificationSettings, error)
 	UpdateNotificationSettings(ctx context.Context, notificationSettingsReq GitHubNotificationSettingsRequest) error
 	DeleteNotificationSettings(ctx context.Context, owner string) error
+	SendTestNotification(ctx context.Context, owner string) ([]NotificationTestResult, error)
+	CreateNotificationDestination(ctx context.Context, owner string, destination NotificationDestination) (*NotificationDestination, error)
+	GetNotificationDestination(ctx context.Context, owner string, destinationID string) (*NotificationDestination, error)
+	UpdateNotificationDestination(ctx context.Context, owner string, destination NotificationDestination) error
+	DeleteNotificationDestination(ctx context.Context, owner string, destinationID string) error
+	GetRepoNotificationOverride(ctx context.Context, owner, repo string) (*RepoNotificationOverride, error)
+	UpdateRepoNotificationOverride(ctx context.Context, owner, repo string, override RepoNotificationOverride) error
+	DeleteRepoNotificationOverride(ctx context.Context, owner, repo string) error
 
 	// policy-driven PRs
 	CreatePolicyDrivenPRPolicy(ctx context.Context, createRequest PolicyDrivenPRPolicy) error
@@ -34,10 +43,14 @@ type Client interface {
 
 	// GitHub Policy Store
 	CreateGitHubPolicyStorePolicy(ctx context.Context, policy *GitHubPolicyStorePolicy) error
+	UpdateGitHubPolicyStorePolicy(ctx context.Context, policy *GitHubPolicyStorePolicy) error
 	GetGitHubPolicyStorePolicy(ctx context.Context, owner string, policyName string) (*GitHubPolicyStorePolicy, error)
+	ListGitHubPolicyStorePolicies(ctx context.Context, owner string) ([]GitHubPolicyStorePolicy, error)
 	DeleteGitHubPolicyStorePolicy(ctx context.Context, owner string, policyName string) error
 	AttachGitHubPolicyStorePolicy(ctx context.Context, owner string, policyName string, request *GitHubPolicyAttachRequest) error
 	DetachGitHubPolicyStorePolicy(ctx context.Context, owner string, policyName string) error
+	GetWorkflowObservedEndpoints(ctx context.Context, owner, repo, workflow string) (*WorkflowObservedEndpoints, error)
+	ListClusters(ctx context.Context, owner string) ([]Cluster, error)
 
 	// Suppression Rules
 	CreateSuppressionRule(ctx context.Context, rule SuppressionRule) (*SuppressionRule, error)
@@ -51,15 +64,21 @@ type Client interface {
 	GetRunPolicy(ctx context.Context, owner string, policyID string) (*RunPolicy, error)
 	UpdateRunPolicy(ctx context.Context, owner string, policyID string, policy UpdateRunPolicyRequest) (*RunPolicy, error)
 	DeleteRunPolicy(ctx context.Context, owner string, policyID string) error
+	ResolveRepositoryFilter(ctx context.Context, owner string, filter *RunPolicyRepositoryFilter) ([]string, error)
 
 	GetPRChecksConfig(ctx context.Context, owner string) (GitHubPRChecksConfig, error)
 	UpdatePRChecksConfig(ctx context.Context, owner string, req GitHubPRChecksConfig) error
 	DeletePRChecksConfig(ctx context.Context, owner string) error
+	GetChecksControlCatalog(ctx context.Context, owner string) (ControlCatalog, error)
 
 	// GitHub PR Template
 	GetGitHubPRTemplate(ctx context.Context, owner string) (*GitHubPRTemplate, error)
+	GetDefaultGitHubPRTemplate(ctx context.Context, owner string) (*GitHubPRTemplate, error)
 	UpdateGitHubPRTemplate(ctx context.Context, owner string, template GitHubPRTemplate) error
 	DeleteGitHubPRTemplate(ctx context.Context, owner string) error
+	GetRepoGitHubPRTemplate(ctx context.Context, owner, repo string) (*GitHubPRTemplate, error)
+	UpdateRepoGitHubPRTemplate(ctx context.Context, owner, repo string, template GitHubPRTemplate) error
+	DeleteRepoGitHubPRTemplate(ctx context.Context, owner, repo string) error
 
 	// Custom Roles
 	ListRoles(ctx context.Context) ([]Role, error)
@@ -146,7 +165,23 @@ func (c *APIClient) do(req *http.Request, opts ...HTTPRequestOpts) ([]byte, erro
 		return body, err
 	}
 
-	return nil, fmt.Errorf("status: %d, body: %s", res.StatusCode, body)
+	return nil, &APIError{StatusCode: res.StatusCode, Body: string(body)}
+}
+
+// APIError is returned when the API responds with an unexpected status code.
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("status: %d, body: %s", e.StatusCode, e.Body)
+}
+
+// IsNotFound reports whether err is, or wraps, an APIError for a 404 response.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
 }
 
 func (c *APIClient) get(ctx context.Context, URI string) ([]byte, error) {
@@ -0,0 +1,56 @@
+package stepsecurityapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIClient_StatusError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		//nolint:errcheck
+		w.Write([]byte("not found"))
+	}))
+	defer server.Close()
+
+	_, err := newTestClient(server).get(context.Background(), server.URL+"/v1/missing")
+	require.Error(t, err)
+	assert.Equal(t, "status: 404, body: not found", err.Error())
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+}
+
+func TestIsNotFound(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "nil", err: nil},
+		{name: "not_found", err: &APIError{StatusCode: http.StatusNotFound}, expected: true},
+		{name: "wrapped_not_found", err: fmt.Errorf("failed to get policy: %w", &APIError{StatusCode: http.StatusNotFound}), expected: true},
+		{name: "other_status", err: &APIError{StatusCode: http.StatusInternalServerError, Body: "status: 404"}},
+		{name: "other_error", err: errors.New("status: 404, body: not found")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, IsNotFound(tc.err))
+		})
+	}
+}
@@ -74,6 +74,195 @@ func GetControlName(control string) string {
 	return controlNamesByCheck[control]
 }
 
+// Types of a control setting in the control catalog.
+const (
+	ControlSettingTypeInt        = "int"
+	ControlSettingTypeBool       = "bool"
+	ControlSettingTypeString     = "string"
+	ControlSettingTypeStringList = "string_list"
+)
+
+// Settings keys of the built-in controls. The cooldown and compromised updates controls
+// share ExemptedPackagesSetting; the PWN request and script injection controls share the
+// workflow settings.
+const (
+	CooldownPeriodSetting    = "cooldown_period_in_days"
+	ExemptedPackagesSetting  = "exempted_packages"
+	ExemptedWorkflowsSetting = "exempted_workflows"
+	AllowedTriggersSetting   = "allowed_triggers"
+	SeverityThresholdSetting = "severity_threshold"
+)
+
+// SeverityThresholds are the values of SeverityThresholdSetting, from lowest to highest.
+var SeverityThresholds = []string{"low", "medium", "high", "critical"}
+
+// ControlCatalogEntry describes a control that can be configured for GitHub checks.
+type ControlCatalogEntry struct {
+	// Name is the display name of the control. Controls that the provider does not know
+	// about yet are configured by Check instead.
+	Name        string                 `json:"name"`
+	Check       string                 `json:"check"`
+	Description string                 `json:"description"`
+	Types       []string               `json:"types"`
+	Settings    []ControlSettingSchema `json:"settings"`
+}
+
+// ControlSettingSchema describes a single setting of a control.
+type ControlSettingSchema struct {
+	Key         string `json:"key"`
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	Default     any    `json:"default,omitempty"`
+	Min         *int64 `json:"min,omitempty"`
+	Max         *int64 `json:"max,omitempty"`
+}
+
+// Setting returns the schema of the setting with the given key.
+func (e ControlCatalogEntry) Setting(key string) (ControlSettingSchema, bool) {
+	for _, setting := range e.Settings {
+		if setting.Key == key {
+			return setting, true
+		}
+	}
+	return ControlSettingSchema{}, false
+}
+
+// ControlCatalog is the list of controls available for GitHub checks.
+type ControlCatalog []ControlCatalogEntry
+
+// Find returns the control whose display name or check name is control.
+func (c ControlCatalog) Find(control string) (ControlCatalogEntry, bool) {
+	for _, entry := range c {
+		if entry.Name == control || entry.Check == control {
+			return entry, true
+		}
+	}
+	return ControlCatalogEntry{}, false
+}
+
+// cooldownChecks are the built-in controls that accept cooldown settings.
+var cooldownChecks = map[string]bool{
+	"maven_package_cooldown":           true,
+	"npm_package_recent_release_guard": true,
+	"nuget_package_cooldown":           true,
+	"pypi_package_cooldown":            true,
+}
+
+// compromisedUpdatesChecks are the built-in controls that flag compromised package versions.
+var compromisedUpdatesChecks = map[string]bool{
+	"maven_package_compromised_updates": true,
+	"npm_package_compromised_updates":   true,
+	"nuget_package_compromised_updates": true,
+	"pypi_package_compromised_updates":  true,
+}
+
+// workflowChecks are the built-in controls that analyze workflow files.
+var workflowChecks = map[string]string{
+	"pwn_request_check":      "Fails when a workflow checks out and runs untrusted pull request code with a privileged trigger.",
+	"script_injection_check": "Fails when a workflow interpolates untrusted input into a run script.",
+}
+
+// DefaultControlCatalog returns the catalog of the controls built into the provider. It is
+// used when the API does not serve a control catalog, and for validation that has to
+// happen before the provider is configured.
+func DefaultControlCatalog() ControlCatalog {
+	catalog := make(ControlCatalog, 0, len(AvailableControls))
+	for _, name := range GetAvailableControls() {
+		check := AvailableControls[name]
+		entry := ControlCatalogEntry{
+			Name:  name,
+			Check: check,
+			Types: []string{"required", "optional"},
+		}
+		switch {
+		case compromisedUpdatesChecks[check]:
+			entry.Description = "Fails when a pull request adds a package version known to be compromised."
+			entry.Settings = []ControlSettingSchema{
+				{
+					Key:         ExemptedPackagesSetting,
+					Type:        ControlSettingTypeStringList,
+					Description: "Packages exempted from the check.",
+				},
+			}
+		case workflowChecks[check] != "":
+			entry.Description = workflowChecks[check]
+			entry.Settings = []ControlSettingSchema{
+				{
+					Key:         ExemptedWorkflowsSetting,
+					Type:        ControlSettingTypeStringList,
+					Description: "Workflow files exempted from the check, as paths or glob patterns under .github/workflows/.",
+				},
+				{
+					Key:         AllowedTriggersSetting,
+					Type:        ControlSettingTypeStringList,
+					Description: "Workflow triggers the check does not report, e.g. workflow_run.",
+				},
+				{
+					Key:         SeverityThresholdSetting,
+					Type:        ControlSettingTypeString,
+					Description: "Lowest severity of findings that fail the check: low, medium, high or critical.",
+				},
+			}
+		case cooldownChecks[check]:
+			entry.Description = "Fails when a pull request adds a package version released more recently than the cooldown period."
+			entry.Settings = []ControlSettingSchema{
+				{
+					Key:         CooldownPeriodSetting,
+					Type:        ControlSettingTypeInt,
+					Description: "Number of days a package version must have been released before it is allowed.",
+					Default:     int64(2),
+					Min:         int64Pointer(1),
+					Max:         int64Pointer(30),
+				},
+				{
+					Key:         ExemptedPackagesSetting,
+					Type:        ControlSettingTypeStringList,
+					Description: "Packages exempted from the cooldown period.",
+				},
+			}
+		}
+		catalog = append(catalog, entry)
+	}
+	catalog.sort()
+	return catalog
+}
+
+// sort orders the catalog by check name, so it reads the same on every refresh.
+func (c ControlCatalog) sort() {
+	sort.Slice(c, func(i, j int) bool {
+		return c[i].Check < c[j].Check
+	})
+}
+
+func int64Pointer(value int64) *int64 {
+	return &value
+}
+
+// GetChecksControlCatalog returns the controls available for GitHub checks of owner. When
+// the API does not serve a control catalog, the catalog built into the provider is
+// returned.
+func (c *APIClient) GetChecksControlCatalog(ctx context.Context, owner string) (ControlCatalog, error) {
+	URI := fmt.Sprintf("%s/v1/github/%s/checks/controls", c.BaseURL, owner)
+
+	respBody, err := c.get(ctx, URI)
+	if err != nil {
+		if IsNotFound(err) {
+			return DefaultControlCatalog(), nil
+		}
+		return nil, fmt.Errorf("failed to get control catalog: %w", err)
+	}
+
+	var resp struct {
+		Controls ControlCatalog `json:"controls"`
+	}
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal control catalog: %w", err)
+	}
+
+	resp.Controls.sort()
+	return resp.Controls, nil
+}
+
 func (c *APIClient) GetPRChecksConfig(ctx context.Context, owner string) (GitHubPRChecksConfig, error) {
 	URI := fmt.Sprintf("%s/v1/github/%s/checks/config", c.BaseURL, owner)
 	prChecksConfig := GitHubPRChecksConfig{}
@@ -90,27 +279,9 @@ func (c *APIClient) GetPRChecksConfig(ctx context.Context, owner string) (GitHub
 	return prChecksConfig, nil
 }
 
+// UpdatePRChecksConfig writes req as the owner's checks configuration. req.Repos is
+// written as is; repositories it does not list are not enrolled on the caller's behalf.
 func (c *APIClient) UpdatePRChecksConfig(ctx context.Context, owner string, req GitHubPRChecksConfig) error {
-
-	if getBooleanPointerValue(req.EnableBaselineCheckForAllNewRepos) ||
-		getBooleanPointerValue(req.EnableRequiredChecksForAllNewRepos) ||
-		getBooleanPointerValue(req.EnableOptionalChecksForAllNewRepos) {
-		existingConfig, err := c.GetPRChecksConfig(ctx, owner)
-		if err != nil {
-			return fmt.Errorf("failed to get PR checks config: %w", err)
-		}
-		for repo := range existingConfig.Repos {
-			if _, ok := req.Repos[repo]; !ok {
-				req.Repos[repo] = CheckOptions{
-					Baseline:          getBooleanPointerValue(req.EnableBaselineCheckForAllNewRepos),
-					RunRequiredChecks: getBooleanPointerValue(req.EnableRequiredChecksForAllNewRepos),
-					RunOptionalChecks: getBooleanPointerValue(req.EnableOptionalChecksForAllNewRepos),
-				}
-			}
-		}
-
-	}
-
 	URI := fmt.Sprintf("%s/v1/github/%s/checks/config", c.BaseURL, owner)
 	_, err := c.put(ctx, URI, req)
 	if err != nil {
@@ -157,13 +328,6 @@ func (c *APIClient) DeletePRChecksConfig(ctx context.Context, owner string) erro
 	return nil
 }
 
-func getBooleanPointerValue(value *bool) bool {
-	if value != nil {
-		return *value
-	}
-	return false
-}
-
 func toPointer(value bool) *bool {
 	return &value
 }
@@ -0,0 +1,86 @@
+package stepsecurityapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetChecksControlCatalog(t *testing.T) {
+	t.Parallel()
+
+	var gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		//nolint:errcheck
+		w.Write([]byte(`{"controls":[
+			{"name":"Script Injection","check":"script_injection_check","types":["required","optional"]},
+			{"name":"Action Pinning","check":"action_pinning_check","types":["optional"],"settings":[
+				{"key":"allow_tags","type":"bool","description":"Accept version tags.","default":false}
+			]}
+		]}`))
+	}))
+	defer server.Close()
+
+	catalog, err := newTestClient(server).GetChecksControlCatalog(context.Background(), "test-org")
+	require.NoError(t, err)
+
+	assert.Equal(t, "/v1/github/test-org/checks/controls", gotPath)
+	require.Len(t, catalog, 2)
+	assert.Equal(t, "action_pinning_check", catalog[0].Check)
+	setting, ok := catalog[0].Setting("allow_tags")
+	require.True(t, ok)
+	assert.Equal(t, ControlSettingTypeBool, setting.Type)
+
+	entry, ok := catalog.Find("Script Injection")
+	require.True(t, ok)
+	assert.Equal(t, "script_injection_check", entry.Check)
+	_, ok = catalog.Find("unknown_check")
+	assert.False(t, ok)
+}
+
+func TestGetChecksControlCatalog_FallsBackToDefault(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	catalog, err := newTestClient(server).GetChecksControlCatalog(context.Background(), "test-org")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultControlCatalog(), catalog)
+	assert.Len(t, catalog, len(AvailableControls))
+
+	cooldown, ok := catalog.Find("NPM Package Cooldown")
+	require.True(t, ok)
+	period, ok := cooldown.Setting(CooldownPeriodSetting)
+	require.True(t, ok)
+	assert.Equal(t, int64(1), *period.Min)
+	assert.Equal(t, int64(30), *period.Max)
+
+	pwn, ok := catalog.Find("pwn_request_check")
+	require.True(t, ok)
+	_, ok = pwn.Setting(SeverityThresholdSetting)
+	assert.True(t, ok)
+	compromised, ok := catalog.Find("NPM Package Compromised Updates")
+	require.True(t, ok)
+	_, ok = compromised.Setting(CooldownPeriodSetting)
+	assert.False(t, ok)
+}
+
+func TestGetChecksControlCatalog_Error(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	_, err := newTestClient(server).GetChecksControlCatalog(context.Background(), "test-org")
+	assert.ErrorContains(t, err, "failed to get control catalog")
+}
@@ -0,0 +1,36 @@
+package stepsecurityapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// RunnerScaleSet is an Actions Runner Controller scale set running in a cluster.
+type RunnerScaleSet struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+// Cluster is a Kubernetes cluster running self-hosted runners that reports to StepSecurity.
+type Cluster struct {
+	Name            string           `json:"name"`
+	Namespaces      []string         `json:"namespaces,omitempty"`
+	RunnerScaleSets []RunnerScaleSet `json:"runner_scale_sets,omitempty"`
+	LastSeen        string           `json:"last_seen,omitempty"`
+}
+
+// ListClusters retrieves the clusters known to StepSecurity for an owner. Their names
+// are what PolicyAttachments.Clusters and ClusterScopes refer to.
+func (c *APIClient) ListClusters(ctx context.Context, owner string) ([]Cluster, error) {
+	URI := fmt.Sprintf("%s/v1/github/%s/actions/clusters", c.BaseURL, owner)
+	respBody, err := c.get(ctx, URI)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list clusters: %w", err)
+	}
+	var clusters []Cluster
+	if err := json.Unmarshal(respBody, &clusters); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal clusters: %w", err)
+	}
+	return clusters, nil
+}
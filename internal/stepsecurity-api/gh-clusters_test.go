@@ -0,0 +1,46 @@
+package stepsecurityapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListClusters(t *testing.T) {
+	t.Parallel()
+
+	var gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		//nolint:errcheck
+		w.Write([]byte(`[
+			{"name":"prod","namespaces":["arc-runners"],"runner_scale_sets":[{"name":"linux-x64","namespace":"arc-runners"}],"last_seen":"2026-03-04T00:00:00Z"},
+			{"name":"staging"}
+		]`))
+	}))
+	defer server.Close()
+
+	clusters, err := newTestClient(server).ListClusters(context.Background(), "test-org")
+	require.NoError(t, err)
+
+	assert.Equal(t, "/v1/github/test-org/actions/clusters", gotPath)
+	require.Len(t, clusters, 2)
+	assert.Equal(t, []RunnerScaleSet{{Name: "linux-x64", Namespace: "arc-runners"}}, clusters[0].RunnerScaleSets)
+	assert.Equal(t, Cluster{Name: "staging"}, clusters[1])
+}
+
+func TestListClusters_Error(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	_, err := newTestClient(server).ListClusters(context.Background(), "test-org")
+	assert.ErrorContains(t, err, "failed to list clusters")
+}
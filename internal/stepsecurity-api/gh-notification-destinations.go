@@ -0,0 +1,125 @@
+package stepsecurityapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Notification destination types.
+const (
+	NotificationDestinationSlack     = "slack"
+	NotificationDestinationTeams     = "teams"
+	NotificationDestinationEmail     = "email"
+	NotificationDestinationWebhook   = "webhook"
+	NotificationDestinationPagerDuty = "pagerduty"
+	NotificationDestinationOpsgenie  = "opsgenie"
+)
+
+// NotificationDestinationTypes lists the supported notification destination types.
+var NotificationDestinationTypes = []string{
+	NotificationDestinationSlack,
+	NotificationDestinationTeams,
+	NotificationDestinationEmail,
+	NotificationDestinationWebhook,
+	NotificationDestinationPagerDuty,
+	NotificationDestinationOpsgenie,
+}
+
+// OpsgenieRegions lists the regions an Opsgenie destination can use.
+var OpsgenieRegions = []string{"us", "eu"}
+
+// NotificationEvents lists the events a notification destination can subscribe to. They
+// correspond to the Notify* flags of NotificationSettings.
+var NotificationEvents = []string{
+	"domain_blocked",
+	"file_overwrite",
+	"new_endpoint_discovered",
+	"https_detections",
+	"secrets_detected",
+	"artifacts_secrets_detected",
+	"imposter_commits_detected",
+	"suspicious_network_call_detected",
+	"suspicious_process_events_detected",
+	"harden_runner_config_changes_detected",
+	"non_compliant_artifact_detected",
+	"run_blocked_by_policy",
+	"baseline_check_failures",
+	"required_check_failures",
+	"optional_check_failures",
+}
+
+// NotificationDestination routes a set of events to a single channel. Unlike
+// NotificationSettings, an owner can have any number of destinations.
+type NotificationDestination struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+	// Target is the Slack or Teams webhook URL, the email address, the webhook endpoint,
+	// the PagerDuty routing key or the Opsgenie API key, depending on Type.
+	Target string   `json:"target"`
+	Events []string `json:"events"`
+	// Repos restricts the destination to events from these repositories. Empty means all repositories.
+	Repos []string `json:"repos,omitempty"`
+	// SigningSecret is the HMAC secret webhook deliveries are signed with. The API never
+	// returns it: nil keeps the current secret and an empty string removes it.
+	SigningSecret *string `json:"signing_secret,omitempty"`
+	// Region is the Opsgenie region, "us" or "eu".
+	Region string `json:"region,omitempty"`
+}
+
+// CreateNotificationDestination creates a notification destination and returns it with its ID.
+func (c *APIClient) CreateNotificationDestination(ctx context.Context, owner string, destination NotificationDestination) (*NotificationDestination, error) {
+	uri := fmt.Sprintf("%s/v1/github/%s/notification-destinations", c.BaseURL, owner)
+
+	body, err := c.post(ctx, uri, destination)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create notification destination: %w", err)
+	}
+
+	var created NotificationDestination
+	if err := json.Unmarshal(body, &created); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal notification destination response: %w", err)
+	}
+
+	return &created, nil
+}
+
+// GetNotificationDestination retrieves a notification destination by ID.
+func (c *APIClient) GetNotificationDestination(ctx context.Context, owner string, destinationID string) (*NotificationDestination, error) {
+	uri := fmt.Sprintf("%s/v1/github/%s/notification-destinations/%s", c.BaseURL, owner, destinationID)
+
+	body, err := c.get(ctx, uri)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification destination: %w", err)
+	}
+
+	var destination NotificationDestination
+	if err := json.Unmarshal(body, &destination); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal notification destination response: %w", err)
+	}
+
+	return &destination, nil
+}
+
+// UpdateNotificationDestination replaces the notification destination with destination.ID.
+func (c *APIClient) UpdateNotificationDestination(ctx context.Context, owner string, destination NotificationDestination) error {
+	uri := fmt.Sprintf("%s/v1/github/%s/notification-destinations/%s", c.BaseURL, owner, destination.ID)
+
+	if _, err := c.put(ctx, uri, destination); err != nil {
+		return fmt.Errorf("failed to update notification destination: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteNotificationDestination deletes a notification destination.
+func (c *APIClient) DeleteNotificationDestination(ctx context.Context, owner string, destinationID string) error {
+	uri := fmt.Sprintf("%s/v1/github/%s/notification-destinations/%s", c.BaseURL, owner, destinationID)
+
+	if _, err := c.delete(ctx, uri); err != nil {
+		return fmt.Errorf("failed to delete notification destination: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package stepsecurityapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationDestinationClient(t *testing.T) {
+	t.Parallel()
+
+	type call struct {
+		method string
+		path   string
+		body   NotificationDestination
+	}
+	var calls []call
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c := call{method: r.Method, path: r.URL.Path}
+		//nolint:errcheck
+		json.NewDecoder(r.Body).Decode(&c.body)
+		calls = append(calls, c)
+		//nolint:errcheck
+		w.Write([]byte(`{"id":"dest-1","name":"security","type":"slack","target":"https://hooks.slack.com/x","events":["secrets_detected"],"repos":["api"]}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	ctx := context.Background()
+	destination := NotificationDestination{
+		Name:   "security",
+		Type:   NotificationDestinationSlack,
+		Target: "https://hooks.slack.com/x",
+		Events: []string{"secrets_detected"},
+		Repos:  []string{"api"},
+	}
+
+	created, err := client.CreateNotificationDestination(ctx, "test-org", destination)
+	require.NoError(t, err)
+	assert.Equal(t, "dest-1", created.ID)
+
+	got, err := client.GetNotificationDestination(ctx, "test-org", "dest-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"api"}, got.Repos)
+
+	destination.ID = "dest-1"
+	require.NoError(t, client.UpdateNotificationDestination(ctx, "test-org", destination))
+	require.NoError(t, client.DeleteNotificationDestination(ctx, "test-org", "dest-1"))
+
+	require.Len(t, calls, 4)
+	assert.Equal(t, http.MethodPost, calls[0].method)
+	assert.Equal(t, "/v1/github/test-org/notification-destinations", calls[0].path)
+	assert.Equal(t, "security", calls[0].body.Name)
+	assert.Equal(t, http.MethodGet, calls[1].method)
+	assert.Equal(t, "/v1/github/test-org/notification-destinations/dest-1", calls[1].path)
+	assert.Equal(t, http.MethodPut, calls[2].method)
+	assert.Equal(t, "/v1/github/test-org/notification-destinations/dest-1", calls[2].path)
+	assert.Equal(t, destination, calls[2].body)
+	assert.Equal(t, http.MethodDelete, calls[3].method)
+}
+
+func TestNotificationDestinationClient_Errors(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	_, err := newTestClient(server).GetNotificationDestination(context.Background(), "test-org", "missing")
+	assert.ErrorContains(t, err, "failed to get notification destination")
+	assert.True(t, IsNotFound(err), "err: %v", err)
+}
+
+func TestNotificationDestination_SigningSecretSerialization(t *testing.T) {
+	t.Parallel()
+
+	empty := ""
+	secret := "s3cr3t"
+	testCases := []struct {
+		name     string
+		secret   *string
+		expected string
+	}{
+		{name: "keep", secret: nil, expected: `{"name":"hook","type":"webhook","target":"https://example.com","events":null}`},
+		{name: "remove", secret: &empty, expected: `{"name":"hook","type":"webhook","target":"https://example.com","events":null,"signing_secret":""}`},
+		{name: "set", secret: &secret, expected: `{"name":"hook","type":"webhook","target":"https://example.com","events":null,"signing_secret":"s3cr3t"}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			body, err := json.Marshal(NotificationDestination{
+				Name:          "hook",
+				Type:          NotificationDestinationWebhook,
+				Target:        "https://example.com",
+				SigningSecret: tc.secret,
+			})
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.expected, string(body))
+		})
+	}
+}
@@ -6,6 +6,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
+
+	"github.com/step-security/terraform-provider-stepsecurity/internal/utilities"
 )
 
 type GitHubNotificationSettingsRequest struct {
@@ -13,7 +16,37 @@ type GitHubNotificationSettingsRequest struct {
 	NotificationSettings
 }
 
+// NotificationSettings are the owner wide notification settings. A nil field is not
+// configured: a channel without a value receives no notifications and an event
+// without a value is not notified.
 type NotificationSettings struct {
+	SlackWebhookURL                   *string
+	TeamsWebhookURL                   *string
+	Email                             *string
+	NotifyWhenDomainBlocked           *bool
+	NotifyOnFileOverwrite             *bool
+	NotifyWhenEndpointDiscovered      *bool
+	NotifyForHttpsDetections          *bool
+	NotifyForSecretsDetection         *bool
+	NotifyForArtifactSecretsDetection *bool
+	NotifyForImposterCommitsDetection *bool
+	NotifyForSuspiciousNetworkCall    *bool
+	NotifyForSuspiciousProcessEvents  *bool
+	NotifyForHardenRunnerConfigChange *bool
+	NotifyForNonCompliantArtifacts    *bool
+	NotifyForBlockedRunPolicy         *bool
+	NotifyForBaselineCheckFailures    *bool   // PR Check failure notifications
+	NotifyForRequiredCheckFailures    *bool   // PR Check failure notifications
+	NotifyForOptionalCheckFailures    *bool   // PR Check failure notifications
+	SlackNotificationMethod           *string // "webhook" (default) or "oauth"
+	SlackChannelID                    *string // For OAuth: channel to post to
+}
+
+// notificationSettingsWire is the representation the API uses. Flags are sent as
+// "true"/"false" strings, and a string field is only cleared when it is sent as a
+// single space; an empty string leaves the stored value unchanged.
+type notificationSettingsWire struct {
+	Owner                             string `json:"owner"`
 	SlackWebhookURL                   string `json:"slackWebhookURL"`
 	TeamsWebhookURL                   string `json:"teamsWebhookURL"`
 	Email                             string `json:"email"`
@@ -29,16 +62,96 @@ type NotificationSettings struct {
 	NotifyForHardenRunnerConfigChange string `json:"notifyForHardenRunnerConfigChanged"`
 	NotifyForNonCompliantArtifacts    string `json:"notifyForNonCompliantArtifacts"`
 	NotifyForBlockedRunPolicy         string `json:"notifyForBlockedRunPolicy"`
-	NotifyForBaselineCheckFailures    string `json:"notifyForBaselineCheckFailures"` // PR Check failure notifications
-	NotifyForRequiredCheckFailures    string `json:"notifyForRequiredCheckFailures"` // PR Check failure notifications
-	NotifyForOptionalCheckFailures    string `json:"notifyForOptionalCheckFailures"` // PR Check failure notifications
-	SlackNotificationMethod           string `json:"slackNotificationMethod"`        // "webhook" (default) or "oauth"
-	SlackChannelID                    string `json:"slackChannelID,omitempty"`       // For OAuth: channel to post to
+	NotifyForBaselineCheckFailures    string `json:"notifyForBaselineCheckFailures"`
+	NotifyForRequiredCheckFailures    string `json:"notifyForRequiredCheckFailures"`
+	NotifyForOptionalCheckFailures    string `json:"notifyForOptionalCheckFailures"`
+	SlackNotificationMethod           string `json:"slackNotificationMethod"`
+	SlackChannelID                    string `json:"slackChannelID"`
+}
+
+// clearedWireString is how the API is told to clear a string setting.
+const clearedWireString = " "
+
+func toWireString(value *string) string {
+	if value == nil || *value == "" {
+		return clearedWireString
+	}
+	return *value
+}
+
+func fromWireString(value string) *string {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+	return &value
+}
+
+func toWireBool(value *bool) string {
+	return utilities.ConvertBoolToString(value != nil && *value)
+}
+
+func fromWireBool(value string) *bool {
+	if value == "" {
+		return nil
+	}
+	b := utilities.ConvertStringToBool(value)
+	return &b
+}
+
+func (s NotificationSettings) toWire(owner string) notificationSettingsWire {
+	return notificationSettingsWire{
+		Owner:                             owner,
+		SlackWebhookURL:                   toWireString(s.SlackWebhookURL),
+		TeamsWebhookURL:                   toWireString(s.TeamsWebhookURL),
+		Email:                             toWireString(s.Email),
+		NotifyWhenDomainBlocked:           toWireBool(s.NotifyWhenDomainBlocked),
+		NotifyOnFileOverwrite:             toWireBool(s.NotifyOnFileOverwrite),
+		NotifyWhenEndpointDiscovered:      toWireBool(s.NotifyWhenEndpointDiscovered),
+		NotifyForHttpsDetections:          toWireBool(s.NotifyForHttpsDetections),
+		NotifyForSecretsDetection:         toWireBool(s.NotifyForSecretsDetection),
+		NotifyForArtifactSecretsDetection: toWireBool(s.NotifyForArtifactSecretsDetection),
+		NotifyForImposterCommitsDetection: toWireBool(s.NotifyForImposterCommitsDetection),
+		NotifyForSuspiciousNetworkCall:    toWireBool(s.NotifyForSuspiciousNetworkCall),
+		NotifyForSuspiciousProcessEvents:  toWireBool(s.NotifyForSuspiciousProcessEvents),
+		NotifyForHardenRunnerConfigChange: toWireBool(s.NotifyForHardenRunnerConfigChange),
+		NotifyForNonCompliantArtifacts:    toWireBool(s.NotifyForNonCompliantArtifacts),
+		NotifyForBlockedRunPolicy:         toWireBool(s.NotifyForBlockedRunPolicy),
+		NotifyForBaselineCheckFailures:    toWireBool(s.NotifyForBaselineCheckFailures),
+		NotifyForRequiredCheckFailures:    toWireBool(s.NotifyForRequiredCheckFailures),
+		NotifyForOptionalCheckFailures:    toWireBool(s.NotifyForOptionalCheckFailures),
+		SlackNotificationMethod:           toWireString(s.SlackNotificationMethod),
+		SlackChannelID:                    toWireString(s.SlackChannelID),
+	}
+}
+
+func (w notificationSettingsWire) settings() NotificationSettings {
+	return NotificationSettings{
+		SlackWebhookURL:                   fromWireString(w.SlackWebhookURL),
+		TeamsWebhookURL:                   fromWireString(w.TeamsWebhookURL),
+		Email:                             fromWireString(w.Email),
+		NotifyWhenDomainBlocked:           fromWireBool(w.NotifyWhenDomainBlocked),
+		NotifyOnFileOverwrite:             fromWireBool(w.NotifyOnFileOverwrite),
+		NotifyWhenEndpointDiscovered:      fromWireBool(w.NotifyWhenEndpointDiscovered),
+		NotifyForHttpsDetections:          fromWireBool(w.NotifyForHttpsDetections),
+		NotifyForSecretsDetection:         fromWireBool(w.NotifyForSecretsDetection),
+		NotifyForArtifactSecretsDetection: fromWireBool(w.NotifyForArtifactSecretsDetection),
+		NotifyForImposterCommitsDetection: fromWireBool(w.NotifyForImposterCommitsDetection),
+		NotifyForSuspiciousNetworkCall:    fromWireBool(w.NotifyForSuspiciousNetworkCall),
+		NotifyForSuspiciousProcessEvents:  fromWireBool(w.NotifyForSuspiciousProcessEvents),
+		NotifyForHardenRunnerConfigChange: fromWireBool(w.NotifyForHardenRunnerConfigChange),
+		NotifyForNonCompliantArtifacts:    fromWireBool(w.NotifyForNonCompliantArtifacts),
+		NotifyForBlockedRunPolicy:         fromWireBool(w.NotifyForBlockedRunPolicy),
+		NotifyForBaselineCheckFailures:    fromWireBool(w.NotifyForBaselineCheckFailures),
+		NotifyForRequiredCheckFailures:    fromWireBool(w.NotifyForRequiredCheckFailures),
+		NotifyForOptionalCheckFailures:    fromWireBool(w.NotifyForOptionalCheckFailures),
+		SlackNotificationMethod:           fromWireString(w.SlackNotificationMethod),
+		SlackChannelID:                    fromWireString(w.SlackChannelID),
+	}
 }
 
 func (c *APIClient) CreateNotificationSettings(ctx context.Context, notificationSettingsReq GitHubNotificationSettingsRequest) error {
 
-	body, err := json.Marshal(notificationSettingsReq)
+	body, err := json.Marshal(notificationSettingsReq.toWire(notificationSettingsReq.Owner))
 	if err != nil {
 		return fmt.Errorf("failed to marshal notification settings: %w", err)
 	}
@@ -71,11 +184,12 @@ func (c *APIClient) GetNotificationSettings(ctx context.Context, owner string) (
 		return nil, fmt.Errorf("failed to get notification settings: %w", err)
 	}
 
-	var notificationSettings NotificationSettings
-	if err := json.Unmarshal(respBody, &notificationSettings); err != nil {
+	var wire notificationSettingsWire
+	if err := json.Unmarshal(respBody, &wire); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal notification settings: %w", err)
 	}
 
+	notificationSettings := wire.settings()
 	return &notificationSettings, nil
 }
 
@@ -83,33 +197,35 @@ func (c *APIClient) UpdateNotificationSettings(ctx context.Context, notification
 	return c.CreateNotificationSettings(ctx, notificationSettingsReq)
 }
 
+// DeleteNotificationSettings clears every channel and turns every event off.
 func (c *APIClient) DeleteNotificationSettings(ctx context.Context, owner string) error {
+	return c.CreateNotificationSettings(ctx, GitHubNotificationSettingsRequest{Owner: owner})
+}
+
+// NotificationTestResult is the outcome of a test notification sent to one of the
+// owner's channels.
+type NotificationTestResult struct {
+	// Channel is "slack", "teams" or "email".
+	Channel   string `json:"channel"`
+	Delivered bool   `json:"delivered"`
+	// Error is the reason the delivery failed, e.g. the response of the Slack API.
+	Error string `json:"error,omitempty"`
+}
+
+// SendTestNotification sends a test message to every configured channel of the owner's
+// notification settings and reports the delivery of each.
+func (c *APIClient) SendTestNotification(ctx context.Context, owner string) ([]NotificationTestResult, error) {
+	uri := fmt.Sprintf("%s/v1/github/%s/actions/runs/notification-settings/test", c.BaseURL, owner)
+
+	body, err := c.post(ctx, uri, struct{}{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to send test notification: %w", err)
+	}
 
-	deleteReq := GitHubNotificationSettingsRequest{
-		Owner: owner,
-		NotificationSettings: NotificationSettings{
-			SlackWebhookURL:                   " ",
-			TeamsWebhookURL:                   " ",
-			Email:                             " ",
-			NotifyWhenDomainBlocked:           "false",
-			NotifyOnFileOverwrite:             "false",
-			NotifyWhenEndpointDiscovered:      "false",
-			NotifyForHttpsDetections:          "false",
-			NotifyForSecretsDetection:         "false",
-			NotifyForArtifactSecretsDetection: "false",
-			NotifyForImposterCommitsDetection: "false",
-			NotifyForSuspiciousNetworkCall:    "false",
-			NotifyForSuspiciousProcessEvents:  "false",
-			NotifyForHardenRunnerConfigChange: "false",
-			NotifyForNonCompliantArtifacts:    "false",
-			NotifyForBlockedRunPolicy:         "false",
-			NotifyForBaselineCheckFailures:    "false",
-			NotifyForRequiredCheckFailures:    "false",
-			NotifyForOptionalCheckFailures:    "false",
-			SlackNotificationMethod:           " ",
-			SlackChannelID:                    " ",
-		},
+	var results []NotificationTestResult
+	if err := json.Unmarshal(body, &results); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal test notification results: %w", err)
 	}
 
-	return c.CreateNotificationSettings(ctx, deleteReq)
+	return results, nil
 }
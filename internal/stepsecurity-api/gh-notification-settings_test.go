@@ -0,0 +1,118 @@
+package stepsecurityapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationSettingsClient_Create(t *testing.T) {
+	t.Parallel()
+
+	var body map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v1/github/test-org/actions/runs/notification-settings", r.URL.Path)
+		//nolint:errcheck
+		json.NewDecoder(r.Body).Decode(&body)
+	}))
+	defer server.Close()
+
+	slack := "https://hooks.slack.com/x"
+	enabled := true
+	err := newTestClient(server).CreateNotificationSettings(context.Background(), GitHubNotificationSettingsRequest{
+		Owner: "test-org",
+		NotificationSettings: NotificationSettings{
+			SlackWebhookURL:           &slack,
+			NotifyForSecretsDetection: &enabled,
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "test-org", body["owner"])
+	assert.Equal(t, slack, body["slackWebhookURL"])
+	assert.Equal(t, "true", body["notifyForSecretsDetection"])
+	// Unset values clear what is stored.
+	assert.Equal(t, " ", body["teamsWebhookURL"])
+	assert.Equal(t, " ", body["email"])
+	assert.Equal(t, " ", body["slackChannelID"])
+	assert.Equal(t, "false", body["notifyWhenDomainBlocked"])
+}
+
+func TestNotificationSettingsClient_Get(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		//nolint:errcheck
+		w.Write([]byte(`{"slackWebhookURL":"https://hooks.slack.com/x","teamsWebhookURL":" ","email":"","notifyForSecretsDetection":"true","notifyWhenDomainBlocked":"false","slackNotificationMethod":"webhook"}`))
+	}))
+	defer server.Close()
+
+	settings, err := newTestClient(server).GetNotificationSettings(context.Background(), "test-org")
+	require.NoError(t, err)
+
+	require.NotNil(t, settings.SlackWebhookURL)
+	assert.Equal(t, "https://hooks.slack.com/x", *settings.SlackWebhookURL)
+	assert.Nil(t, settings.TeamsWebhookURL)
+	assert.Nil(t, settings.Email)
+	assert.Nil(t, settings.SlackChannelID)
+	require.NotNil(t, settings.NotifyForSecretsDetection)
+	assert.True(t, *settings.NotifyForSecretsDetection)
+	require.NotNil(t, settings.NotifyWhenDomainBlocked)
+	assert.False(t, *settings.NotifyWhenDomainBlocked)
+	assert.Nil(t, settings.NotifyOnFileOverwrite)
+	require.NotNil(t, settings.SlackNotificationMethod)
+	assert.Equal(t, "webhook", *settings.SlackNotificationMethod)
+}
+
+func TestNotificationSettingsClient_Delete(t *testing.T) {
+	t.Parallel()
+
+	var body map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		//nolint:errcheck
+		json.NewDecoder(r.Body).Decode(&body)
+	}))
+	defer server.Close()
+
+	require.NoError(t, newTestClient(server).DeleteNotificationSettings(context.Background(), "test-org"))
+
+	for key, value := range body {
+		switch key {
+		case "owner":
+			assert.Equal(t, "test-org", value)
+		case "slackWebhookURL", "teamsWebhookURL", "email", "slackNotificationMethod", "slackChannelID":
+			assert.Equal(t, " ", value, key)
+		default:
+			assert.Equal(t, "false", value, key)
+		}
+	}
+	assert.Len(t, body, 21)
+}
+
+func TestNotificationSettingsClient_SendTestNotification(t *testing.T) {
+	t.Parallel()
+
+	var method, path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+		//nolint:errcheck
+		w.Write([]byte(`[{"channel":"slack","delivered":false,"error":"channel_not_found"},{"channel":"email","delivered":true}]`))
+	}))
+	defer server.Close()
+
+	results, err := newTestClient(server).SendTestNotification(context.Background(), "test-org")
+	require.NoError(t, err)
+
+	assert.Equal(t, http.MethodPost, method)
+	assert.Equal(t, "/v1/github/test-org/actions/runs/notification-settings/test", path)
+	assert.Equal(t, []NotificationTestResult{
+		{Channel: "slack", Error: "channel_not_found"},
+		{Channel: "email", Delivered: true},
+	}, results)
+}
@@ -0,0 +1,41 @@
+package stepsecurityapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// ObservedEndpoint is an outbound endpoint Harden-Runner recorded for a workflow.
+type ObservedEndpoint struct {
+	Host string `json:"host"`
+	Port int    `json:"port"`
+	// Jobs lists the jobs of the workflow that called the endpoint.
+	Jobs      []string `json:"jobs,omitempty"`
+	FirstSeen string   `json:"first_seen,omitempty"`
+	LastSeen  string   `json:"last_seen,omitempty"`
+}
+
+// WorkflowObservedEndpoints is the egress baseline Harden-Runner built for a workflow.
+type WorkflowObservedEndpoints struct {
+	Owner     string             `json:"owner"`
+	Repo      string             `json:"repo"`
+	Workflow  string             `json:"workflow"`
+	Endpoints []ObservedEndpoint `json:"endpoints"`
+}
+
+// GetWorkflowObservedEndpoints retrieves the endpoints Harden-Runner observed for a
+// workflow of a repository. workflow is the workflow file name, e.g. ci.yml.
+func (c *APIClient) GetWorkflowObservedEndpoints(ctx context.Context, owner, repo, workflow string) (*WorkflowObservedEndpoints, error) {
+	URI := fmt.Sprintf("%s/v1/github/%s/%s/actions/workflows/%s/observed-endpoints", c.BaseURL, owner, repo, url.PathEscape(workflow))
+	respBody, err := c.get(ctx, URI)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get observed endpoints: %w", err)
+	}
+	var baseline WorkflowObservedEndpoints
+	if err := json.Unmarshal(respBody, &baseline); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal observed endpoints: %w", err)
+	}
+	return &baseline, nil
+}
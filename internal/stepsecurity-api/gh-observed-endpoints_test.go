@@ -0,0 +1,51 @@
+package stepsecurityapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetWorkflowObservedEndpoints(t *testing.T) {
+	t.Parallel()
+
+	var gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		//nolint:errcheck
+		w.Write([]byte(`{"owner":"test-org","repo":"api","workflow":"ci.yml","endpoints":[
+			{"host":"github.com","port":443,"jobs":["build","test"],"first_seen":"2026-01-02T00:00:00Z","last_seen":"2026-03-04T00:00:00Z"},
+			{"host":"registry.npmjs.org","port":443,"jobs":["build"]}
+		]}`))
+	}))
+	defer server.Close()
+
+	baseline, err := newTestClient(server).GetWorkflowObservedEndpoints(context.Background(), "test-org", "api", "ci.yml")
+	require.NoError(t, err)
+
+	assert.Equal(t, "/v1/github/test-org/api/actions/workflows/ci.yml/observed-endpoints", gotPath)
+	require.Len(t, baseline.Endpoints, 2)
+	assert.Equal(t, ObservedEndpoint{
+		Host:      "github.com",
+		Port:      443,
+		Jobs:      []string{"build", "test"},
+		FirstSeen: "2026-01-02T00:00:00Z",
+		LastSeen:  "2026-03-04T00:00:00Z",
+	}, baseline.Endpoints[0])
+}
+
+func TestGetWorkflowObservedEndpoints_Error(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	_, err := newTestClient(server).GetWorkflowObservedEndpoints(context.Background(), "test-org", "api", "ci.yml")
+	assert.ErrorContains(t, err, "failed to get observed endpoints")
+}
@@ -8,8 +8,9 @@ import (
 
 // Hierarchical structure for policy attachments
 type PolicyAttachments struct {
-	Org      *OrgResource `json:"org,omitempty"`
-	Clusters []string     `json:"clusters,omitempty"`
+	Org           *OrgResource   `json:"org,omitempty"`
+	Clusters      []string       `json:"clusters,omitempty"`
+	ClusterScopes []ClusterScope `json:"cluster_scopes,omitempty"`
 }
 
 type OrgResource struct {
@@ -18,6 +19,14 @@ type OrgResource struct {
 	Repos      []RepoResource `json:"repos,omitempty"`
 }
 
+// ClusterScope attaches a policy to some namespaces and runner scale sets of a
+// cluster rather than to the whole cluster.
+type ClusterScope struct {
+	Name            string           `json:"name"` // cluster name
+	Namespaces      []string         `json:"namespaces,omitempty"`
+	RunnerScaleSets []RunnerScaleSet `json:"runner_scale_sets,omitempty"`
+}
+
 type RepoResource struct {
 	Name        string   `json:"name"`                // repo name
 	ApplyToRepo bool     `json:"apply_to_repo"`       // if true, applies to entire repo
@@ -68,6 +77,32 @@ func (c *APIClient) CreateGitHubPolicyStorePolicy(ctx context.Context, policy *G
 	return nil
 }
 
+// UpdateGitHubPolicyStorePolicy replaces the settings of an existing policy.
+// Attachments are managed through AttachGitHubPolicyStorePolicy and are not sent.
+func (c *APIClient) UpdateGitHubPolicyStorePolicy(ctx context.Context, policy *GitHubPolicyStorePolicy) error {
+	if policy == nil {
+		return fmt.Errorf("empty policy provided")
+	}
+
+	policyForUpdate := &GitHubPolicyStorePolicy{
+		Owner:                 policy.Owner,
+		PolicyName:            policy.PolicyName,
+		AllowedEndpoints:      policy.AllowedEndpoints,
+		EgressPolicy:          policy.EgressPolicy,
+		DisableTelemetry:      policy.DisableTelemetry,
+		DisableSudo:           policy.DisableSudo,
+		DisableFileMonitoring: policy.DisableFileMonitoring,
+		Lockdown:              policy.Lockdown,
+	}
+
+	URI := fmt.Sprintf("%s/v1/github/%s/actions/policies/%s", c.BaseURL, policy.Owner, policy.PolicyName)
+	_, err := c.put(ctx, URI, policyForUpdate)
+	if err != nil {
+		return fmt.Errorf("failed to update config: %w", err)
+	}
+	return nil
+}
+
 func (c *APIClient) GetGitHubPolicyStorePolicy(ctx context.Context, owner string, policyName string) (*GitHubPolicyStorePolicy, error) {
 	URI := fmt.Sprintf("%s/v1/github/%s/actions/policies/%s", c.BaseURL, owner, policyName)
 	respBody, err := c.get(ctx, URI)
@@ -81,6 +116,20 @@ func (c *APIClient) GetGitHubPolicyStorePolicy(ctx context.Context, owner string
 	return &policy, nil
 }
 
+// ListGitHubPolicyStorePolicies retrieves all policy store policies of an owner, including their attachments.
+func (c *APIClient) ListGitHubPolicyStorePolicies(ctx context.Context, owner string) ([]GitHubPolicyStorePolicy, error) {
+	URI := fmt.Sprintf("%s/v1/github/%s/actions/policies", c.BaseURL, owner)
+	respBody, err := c.get(ctx, URI)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list policies: %w", err)
+	}
+	var policies []GitHubPolicyStorePolicy
+	if err := json.Unmarshal(respBody, &policies); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal policies: %w", err)
+	}
+	return policies, nil
+}
+
 func (c *APIClient) DeleteGitHubPolicyStorePolicy(ctx context.Context, owner string, policyName string) error {
 	URI := fmt.Sprintf("%s/v1/github/%s/actions/policies/%s", c.BaseURL, owner, policyName)
 	_, err := c.delete(ctx, URI)
@@ -92,8 +141,9 @@ func (c *APIClient) DeleteGitHubPolicyStorePolicy(ctx context.Context, owner str
 
 // New hierarchical attachment request structure
 type GitHubPolicyAttachRequest struct {
-	Org      *OrgResource `json:"org,omitempty"`
-	Clusters []string     `json:"clusters,omitempty"`
+	Org           *OrgResource   `json:"org,omitempty"`
+	Clusters      []string       `json:"clusters,omitempty"`
+	ClusterScopes []ClusterScope `json:"cluster_scopes,omitempty"`
 }
 
 func (c *APIClient) AttachGitHubPolicyStorePolicy(ctx context.Context, owner string, policyName string, request *GitHubPolicyAttachRequest) error {
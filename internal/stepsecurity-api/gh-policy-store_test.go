@@ -0,0 +1,119 @@
+package stepsecurityapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateGitHubPolicyStorePolicy(t *testing.T) {
+	t.Parallel()
+
+	var gotMethod, gotPath string
+	var gotBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		gotPath = r.URL.Path
+		//nolint:errcheck
+		json.NewDecoder(r.Body).Decode(&gotBody)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	err := newTestClient(server).UpdateGitHubPolicyStorePolicy(context.Background(), &GitHubPolicyStorePolicy{
+		Owner:            "test-org",
+		PolicyName:       "test-policy",
+		AllowedEndpoints: []string{"github.com:443"},
+		EgressPolicy:     "block",
+		Lockdown:         &LockdownConfig{Enabled: true, Detections: []string{"Reverse-Shell"}},
+		Attachments:      &PolicyAttachments{Clusters: []string{"cluster-1"}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, http.MethodPut, gotMethod)
+	assert.Equal(t, "/v1/github/test-org/actions/policies/test-policy", gotPath)
+	assert.Equal(t, "block", gotBody["egress_policy"])
+	assert.NotContains(t, gotBody, "attachments")
+}
+
+func TestUpdateGitHubPolicyStorePolicy_Errors(t *testing.T) {
+	t.Parallel()
+
+	err := (&APIClient{}).UpdateGitHubPolicyStorePolicy(context.Background(), nil)
+	assert.ErrorContains(t, err, "empty policy provided")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	err = newTestClient(server).UpdateGitHubPolicyStorePolicy(context.Background(), &GitHubPolicyStorePolicy{
+		Owner:      "test-org",
+		PolicyName: "missing",
+	})
+	assert.ErrorContains(t, err, "failed to update config")
+}
+
+func TestListGitHubPolicyStorePolicies(t *testing.T) {
+	t.Parallel()
+
+	var gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		//nolint:errcheck
+		w.Write([]byte(`[
+			{"owner":"test-org","policyName":"audit-all","allowed_endpoints":["github.com:443"],"egress_policy":"audit"},
+			{"owner":"test-org","policyName":"locked","allowed_endpoints":["github.com:443"],"egress_policy":"block",
+			 "lockdown":{"enabled":true,"detections":["Reverse-Shell"]},
+			 "attachments":{"org":{"name":"test-org","apply_to_org":false,"repos":[{"name":"api","apply_to_repo":false,"workflows":["ci.yml"]}]},"clusters":["prod"]}}
+		]`))
+	}))
+	defer server.Close()
+
+	policies, err := newTestClient(server).ListGitHubPolicyStorePolicies(context.Background(), "test-org")
+	require.NoError(t, err)
+
+	assert.Equal(t, "/v1/github/test-org/actions/policies", gotPath)
+	require.Len(t, policies, 2)
+	assert.Equal(t, "audit-all", policies[0].PolicyName)
+	assert.Nil(t, policies[0].Attachments)
+	assert.Equal(t, []string{"Reverse-Shell"}, policies[1].Lockdown.Detections)
+	assert.Equal(t, []string{"ci.yml"}, policies[1].Attachments.Org.Repos[0].Workflows)
+	assert.Equal(t, []string{"prod"}, policies[1].Attachments.Clusters)
+}
+
+func TestAttachGitHubPolicyStorePolicy_ClusterScopes(t *testing.T) {
+	t.Parallel()
+
+	var gotPath string
+	var gotBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		//nolint:errcheck
+		json.NewDecoder(r.Body).Decode(&gotBody)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	err := newTestClient(server).AttachGitHubPolicyStorePolicy(context.Background(), "test-org", "test-policy", &GitHubPolicyAttachRequest{
+		ClusterScopes: []ClusterScope{{
+			Name:            "prod",
+			Namespaces:      []string{"payments"},
+			RunnerScaleSets: []RunnerScaleSet{{Name: "arc-runners", Namespace: "arc"}},
+		}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "/v1/github/test-org/actions/policies/test-policy/attach", gotPath)
+	assert.NotContains(t, gotBody, "clusters")
+	assert.Equal(t, []any{map[string]any{
+		"name":              "prod",
+		"namespaces":        []any{"payments"},
+		"runner_scale_sets": []any{map[string]any{"name": "arc-runners", "namespace": "arc"}},
+	}}, gotBody["cluster_scopes"])
+}
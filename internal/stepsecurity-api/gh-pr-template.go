@@ -6,11 +6,32 @@ import (
 	"fmt"
 )
 
+// PRTemplateSecurityFixesPlaceholder is replaced with the list of security fixes in the
+// summary of a policy-driven PR. Without it the PR doesn't describe its changes.
+const PRTemplateSecurityFixesPlaceholder = "{{STEPSECURITY_SECURITY_FIXES}}"
+
+// PRTemplatePlaceholders lists the placeholders StepSecurity substitutes in PR templates.
+var PRTemplatePlaceholders = []string{
+	PRTemplateSecurityFixesPlaceholder,
+}
+
 type GitHubPRTemplate struct {
 	Title         string   `json:"title"`
 	Summary       string   `json:"summary"`
 	CommitMessage string   `json:"commit_message"`
 	Labels        []string `json:"labels,omitempty"`
+	// Reviewers and Assignees are GitHub usernames; TeamReviewers are team slugs of
+	// the owner.
+	Reviewers     []string `json:"reviewers,omitempty"`
+	TeamReviewers []string `json:"team_reviewers,omitempty"`
+	Assignees     []string `json:"assignees,omitempty"`
+	Draft         bool     `json:"draft"`
+	// BranchPrefix is prepended to the name of the head branch of each PR.
+	BranchPrefix string `json:"branch_prefix,omitempty"`
+	// SignOff appends a Signed-off-by trailer to the commit message, and each of
+	// CoAuthors, formatted as "Name <email>", a Co-authored-by trailer.
+	SignOff   bool     `json:"sign_off"`
+	CoAuthors []string `json:"co_authors,omitempty"`
 }
 
 func (c *APIClient) GetGitHubPRTemplate(ctx context.Context, owner string) (*GitHubPRTemplate, error) {
@@ -38,19 +59,81 @@ func (c *APIClient) UpdateGitHubPRTemplate(ctx context.Context, owner string, te
 	return nil
 }
 
-func (c *APIClient) DeleteGitHubPRTemplate(ctx context.Context, owner string) error {
-	// Reset PR template to default values
-	template := GitHubPRTemplate{
-		Title:         "[StepSecurity] Apply security best practices",
-		Summary:       "## Summary\n\nThis pull request has been generated by [StepSecurity](https://int1.stepsecurity.io/github/shubham-stepsecurity/actions/dashboard) as part of your enterprise subscription to ensure compliance with recommended security best practices. Please review and merge the pull request to apply these security enhancements.\n\n## Security Fixes\n\n{{STEPSECURITY_SECURITY_FIXES}}\n\n## Feedback\nFor bug reports, feature requests, and general feedback; please create an issue in [step-security/secure-repo](https://github.com/step-security/secure-repo) or contact us via [our website](https://www.stepsecurity.io/).\n\n\n",
-		CommitMessage: "[StepSecurity] Apply security best practices\n\nSigned-off-by: StepSecurity Bot <bot@stepsecurity.io>",
-		Labels:        []string{},
+// GetDefaultGitHubPRTemplate retrieves StepSecurity's default PR template for the
+// owner, which is used until the owner configures one.
+func (c *APIClient) GetDefaultGitHubPRTemplate(ctx context.Context, owner string) (*GitHubPRTemplate, error) {
+	URI := fmt.Sprintf("%s/v1/github/%s/pr-template/default", c.BaseURL, owner)
+	respBody, err := c.get(ctx, URI)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get default PR template: %w", err)
+	}
+
+	var template GitHubPRTemplate
+	if err := json.Unmarshal(respBody, &template); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal default PR template: %w", err)
 	}
 
-	err := c.UpdateGitHubPRTemplate(ctx, owner, template)
+	return &template, nil
+}
+
+// DeleteGitHubPRTemplate resets the owner's PR template to StepSecurity's default.
+// When the API has no default template endpoint, the template is deleted instead so
+// that the server falls back to its default; a 404 from both is returned as is and
+// can be checked with IsNotFound.
+func (c *APIClient) DeleteGitHubPRTemplate(ctx context.Context, owner string) error {
+	template, err := c.GetDefaultGitHubPRTemplate(ctx, owner)
+	if IsNotFound(err) {
+		URI := fmt.Sprintf("%s/v1/github/%s/pr-template", c.BaseURL, owner)
+		if _, err := c.delete(ctx, URI); err != nil {
+			return fmt.Errorf("failed to delete PR template: %w", err)
+		}
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf("failed to delete PR template: %w", err)
 	}
 
+	if err := c.UpdateGitHubPRTemplate(ctx, owner, *template); err != nil {
+		return fmt.Errorf("failed to delete PR template: %w", err)
+	}
+
+	return nil
+}
+
+// GetRepoGitHubPRTemplate retrieves the PR template of a repository, which overrides
+// the owner's template. The API responds with a 404 when the repository has none.
+func (c *APIClient) GetRepoGitHubPRTemplate(ctx context.Context, owner, repo string) (*GitHubPRTemplate, error) {
+	URI := fmt.Sprintf("%s/v1/github/%s/%s/pr-template", c.BaseURL, owner, repo)
+	respBody, err := c.get(ctx, URI)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo PR template: %w", err)
+	}
+
+	var template GitHubPRTemplate
+	if err := json.Unmarshal(respBody, &template); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal repo PR template: %w", err)
+	}
+
+	return &template, nil
+}
+
+// UpdateRepoGitHubPRTemplate creates or replaces the PR template of a repository.
+func (c *APIClient) UpdateRepoGitHubPRTemplate(ctx context.Context, owner, repo string, template GitHubPRTemplate) error {
+	URI := fmt.Sprintf("%s/v1/github/%s/%s/pr-template", c.BaseURL, owner, repo)
+	if _, err := c.post(ctx, URI, template); err != nil {
+		return fmt.Errorf("failed to update repo PR template: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteRepoGitHubPRTemplate removes the PR template of a repository, so that its
+// policy-driven PRs use the owner's template again.
+func (c *APIClient) DeleteRepoGitHubPRTemplate(ctx context.Context, owner, repo string) error {
+	URI := fmt.Sprintf("%s/v1/github/%s/%s/pr-template", c.BaseURL, owner, repo)
+	if _, err := c.delete(ctx, URI); err != nil {
+		return fmt.Errorf("failed to delete repo PR template: %w", err)
+	}
+
 	return nil
 }
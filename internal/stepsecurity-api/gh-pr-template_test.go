@@ -0,0 +1,196 @@
+package stepsecurityapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDefaultPRTemplate = `{"title":"[StepSecurity] Apply security best practices","summary":"## Security Fixes\n\n{{STEPSECURITY_SECURITY_FIXES}}","commit_message":"[StepSecurity] Apply security best practices","labels":[]}`
+
+func TestGitHubPRTemplateClient_GetDefault(t *testing.T) {
+	t.Parallel()
+
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		//nolint:errcheck
+		w.Write([]byte(testDefaultPRTemplate))
+	}))
+	defer server.Close()
+
+	template, err := newTestClient(server).GetDefaultGitHubPRTemplate(context.Background(), "test-org")
+	require.NoError(t, err)
+
+	assert.Equal(t, "/v1/github/test-org/pr-template/default", path)
+	assert.Equal(t, "[StepSecurity] Apply security best practices", template.Title)
+	assert.Equal(t, "## Security Fixes\n\n"+PRTemplateSecurityFixesPlaceholder, template.Summary)
+}
+
+func TestGitHubPRTemplateClient_DeleteRestoresDefault(t *testing.T) {
+	t.Parallel()
+
+	var posted map[string]any
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		if r.Method == http.MethodGet {
+			//nolint:errcheck
+			w.Write([]byte(testDefaultPRTemplate))
+			return
+		}
+		//nolint:errcheck
+		json.NewDecoder(r.Body).Decode(&posted)
+	}))
+	defer server.Close()
+
+	require.NoError(t, newTestClient(server).DeleteGitHubPRTemplate(context.Background(), "test-org"))
+
+	assert.Equal(t, []string{
+		"GET /v1/github/test-org/pr-template/default",
+		"POST /v1/github/test-org/pr-template",
+	}, calls)
+	assert.Equal(t, "[StepSecurity] Apply security best practices", posted["title"])
+	assert.Equal(t, "## Security Fixes\n\n"+PRTemplateSecurityFixesPlaceholder, posted["summary"])
+}
+
+func TestGitHubPRTemplateClient_DeleteWithoutDefault(t *testing.T) {
+	t.Parallel()
+
+	var posts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			posts++
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	err := newTestClient(server).DeleteGitHubPRTemplate(context.Background(), "test-org")
+	require.Error(t, err)
+	assert.Zero(t, posts)
+}
+
+func TestGitHubPRTemplateClient_DeleteFallsBackToDelete(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name         string
+		deleteStatus int
+		expectedErr  bool
+	}{
+		{name: "deleted", deleteStatus: http.StatusNoContent},
+		{name: "unsupported", deleteStatus: http.StatusNotFound, expectedErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls = append(calls, r.Method+" "+r.URL.Path)
+				if r.Method == http.MethodDelete {
+					w.WriteHeader(tc.deleteStatus)
+					return
+				}
+				w.WriteHeader(http.StatusNotFound)
+			}))
+			defer server.Close()
+
+			err := newTestClient(server).DeleteGitHubPRTemplate(context.Background(), "test-org")
+			assert.Equal(t, []string{
+				"GET /v1/github/test-org/pr-template/default",
+				"DELETE /v1/github/test-org/pr-template",
+			}, calls)
+			if tc.expectedErr {
+				assert.True(t, IsNotFound(err), "err: %v", err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestGitHubPRTemplate_JSON(t *testing.T) {
+	t.Parallel()
+
+	body, err := json.Marshal(GitHubPRTemplate{
+		Title:         "ci: apply security best practices",
+		Summary:       PRTemplateSecurityFixesPlaceholder,
+		CommitMessage: "ci: apply security best practices",
+		Reviewers:     []string{"octocat"},
+		TeamReviewers: []string{"platform-security"},
+		Draft:         true,
+		BranchPrefix:  "security/",
+		CoAuthors:     []string{"Mona Lisa <mona@example.com>"},
+	})
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"title": "ci: apply security best practices",
+		"summary": "{{STEPSECURITY_SECURITY_FIXES}}",
+		"commit_message": "ci: apply security best practices",
+		"reviewers": ["octocat"],
+		"team_reviewers": ["platform-security"],
+		"draft": true,
+		"branch_prefix": "security/",
+		"sign_off": false,
+		"co_authors": ["Mona Lisa <mona@example.com>"]
+	}`, string(body))
+}
+
+func TestRepoGitHubPRTemplateClient(t *testing.T) {
+	t.Parallel()
+
+	var calls []string
+	var posted map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		switch r.Method {
+		case http.MethodGet:
+			//nolint:errcheck
+			w.Write([]byte(`{"title":"fix(payments): apply security best practices","summary":"{{STEPSECURITY_SECURITY_FIXES}}","commit_message":"fix(payments): harden workflows","labels":["team:payments"],"team_reviewers":["payments"]}`))
+		case http.MethodPost:
+			//nolint:errcheck
+			json.NewDecoder(r.Body).Decode(&posted)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	ctx := context.Background()
+
+	template, err := client.GetRepoGitHubPRTemplate(ctx, "test-org", "monorepo")
+	require.NoError(t, err)
+	assert.Equal(t, "fix(payments): apply security best practices", template.Title)
+	assert.Equal(t, []string{"payments"}, template.TeamReviewers)
+
+	require.NoError(t, client.UpdateRepoGitHubPRTemplate(ctx, "test-org", "monorepo", *template))
+	require.NoError(t, client.DeleteRepoGitHubPRTemplate(ctx, "test-org", "monorepo"))
+
+	assert.Equal(t, []string{
+		"GET /v1/github/test-org/monorepo/pr-template",
+		"POST /v1/github/test-org/monorepo/pr-template",
+		"DELETE /v1/github/test-org/monorepo/pr-template",
+	}, calls)
+	assert.Equal(t, []any{"team:payments"}, posted["labels"])
+}
+
+func TestRepoGitHubPRTemplateClient_NotFound(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	_, err := newTestClient(server).GetRepoGitHubPRTemplate(context.Background(), "test-org", "monorepo")
+	assert.True(t, IsNotFound(err), "err: %v", err)
+}
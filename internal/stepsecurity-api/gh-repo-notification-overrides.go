@@ -0,0 +1,62 @@
+package stepsecurityapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// RepoNotificationOverride adjusts the owner's notification settings for a single
+// repository.
+type RepoNotificationOverride struct {
+	// Events turns events on or off for the repository, keyed by the names in
+	// NotificationEvents. Events that are not present follow the owner's settings.
+	Events map[string]bool `json:"events,omitempty"`
+	// DestinationIDs are notification destinations that receive the repository's
+	// events in addition to the owner's channels.
+	DestinationIDs []string `json:"destination_ids,omitempty"`
+	// InheritOwnerChannels reports whether the owner's Slack, Teams and email
+	// channels keep receiving the repository's events.
+	InheritOwnerChannels bool `json:"inherit_owner_channels"`
+}
+
+// GetRepoNotificationOverride retrieves the notification override of a repository.
+// The API responds with a 404 when the repository has none.
+func (c *APIClient) GetRepoNotificationOverride(ctx context.Context, owner, repo string) (*RepoNotificationOverride, error) {
+	uri := fmt.Sprintf("%s/v1/github/%s/%s/actions/runs/notification-settings", c.BaseURL, owner, repo)
+
+	body, err := c.get(ctx, uri)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo notification override: %w", err)
+	}
+
+	var override RepoNotificationOverride
+	if err := json.Unmarshal(body, &override); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal repo notification override: %w", err)
+	}
+
+	return &override, nil
+}
+
+// UpdateRepoNotificationOverride creates or replaces the notification override of a repository.
+func (c *APIClient) UpdateRepoNotificationOverride(ctx context.Context, owner, repo string, override RepoNotificationOverride) error {
+	uri := fmt.Sprintf("%s/v1/github/%s/%s/actions/runs/notification-settings", c.BaseURL, owner, repo)
+
+	if _, err := c.put(ctx, uri, override); err != nil {
+		return fmt.Errorf("failed to update repo notification override: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteRepoNotificationOverride removes the notification override of a repository,
+// so that it follows the owner's settings again.
+func (c *APIClient) DeleteRepoNotificationOverride(ctx context.Context, owner, repo string) error {
+	uri := fmt.Sprintf("%s/v1/github/%s/%s/actions/runs/notification-settings", c.BaseURL, owner, repo)
+
+	if _, err := c.delete(ctx, uri); err != nil {
+		return fmt.Errorf("failed to delete repo notification override: %w", err)
+	}
+
+	return nil
+}
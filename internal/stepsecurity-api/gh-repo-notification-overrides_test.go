@@ -0,0 +1,68 @@
+package stepsecurityapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepoNotificationOverrideClient(t *testing.T) {
+	t.Parallel()
+
+	type call struct {
+		method string
+		path   string
+		body   map[string]any
+	}
+	var calls []call
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c := call{method: r.Method, path: r.URL.Path}
+		//nolint:errcheck
+		json.NewDecoder(r.Body).Decode(&c.body)
+		calls = append(calls, c)
+		//nolint:errcheck
+		w.Write([]byte(`{"events":{"secrets_detected":true,"domain_blocked":false},"destination_ids":["dest-1"],"inherit_owner_channels":true}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	ctx := context.Background()
+
+	override, err := client.GetRepoNotificationOverride(ctx, "test-org", "release")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"secrets_detected": true, "domain_blocked": false}, override.Events)
+	assert.Equal(t, []string{"dest-1"}, override.DestinationIDs)
+	assert.True(t, override.InheritOwnerChannels)
+
+	require.NoError(t, client.UpdateRepoNotificationOverride(ctx, "test-org", "release", RepoNotificationOverride{
+		Events: map[string]bool{"secrets_detected": true},
+	}))
+	require.NoError(t, client.DeleteRepoNotificationOverride(ctx, "test-org", "release"))
+
+	require.Len(t, calls, 3)
+	for i, method := range []string{http.MethodGet, http.MethodPut, http.MethodDelete} {
+		assert.Equal(t, method, calls[i].method)
+		assert.Equal(t, "/v1/github/test-org/release/actions/runs/notification-settings", calls[i].path)
+	}
+	assert.Equal(t, map[string]any{
+		"events":                 map[string]any{"secrets_detected": true},
+		"inherit_owner_channels": false,
+	}, calls[1].body)
+}
+
+func TestRepoNotificationOverrideClient_NotFound(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	_, err := newTestClient(server).GetRepoNotificationOverride(context.Background(), "test-org", "release")
+	assert.True(t, IsNotFound(err), "err: %v", err)
+}
@@ -0,0 +1,29 @@
+package stepsecurityapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Repository is a repository of a GitHub owner with the attributes repository filters
+// select on.
+type Repository struct {
+	Name       string   `json:"name"`
+	Topics     []string `json:"topics,omitempty"`
+	Visibility string   `json:"visibility,omitempty"`
+}
+
+// ListRepositories retrieves the repositories StepSecurity knows for an owner.
+func (c *APIClient) ListRepositories(ctx context.Context, owner string) ([]Repository, error) {
+	URI := fmt.Sprintf("%s/v1/github/%s/repos", c.BaseURL, owner)
+	respBody, err := c.get(ctx, URI)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repositories: %w", err)
+	}
+	var repos []Repository
+	if err := json.Unmarshal(respBody, &repos); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal repositories: %w", err)
+	}
+	return repos, nil
+}
@@ -4,6 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -20,6 +24,64 @@ type RunPolicy struct {
 	AllRepos      bool            `json:"all_repos,omitempty"`
 	AllOrgs       bool            `json:"all_orgs,omitempty"`
 	Repositories  []string        `json:"repositories,omitempty"`
+	// RepositoryFilter narrows AllRepos to the repositories matching it. It is resolved
+	// server-side, so repositories created later are picked up automatically. When the
+	// API does not support filters, CreateRunPolicy and UpdateRunPolicy save the matching
+	// repositories in Repositories instead.
+	RepositoryFilter *RunPolicyRepositoryFilter `json:"repository_filter,omitempty"`
+}
+
+// RunPolicyRepositoryFilter selects repositories by name pattern, topic and visibility.
+// A repository matches when it matches at least one include pattern (every repository
+// when none are set), has at least one of the topics, has one of the visibilities, and
+// matches no exclude pattern. Empty criteria do not filter.
+type RunPolicyRepositoryFilter struct {
+	IncludePatterns []string `json:"include_patterns,omitempty"`
+	ExcludePatterns []string `json:"exclude_patterns,omitempty"`
+	Topics          []string `json:"topics,omitempty"`
+	Visibility      []string `json:"visibility,omitempty"`
+}
+
+// MatchesName reports whether a repository name passes the include and exclude patterns
+// of f. A nil filter matches every repository.
+func (f *RunPolicyRepositoryFilter) MatchesName(name string) bool {
+	if f == nil {
+		return true
+	}
+	if matchesAnyRepositoryPattern(f.ExcludePatterns, name) {
+		return false
+	}
+	return len(f.IncludePatterns) == 0 || matchesAnyRepositoryPattern(f.IncludePatterns, name)
+}
+
+// Matches reports whether repo is selected by f.
+func (f *RunPolicyRepositoryFilter) Matches(repo Repository) bool {
+	if !f.MatchesName(repo.Name) {
+		return false
+	}
+	if f == nil {
+		return true
+	}
+	if len(f.Topics) > 0 && !slices.ContainsFunc(repo.Topics, func(topic string) bool {
+		return slices.Contains(f.Topics, strings.ToLower(topic))
+	}) {
+		return false
+	}
+	return len(f.Visibility) == 0 || slices.Contains(f.Visibility, strings.ToLower(repo.Visibility))
+}
+
+func matchesAnyRepositoryPattern(patterns []string, repo string) bool {
+	for _, pattern := range patterns {
+		if matched, err := path.Match(strings.ToLower(pattern), strings.ToLower(repo)); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// RunPolicyCapabilities lists the optional run policy features the API supports.
+type RunPolicyCapabilities struct {
+	RepositoryFilter bool `json:"repository_filter"`
 }
 
 type RunPolicyConfig struct {
@@ -50,19 +112,21 @@ type RunPolicyConfig struct {
 }
 
 type CreateRunPolicyRequest struct {
-	Name         string          `json:"name"`
-	PolicyConfig RunPolicyConfig `json:"policy_config"`
-	AllRepos     bool            `json:"all_repos"`
-	AllOrgs      bool            `json:"all_orgs"`
-	Repositories []string        `json:"repositories"`
+	Name             string                     `json:"name"`
+	PolicyConfig     RunPolicyConfig            `json:"policy_config"`
+	AllRepos         bool                       `json:"all_repos"`
+	AllOrgs          bool                       `json:"all_orgs"`
+	Repositories     []string                   `json:"repositories"`
+	RepositoryFilter *RunPolicyRepositoryFilter `json:"repository_filter,omitempty"`
 }
 
 type UpdateRunPolicyRequest struct {
-	Name         string          `json:"name"`
-	PolicyConfig RunPolicyConfig `json:"policy_config"`
-	AllRepos     bool            `json:"all_repos"`
-	AllOrgs      bool            `json:"all_orgs"`
-	Repositories []string        `json:"repositories"`
+	Name             string                     `json:"name"`
+	PolicyConfig     RunPolicyConfig            `json:"policy_config"`
+	AllRepos         bool                       `json:"all_repos"`
+	AllOrgs          bool                       `json:"all_orgs"`
+	Repositories     []string                   `json:"repositories"`
+	RepositoryFilter *RunPolicyRepositoryFilter `json:"repository_filter,omitempty"`
 }
 
 // ListRunPolicies retrieves all run policies for a given owner
@@ -87,7 +151,12 @@ func (c *APIClient) ListRunPolicies(ctx context.Context, owner string) ([]RunPol
 func (c *APIClient) CreateRunPolicy(ctx context.Context, owner string, policy CreateRunPolicyRequest) (*RunPolicy, error) {
 	uri := fmt.Sprintf("%s/v1/github/%s/actions/run-policies", c.BaseURL, owner)
 
-	body, err := c.post(ctx, uri, policy)
+	request := UpdateRunPolicyRequest(policy)
+	if err := c.expandRepositoryFilter(ctx, owner, &request); err != nil {
+		return nil, err
+	}
+
+	body, err := c.post(ctx, uri, CreateRunPolicyRequest(request))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create run policy: %w", err)
 	}
@@ -123,6 +192,10 @@ func (c *APIClient) GetRunPolicy(ctx context.Context, owner string, policyID str
 func (c *APIClient) UpdateRunPolicy(ctx context.Context, owner string, policyID string, policy UpdateRunPolicyRequest) (*RunPolicy, error) {
 	uri := fmt.Sprintf("%s/v1/github/%s/actions/run-policies/%s", c.BaseURL, owner, policyID)
 
+	if err := c.expandRepositoryFilter(ctx, owner, &policy); err != nil {
+		return nil, err
+	}
+
 	body, err := c.put(ctx, uri, policy)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update run policy: %w", err)
@@ -137,6 +210,72 @@ func (c *APIClient) UpdateRunPolicy(ctx context.Context, owner string, policyID
 	return &updatedPolicy, nil
 }
 
+// GetRunPolicyCapabilities returns the optional run policy features the API supports for
+// owner. An API without a capabilities endpoint supports none of them.
+func (c *APIClient) GetRunPolicyCapabilities(ctx context.Context, owner string) (RunPolicyCapabilities, error) {
+	uri := fmt.Sprintf("%s/v1/github/%s/actions/run-policies/capabilities", c.BaseURL, owner)
+
+	body, err := c.get(ctx, uri)
+	if err != nil {
+		if IsNotFound(err) {
+			return RunPolicyCapabilities{}, nil
+		}
+		return RunPolicyCapabilities{}, fmt.Errorf("failed to get run policy capabilities: %w", err)
+	}
+
+	var capabilities RunPolicyCapabilities
+	if err := json.Unmarshal(body, &capabilities); err != nil {
+		return RunPolicyCapabilities{}, fmt.Errorf("failed to unmarshal run policy capabilities: %w", err)
+	}
+
+	return capabilities, nil
+}
+
+// ResolveRepositoryFilter returns the sorted names of the repositories of owner selected
+// by filter.
+func (c *APIClient) ResolveRepositoryFilter(ctx context.Context, owner string, filter *RunPolicyRepositoryFilter) ([]string, error) {
+	repos, err := c.ListRepositories(ctx, owner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve repository filter: %w", err)
+	}
+
+	names := []string{}
+	for _, repo := range repos {
+		if filter.Matches(repo) {
+			names = append(names, repo.Name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// expandRepositoryFilter replaces the repository filter of policy with the repositories
+// it selects when the API does not support filters. This is checked before the policy is
+// written, because an API that dropped the filter would apply the policy to every
+// repository.
+func (c *APIClient) expandRepositoryFilter(ctx context.Context, owner string, policy *UpdateRunPolicyRequest) error {
+	if policy.RepositoryFilter == nil {
+		return nil
+	}
+
+	capabilities, err := c.GetRunPolicyCapabilities(ctx, owner)
+	if err != nil {
+		return err
+	}
+	if capabilities.RepositoryFilter {
+		return nil
+	}
+
+	repos, err := c.ResolveRepositoryFilter(ctx, owner, policy.RepositoryFilter)
+	if err != nil {
+		return err
+	}
+	policy.AllRepos = false
+	policy.Repositories = repos
+	policy.RepositoryFilter = nil
+	return nil
+}
+
 // DeleteRunPolicy deletes a run policy by policy ID
 func (c *APIClient) DeleteRunPolicy(ctx context.Context, owner string, policyID string) error {
 	uri := fmt.Sprintf("%s/v1/github/%s/actions/run-policies/%s", c.BaseURL, owner, policyID)
@@ -0,0 +1,171 @@
+package stepsecurityapi
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runPolicyServer serves run policies and the repositories of test-org. When
+// filterSupported is false it behaves like an API without repository filter support.
+type runPolicyServer struct {
+	mu              sync.Mutex
+	filterSupported bool
+	writes          []map[string]json.RawMessage
+}
+
+func (s *runPolicyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case strings.HasSuffix(r.URL.Path, "/run-policies/capabilities"):
+		if !s.filterSupported {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		//nolint:errcheck
+		w.Write([]byte(`{"repository_filter": true}`))
+	case strings.HasSuffix(r.URL.Path, "/repos"):
+		//nolint:errcheck
+		json.NewEncoder(w).Encode([]Repository{
+			{Name: "svc-payments", Topics: []string{"payments"}, Visibility: "private"},
+			{Name: "svc-orders", Visibility: "private"},
+			{Name: "svc-legacy", Topics: []string{"payments"}, Visibility: "private"},
+			{Name: "website", Topics: []string{"payments"}, Visibility: "public"},
+		})
+	case r.Method == http.MethodPost || r.Method == http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(body, &fields); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.writes = append(s.writes, fields)
+
+		var req UpdateRunPolicyRequest
+		//nolint:errcheck
+		json.Unmarshal(body, &req)
+		//nolint:errcheck
+		json.NewEncoder(w).Encode(RunPolicy{
+			PolicyID:     "policy-1",
+			Name:         req.Name,
+			PolicyConfig: req.PolicyConfig,
+			AllRepos:     req.AllRepos,
+			Repositories: req.Repositories,
+		})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func testRunPolicyFilterRequest() CreateRunPolicyRequest {
+	return CreateRunPolicyRequest{
+		Name:         "svc policy",
+		PolicyConfig: RunPolicyConfig{Owner: "test-org", Name: "svc policy", EnableSecretsPolicy: true},
+		AllRepos:     true,
+		RepositoryFilter: &RunPolicyRepositoryFilter{
+			IncludePatterns: []string{"svc-*"},
+			ExcludePatterns: []string{"svc-legacy"},
+		},
+	}
+}
+
+func TestCreateRunPolicy_RepositoryFilter(t *testing.T) {
+	t.Parallel()
+
+	backend := &runPolicyServer{filterSupported: true}
+	server := httptest.NewServer(backend)
+	defer server.Close()
+
+	_, err := newTestClient(server).CreateRunPolicy(context.Background(), "test-org", testRunPolicyFilterRequest())
+	require.NoError(t, err)
+
+	require.Len(t, backend.writes, 1)
+	assert.JSONEq(t, `true`, string(backend.writes[0]["all_repos"]))
+	assert.JSONEq(t, `{"include_patterns": ["svc-*"], "exclude_patterns": ["svc-legacy"]}`, string(backend.writes[0]["repository_filter"]))
+}
+
+func TestCreateRunPolicy_RepositoryFilterUnsupported(t *testing.T) {
+	t.Parallel()
+
+	backend := &runPolicyServer{}
+	server := httptest.NewServer(backend)
+	defer server.Close()
+
+	policy, err := newTestClient(server).CreateRunPolicy(context.Background(), "test-org", testRunPolicyFilterRequest())
+	require.NoError(t, err)
+
+	// The only write carries the expanded repository list, never all_repos = true.
+	require.Len(t, backend.writes, 1)
+	assert.JSONEq(t, `false`, string(backend.writes[0]["all_repos"]))
+	assert.JSONEq(t, `["svc-orders", "svc-payments"]`, string(backend.writes[0]["repositories"]))
+	assert.NotContains(t, backend.writes[0], "repository_filter")
+	assert.Equal(t, []string{"svc-orders", "svc-payments"}, policy.Repositories)
+}
+
+func TestUpdateRunPolicy_RepositoryFilterUnsupported(t *testing.T) {
+	t.Parallel()
+
+	backend := &runPolicyServer{}
+	server := httptest.NewServer(backend)
+	defer server.Close()
+
+	request := UpdateRunPolicyRequest(testRunPolicyFilterRequest())
+	request.RepositoryFilter = &RunPolicyRepositoryFilter{Topics: []string{"payments"}, Visibility: []string{"private"}}
+	_, err := newTestClient(server).UpdateRunPolicy(context.Background(), "test-org", "policy-1", request)
+	require.NoError(t, err)
+
+	require.Len(t, backend.writes, 1)
+	assert.JSONEq(t, `false`, string(backend.writes[0]["all_repos"]))
+	assert.JSONEq(t, `["svc-legacy", "svc-payments"]`, string(backend.writes[0]["repositories"]))
+	assert.NotContains(t, backend.writes[0], "repository_filter")
+}
+
+func TestUpdateRunPolicy_WithoutRepositoryFilter(t *testing.T) {
+	t.Parallel()
+
+	backend := &runPolicyServer{}
+	server := httptest.NewServer(backend)
+	defer server.Close()
+
+	request := UpdateRunPolicyRequest(testRunPolicyFilterRequest())
+	request.RepositoryFilter = nil
+	_, err := newTestClient(server).UpdateRunPolicy(context.Background(), "test-org", "policy-1", request)
+	require.NoError(t, err)
+
+	// Without a filter the field is left out, so APIs that do not know it never see it.
+	require.Len(t, backend.writes, 1)
+	assert.JSONEq(t, `true`, string(backend.writes[0]["all_repos"]))
+	assert.NotContains(t, backend.writes[0], "repository_filter")
+}
+
+func TestRunPolicyRepositoryFilter_Matches(t *testing.T) {
+	t.Parallel()
+
+	filter := &RunPolicyRepositoryFilter{
+		IncludePatterns: []string{"svc-*", "*-api"},
+		ExcludePatterns: []string{"svc-legacy*"},
+	}
+
+	assert.True(t, (*RunPolicyRepositoryFilter)(nil).MatchesName("anything"))
+	assert.True(t, filter.MatchesName("svc-payments"))
+	assert.True(t, filter.MatchesName("Billing-API"))
+	assert.False(t, filter.MatchesName("svc-legacy-orders"))
+	assert.False(t, filter.MatchesName("website"))
+	// Without include patterns every repository not excluded matches by name.
+	assert.True(t, (&RunPolicyRepositoryFilter{Topics: []string{"payments"}}).MatchesName("website"))
+
+	topics := &RunPolicyRepositoryFilter{Topics: []string{"payments"}, Visibility: []string{"private"}}
+	assert.True(t, topics.Matches(Repository{Name: "svc-payments", Topics: []string{"go", "payments"}, Visibility: "private"}))
+	assert.False(t, topics.Matches(Repository{Name: "svc-orders", Topics: []string{"go"}, Visibility: "private"}))
+	assert.False(t, topics.Matches(Repository{Name: "website", Topics: []string{"payments"}, Visibility: "public"}))
+}
@@ -58,6 +58,46 @@ func (m *MockStepSecurityClient) DeleteNotificationSettings(ctx context.Context,
 	return args.Error(0)
 }
 
+func (m *MockStepSecurityClient) SendTestNotification(ctx context.Context, owner string) ([]NotificationTestResult, error) {
+	args := m.Called(ctx, owner)
+	return args.Get(0).([]NotificationTestResult), args.Error(1)
+}
+
+func (m *MockStepSecurityClient) CreateNotificationDestination(ctx context.Context, owner string, destination NotificationDestination) (*NotificationDestination, error) {
+	args := m.Called(ctx, owner, destination)
+	return args.Get(0).(*NotificationDestination), args.Error(1)
+}
+
+func (m *MockStepSecurityClient) GetNotificationDestination(ctx context.Context, owner string, destinationID string) (*NotificationDestination, error) {
+	args := m.Called(ctx, owner, destinationID)
+	return args.Get(0).(*NotificationDestination), args.Error(1)
+}
+
+func (m *MockStepSecurityClient) UpdateNotificationDestination(ctx context.Context, owner string, destination NotificationDestination) error {
+	args := m.Called(ctx, owner, destination)
+	return args.Error(0)
+}
+
+func (m *MockStepSecurityClient) DeleteNotificationDestination(ctx context.Context, owner string, destinationID string) error {
+	args := m.Called(ctx, owner, destinationID)
+	return args.Error(0)
+}
+
+func (m *MockStepSecurityClient) GetRepoNotificationOverride(ctx context.Context, owner, repo string) (*RepoNotificationOverride, error) {
+	args := m.Called(ctx, owner, repo)
+	return args.Get(0).(*RepoNotificationOverride), args.Error(1)
+}
+
+func (m *MockStepSecurityClient) UpdateRepoNotificationOverride(ctx context.Context, owner, repo string, override RepoNotificationOverride) error {
+	args := m.Called(ctx, owner, repo, override)
+	return args.Error(0)
+}
+
+func (m *MockStepSecurityClient) DeleteRepoNotificationOverride(ctx context.Context, owner, repo string) error {
+	args := m.Called(ctx, owner, repo)
+	return args.Error(0)
+}
+
 // Policy-driven PR methods
 func (m *MockStepSecurityClient) CreatePolicyDrivenPRPolicy(ctx context.Context, req PolicyDrivenPRPolicy) error {
 	args := m.Called(ctx, req)
@@ -97,11 +137,21 @@ func (m *MockStepSecurityClient) CreateGitHubPolicyStorePolicy(ctx context.Conte
 	return args.Error(0)
 }
 
+func (m *MockStepSecurityClient) UpdateGitHubPolicyStorePolicy(ctx context.Context, policy *GitHubPolicyStorePolicy) error {
+	args := m.Called(ctx, policy)
+	return args.Error(0)
+}
+
 func (m *MockStepSecurityClient) GetGitHubPolicyStorePolicy(ctx context.Context, owner string, policyName string) (*GitHubPolicyStorePolicy, error) {
 	args := m.Called(ctx, owner, policyName)
 	return args.Get(0).(*GitHubPolicyStorePolicy), args.Error(1)
 }
 
+func (m *MockStepSecurityClient) ListGitHubPolicyStorePolicies(ctx context.Context, owner string) ([]GitHubPolicyStorePolicy, error) {
+	args := m.Called(ctx, owner)
+	return args.Get(0).([]GitHubPolicyStorePolicy), args.Error(1)
+}
+
 func (m *MockStepSecurityClient) DeleteGitHubPolicyStorePolicy(ctx context.Context, owner string, policyName string) error {
 	args := m.Called(ctx, owner, policyName)
 	return args.Error(0)
@@ -117,6 +167,16 @@ func (m *MockStepSecurityClient) DetachGitHubPolicyStorePolicy(ctx context.Conte
 	return args.Error(0)
 }
 
+func (m *MockStepSecurityClient) GetWorkflowObservedEndpoints(ctx context.Context, owner, repo, workflow string) (*WorkflowObservedEndpoints, error) {
+	args := m.Called(ctx, owner, repo, workflow)
+	return args.Get(0).(*WorkflowObservedEndpoints), args.Error(1)
+}
+
+func (m *MockStepSecurityClient) ListClusters(ctx context.Context, owner string) ([]Cluster, error) {
+	args := m.Called(ctx, owner)
+	return args.Get(0).([]Cluster), args.Error(1)
+}
+
 func (m *MockStepSecurityClient) CreateSuppressionRule(ctx context.Context, rule SuppressionRule) (*SuppressionRule, error) {
 	args := m.Called(ctx, rule)
 	return args.Get(0).(*SuppressionRule), args.Error(1)
@@ -163,6 +223,11 @@ func (m *MockStepSecurityClient) DeleteRunPolicy(ctx context.Context, owner stri
 	return args.Error(0)
 }
 
+func (m *MockStepSecurityClient) ResolveRepositoryFilter(ctx context.Context, owner string, filter *RunPolicyRepositoryFilter) ([]string, error) {
+	args := m.Called(ctx, owner, filter)
+	return args.Get(0).([]string), args.Error(1)
+}
+
 // GitHub PR Checks methods
 func (m *MockStepSecurityClient) GetPRChecksConfig(ctx context.Context, owner string) (GitHubPRChecksConfig, error) {
 	args := m.Called(ctx, owner)
@@ -179,12 +244,22 @@ func (m *MockStepSecurityClient) DeletePRChecksConfig(ctx context.Context, owner
 	return args.Error(0)
 }
 
+func (m *MockStepSecurityClient) GetChecksControlCatalog(ctx context.Context, owner string) (ControlCatalog, error) {
+	args := m.Called(ctx, owner)
+	return args.Get(0).(ControlCatalog), args.Error(1)
+}
+
 // GitHub PR Template methods
 func (m *MockStepSecurityClient) GetGitHubPRTemplate(ctx context.Context, owner string) (*GitHubPRTemplate, error) {
 	args := m.Called(ctx, owner)
 	return args.Get(0).(*GitHubPRTemplate), args.Error(1)
 }
 
+func (m *MockStepSecurityClient) GetDefaultGitHubPRTemplate(ctx context.Context, owner string) (*GitHubPRTemplate, error) {
+	args := m.Called(ctx, owner)
+	return args.Get(0).(*GitHubPRTemplate), args.Error(1)
+}
+
 func (m *MockStepSecurityClient) UpdateGitHubPRTemplate(ctx context.Context, owner string, template GitHubPRTemplate) error {
 	args := m.Called(ctx, owner, template)
 	return args.Error(0)
@@ -195,6 +270,21 @@ func (m *MockStepSecurityClient) DeleteGitHubPRTemplate(ctx context.Context, own
 	return args.Error(0)
 }
 
+func (m *MockStepSecurityClient) GetRepoGitHubPRTemplate(ctx context.Context, owner, repo string) (*GitHubPRTemplate, error) {
+	args := m.Called(ctx, owner, repo)
+	return args.Get(0).(*GitHubPRTemplate), args.Error(1)
+}
+
+func (m *MockStepSecurityClient) UpdateRepoGitHubPRTemplate(ctx context.Context, owner, repo string, template GitHubPRTemplate) error {
+	args := m.Called(ctx, owner, repo, template)
+	return args.Error(0)
+}
+
+func (m *MockStepSecurityClient) DeleteRepoGitHubPRTemplate(ctx context.Context, owner, repo string) error {
+	args := m.Called(ctx, owner, repo)
+	return args.Error(0)
+}
+
 // Custom Role methods
 func (m *MockStepSecurityClient) ListRoles(ctx context.Context) ([]Role, error) {
 	args := m.Called(ctx)